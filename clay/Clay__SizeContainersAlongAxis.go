@@ -59,6 +59,10 @@ func Clay__SizeContainersAlongAxis(xAxis bool) {
 		for i := int32(0); i < bfsBuffer.Length(); i++ {
 			parentIndex := Clay__Array_GetValue(&bfsBuffer, i)
 			parent := Clay__Array_Get(&currentContext.LayoutElements, parentIndex)
+			// Unchanged subtrees keep the sizes of the last layout, see Clay_SetIncrementalLayoutEnabled
+			if Clay__ReuseCachedSizes(parent, xAxis) {
+				continue
+			}
 			parentStyleConfig := parent.LayoutConfig
 			growContainerCount := 0
 			var parentSize float32
@@ -75,7 +79,7 @@ func Clay__SizeContainersAlongAxis(xAxis bool) {
 			}
 			var innerContentSize float32
 			totalPaddingAndChildGaps := parentPadding
-			sizingAlongAxis := (xAxis && parentStyleConfig.LayoutDirection == CLAY_LEFT_TO_RIGHT) || (!xAxis && parentStyleConfig.LayoutDirection == CLAY_TOP_TO_BOTTOM)
+			sizingAlongAxis := xAxis == Clay__LayoutDirectionIsHorizontal(parentStyleConfig.LayoutDirection)
 			Clay__Array_Reset(&resizableContainerBuffer)
 			parentChildGap := parentStyleConfig.ChildGap
 
@@ -104,6 +108,10 @@ func Clay__SizeContainersAlongAxis(xAxis bool) {
 				}
 
 				if sizingAlongAxis {
+					// Margins take up space like child gaps, including the margins of percentage containers
+					childMargin := Clay__MarginSizeAlongAxis(childElement, xAxis)
+					innerContentSize += childMargin
+					totalPaddingAndChildGaps += childMargin
 					if childSizing.Type == CLAY__SIZING_TYPE_PERCENT {
 						innerContentSize += 0
 					} else {
@@ -118,7 +126,7 @@ func Clay__SizeContainersAlongAxis(xAxis bool) {
 						totalPaddingAndChildGaps += float32(parentChildGap)
 					}
 				} else {
-					innerContentSize = CLAY__MAX(childSize, innerContentSize)
+					innerContentSize = CLAY__MAX(childSize+Clay__MarginSizeAlongAxis(childElement, xAxis), innerContentSize)
 				}
 
 			}
@@ -137,7 +145,11 @@ func Clay__SizeContainersAlongAxis(xAxis bool) {
 				}
 
 				if childSizing.Type == CLAY__SIZING_TYPE_PERCENT {
-					childSize = (parentSize - totalPaddingAndChildGaps) * childSizing.Size.Percent
+					availableSize := parentSize - totalPaddingAndChildGaps
+					if !sizingAlongAxis {
+						availableSize -= Clay__MarginSizeAlongAxis(childElement, xAxis)
+					}
+					childSize = availableSize * childSizing.Size.Percent
 					if xAxis {
 						childElement.Dimensions.Width = childSize
 					} else {
@@ -149,6 +161,18 @@ func Clay__SizeContainersAlongAxis(xAxis bool) {
 					Clay__UpdateAspectRatioBox(childElement)
 				}
 			}
+			if gridData := Clay__GetGridLayoutData(parent); gridData != nil {
+				Clay__SizeGridChildrenAlongAxis(parent, gridData, xAxis)
+				continue
+			}
+			if Clay__ElementWrapsChildren(parent) {
+				if sizingAlongAxis {
+					Clay__SizeWrappedChildrenAlongAxis(parent, &resizableContainerBuffer, xAxis)
+				} else {
+					Clay__SizeWrappedChildrenAcrossAxis(parent, xAxis)
+				}
+				continue
+			}
 			if sizingAlongAxis {
 				sizeToDistribute := parentSize - parentPadding - innerContentSize
 				// The content is too large, compress the children as much as possible
@@ -161,135 +185,10 @@ func Clay__SizeContainersAlongAxis(xAxis bool) {
 							continue
 						}
 					}
-					// Scrolling containers preferentially compress before others
-					for sizeToDistribute < -CLAY__EPSILON && resizableContainerBuffer.Length() > 0 {
-						var largest float32 = 0
-						var secondLargest float32 = 0
-						var widthToAdd float32 = sizeToDistribute
-						for childIndex := int32(0); childIndex < resizableContainerBuffer.Length(); childIndex++ {
-							child := Clay__Array_Get(&currentContext.LayoutElements, Clay__Array_GetValue(&resizableContainerBuffer, childIndex))
-							var childSize float32
-							if xAxis {
-								childSize = child.Dimensions.Width
-							} else {
-								childSize = child.Dimensions.Height
-							}
-
-							if Clay__FloatEqual(childSize, largest) {
-								continue
-							}
-							if childSize > largest {
-								secondLargest = largest
-								largest = childSize
-							}
-							if childSize < largest {
-								secondLargest = CLAY__MAX(secondLargest, childSize)
-								widthToAdd = secondLargest - largest
-							}
-						}
-
-						widthToAdd = CLAY__MAX(widthToAdd, sizeToDistribute/float32(resizableContainerBuffer.Length()))
-
-						for childIndex := int32(0); childIndex < resizableContainerBuffer.Length(); childIndex++ {
-							child := Clay__Array_Get(&currentContext.LayoutElements, Clay__Array_GetValue(&resizableContainerBuffer, childIndex))
-							var childSize float32
-							var minSize float32
-							if xAxis {
-								childSize = child.Dimensions.Width
-								minSize = child.MinDimensions.Width
-							} else {
-								childSize = child.Dimensions.Height
-								minSize = child.MinDimensions.Height
-							}
-
-							previousWidth := childSize
-							if Clay__FloatEqual(childSize, largest) {
-								childSize += widthToAdd
-								if childSize <= minSize {
-									childSize = minSize
-									Clay__Array_RemoveSwapback(&resizableContainerBuffer, childIndex)
-									childIndex--
-								}
-								if xAxis {
-									child.Dimensions.Width = childSize
-								} else {
-									child.Dimensions.Height = childSize
-								}
-								sizeToDistribute -= (childSize - previousWidth)
-							}
-						}
-					}
+					Clay__CompressChildrenAlongAxis(&resizableContainerBuffer, sizeToDistribute, xAxis)
 					// The content is too small, allow SIZING_GROW containers to expand
 				} else if sizeToDistribute > 0 && growContainerCount > 0 {
-					for childIndex := int32(0); childIndex < resizableContainerBuffer.Length(); childIndex++ {
-						child := Clay__Array_Get(&currentContext.LayoutElements, Clay__Array_GetValue(&resizableContainerBuffer, childIndex))
-
-						var childSizing Clay__SizingType
-						if xAxis {
-							childSizing = child.LayoutConfig.Sizing.Width.Type
-						} else {
-							childSizing = child.LayoutConfig.Sizing.Height.Type
-						}
-						if childSizing != CLAY__SIZING_TYPE_GROW {
-							Clay__Array_RemoveSwapback(&resizableContainerBuffer, childIndex)
-							childIndex--
-						}
-					}
-					for sizeToDistribute > CLAY__EPSILON && resizableContainerBuffer.Length() > 0 {
-						var smallest float32 = CLAY__MAXFLOAT
-						var secondSmallest float32 = CLAY__MAXFLOAT
-						widthToAdd := sizeToDistribute
-						for childIndex := int32(0); childIndex < resizableContainerBuffer.Length(); childIndex++ {
-							child := Clay__Array_Get(&currentContext.LayoutElements, Clay__Array_GetValue(&resizableContainerBuffer, childIndex))
-							var childSize float32
-							if xAxis {
-								childSize = child.Dimensions.Width
-							} else {
-								childSize = child.Dimensions.Height
-							}
-							if Clay__FloatEqual(childSize, smallest) {
-								continue
-							}
-							if childSize < smallest {
-								secondSmallest = smallest
-								smallest = childSize
-							}
-							if childSize > smallest {
-								secondSmallest = CLAY__MIN(secondSmallest, childSize)
-								widthToAdd = secondSmallest - smallest
-							}
-						}
-
-						widthToAdd = CLAY__MIN(widthToAdd, sizeToDistribute/float32(resizableContainerBuffer.Length()))
-
-						for childIndex := int32(0); childIndex < resizableContainerBuffer.Length(); childIndex++ {
-							child := Clay__Array_Get(&currentContext.LayoutElements, Clay__Array_GetValue(&resizableContainerBuffer, childIndex))
-							var childSize float32
-							var maxSize float32
-							if xAxis {
-								childSize = child.Dimensions.Width
-								maxSize = child.LayoutConfig.Sizing.Width.Size.MinMax.Max
-							} else {
-								childSize = child.Dimensions.Height
-								maxSize = child.LayoutConfig.Sizing.Height.Size.MinMax.Max
-							}
-							previousWidth := childSize
-							if Clay__FloatEqual(childSize, smallest) {
-								childSize += widthToAdd
-								if childSize >= maxSize {
-									childSize = maxSize
-									Clay__Array_RemoveSwapback(&resizableContainerBuffer, childIndex)
-									childIndex--
-								}
-								if xAxis {
-									child.Dimensions.Width = childSize
-								} else {
-									child.Dimensions.Height = childSize
-								}
-								sizeToDistribute -= (childSize - previousWidth)
-							}
-						}
-					}
+					Clay__GrowChildrenAlongAxis(&resizableContainerBuffer, sizeToDistribute, xAxis)
 				}
 				// Sizing along the non layout axis ("off axis")
 			} else {
@@ -309,12 +208,13 @@ func Clay__SizeContainersAlongAxis(xAxis bool) {
 						childSize = childElement.Dimensions.Height
 					}
 
-					var maxSize float32 = parentSize - parentPadding
+					childMargin := Clay__MarginSizeAlongAxis(childElement, xAxis)
+					var maxSize float32 = parentSize - parentPadding - childMargin
 					// If we're laying out the children of a scroll panel, grow containers expand to the size of the inner content, not the outer container
 					if Clay__ElementHasConfig(parent, CLAY__ELEMENT_CONFIG_TYPE_CLIP) {
 						clipElementConfig := Clay__FindElementConfigWithType(parent, CLAY__ELEMENT_CONFIG_TYPE_CLIP).ClipElementConfig
 						if (xAxis && clipElementConfig.Horizontal) || (!xAxis && clipElementConfig.Vertical) {
-							maxSize = CLAY__MAX(maxSize, innerContentSize)
+							maxSize = CLAY__MAX(maxSize, innerContentSize-childMargin)
 						}
 					}
 					if childSizing.Type == CLAY__SIZING_TYPE_GROW {
@@ -333,3 +233,147 @@ func Clay__SizeContainersAlongAxis(xAxis bool) {
 
 	}
 }
+
+// Returns the GrowWeight of sizing, treating unset weights as 1.
+func Clay__SizingGrowWeight(sizing Clay_SizingAxis) float32 {
+	if sizing.GrowWeight <= 0 {
+		return 1
+	}
+	return sizing.GrowWeight
+}
+
+// Returns the ShrinkWeight of sizing, treating unset weights as 1.
+func Clay__SizingShrinkWeight(sizing Clay_SizingAxis) float32 {
+	if sizing.ShrinkWeight <= 0 {
+		return 1
+	}
+	return sizing.ShrinkWeight
+}
+
+// Shrinks resizable children until the negative sizeToDistribute has been absorbed or every child has reached its minimum size.
+// Children with the lowest ShrinkPriority are compressed to their minimum size before the next priority is touched. Within a
+// priority the children with the largest size multiplied by ShrinkWeight are compressed first, so compressed siblings settle at
+// sizes inversely proportional to their weights.
+func Clay__CompressChildrenAlongAxis(resizableContainerBuffer *Clay__Array[int32], sizeToDistribute float32, xAxis bool) {
+	currentContext := Clay_GetCurrentContext()
+	// Scrolling containers preferentially compress before others
+	for sizeToDistribute < -CLAY__EPSILON && resizableContainerBuffer.Length() > 0 {
+		var priority uint8 = 255
+		for childIndex := int32(0); childIndex < resizableContainerBuffer.Length(); childIndex++ {
+			child := Clay__Array_Get(&currentContext.LayoutElements, Clay__Array_GetValue(resizableContainerBuffer, childIndex))
+			if childPriority := Clay__SizingAlongAxis(child.LayoutConfig.Sizing, xAxis).ShrinkPriority; childPriority < priority {
+				priority = childPriority
+			}
+		}
+
+		var largest float32 = 0
+		var secondLargest float32 = 0
+		var largestInverseWeight float32 = 0
+		for childIndex := int32(0); childIndex < resizableContainerBuffer.Length(); childIndex++ {
+			child := Clay__Array_Get(&currentContext.LayoutElements, Clay__Array_GetValue(resizableContainerBuffer, childIndex))
+			childSizing := Clay__SizingAlongAxis(child.LayoutConfig.Sizing, xAxis)
+			if childSizing.ShrinkPriority != priority {
+				continue
+			}
+			weight := Clay__SizingShrinkWeight(childSizing)
+			weightedSize := Clay__SizeAlongAxis(child.Dimensions, xAxis) * weight
+
+			if Clay__FloatEqual(weightedSize, largest) {
+				largestInverseWeight += 1 / weight
+				continue
+			}
+			if weightedSize > largest {
+				secondLargest = largest
+				largest = weightedSize
+				largestInverseWeight = 1 / weight
+			} else {
+				secondLargest = CLAY__MAX(secondLargest, weightedSize)
+			}
+		}
+
+		// Shrink the weighted size of the largest children down towards the second largest
+		weightedSizeToAdd := CLAY__MAX(secondLargest-largest, sizeToDistribute/largestInverseWeight)
+
+		for childIndex := int32(0); childIndex < resizableContainerBuffer.Length(); childIndex++ {
+			child := Clay__Array_Get(&currentContext.LayoutElements, Clay__Array_GetValue(resizableContainerBuffer, childIndex))
+			childSizing := Clay__SizingAlongAxis(child.LayoutConfig.Sizing, xAxis)
+			if childSizing.ShrinkPriority != priority {
+				continue
+			}
+			weight := Clay__SizingShrinkWeight(childSizing)
+			childSize := Clay__SizeAlongAxis(child.Dimensions, xAxis)
+			minSize := Clay__SizeAlongAxis(child.MinDimensions, xAxis)
+
+			previousSize := childSize
+			if Clay__FloatEqual(childSize*weight, largest) {
+				childSize += weightedSizeToAdd / weight
+				if childSize <= minSize {
+					childSize = minSize
+					Clay__Array_RemoveSwapback(resizableContainerBuffer, childIndex)
+					childIndex--
+				}
+				Clay__SetSizeAlongAxis(&child.Dimensions, xAxis, childSize)
+				sizeToDistribute -= (childSize - previousSize)
+			}
+		}
+	}
+}
+
+// Expands SIZING_GROW children until sizeToDistribute has been used up or every child has reached its maximum size.
+// The children with the smallest size per unit of GrowWeight are expanded first, so children end up sized in proportion
+// to their weights wherever their content allows it.
+func Clay__GrowChildrenAlongAxis(resizableContainerBuffer *Clay__Array[int32], sizeToDistribute float32, xAxis bool) {
+	currentContext := Clay_GetCurrentContext()
+	for childIndex := int32(0); childIndex < resizableContainerBuffer.Length(); childIndex++ {
+		child := Clay__Array_Get(&currentContext.LayoutElements, Clay__Array_GetValue(resizableContainerBuffer, childIndex))
+		if Clay__SizingAlongAxis(child.LayoutConfig.Sizing, xAxis).Type != CLAY__SIZING_TYPE_GROW {
+			Clay__Array_RemoveSwapback(resizableContainerBuffer, childIndex)
+			childIndex--
+		}
+	}
+	for sizeToDistribute > CLAY__EPSILON && resizableContainerBuffer.Length() > 0 {
+		var smallest float32 = CLAY__MAXFLOAT
+		var secondSmallest float32 = CLAY__MAXFLOAT
+		var smallestWeight float32 = 0
+		for childIndex := int32(0); childIndex < resizableContainerBuffer.Length(); childIndex++ {
+			child := Clay__Array_Get(&currentContext.LayoutElements, Clay__Array_GetValue(resizableContainerBuffer, childIndex))
+			weight := Clay__SizingGrowWeight(Clay__SizingAlongAxis(child.LayoutConfig.Sizing, xAxis))
+			weightedSize := Clay__SizeAlongAxis(child.Dimensions, xAxis) / weight
+
+			if Clay__FloatEqual(weightedSize, smallest) {
+				smallestWeight += weight
+				continue
+			}
+			if weightedSize < smallest {
+				secondSmallest = smallest
+				smallest = weightedSize
+				smallestWeight = weight
+			} else {
+				secondSmallest = CLAY__MIN(secondSmallest, weightedSize)
+			}
+		}
+
+		// Grow the smallest children up towards the second smallest, each in proportion to its weight
+		sizePerWeight := CLAY__MIN(secondSmallest-smallest, sizeToDistribute/smallestWeight)
+
+		for childIndex := int32(0); childIndex < resizableContainerBuffer.Length(); childIndex++ {
+			child := Clay__Array_Get(&currentContext.LayoutElements, Clay__Array_GetValue(resizableContainerBuffer, childIndex))
+			childSizing := Clay__SizingAlongAxis(child.LayoutConfig.Sizing, xAxis)
+			weight := Clay__SizingGrowWeight(childSizing)
+			childSize := Clay__SizeAlongAxis(child.Dimensions, xAxis)
+			maxSize := childSizing.Size.MinMax.Max
+
+			previousSize := childSize
+			if Clay__FloatEqual(childSize/weight, smallest) {
+				childSize += sizePerWeight * weight
+				if childSize >= maxSize {
+					childSize = maxSize
+					Clay__Array_RemoveSwapback(resizableContainerBuffer, childIndex)
+					childIndex--
+				}
+				Clay__SetSizeAlongAxis(&child.Dimensions, xAxis, childSize)
+				sizeToDistribute -= (childSize - previousSize)
+			}
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package clay
+
+// Controls how the space remaining along the layout axis is distributed around child elements.
+type Clay__SpaceDistribution uint8
+
+const (
+	CLAY__SPACE_DISTRIBUTION_NONE Clay__SpaceDistribution = iota
+	CLAY__SPACE_DISTRIBUTION_BETWEEN
+	CLAY__SPACE_DISTRIBUTION_AROUND
+	CLAY__SPACE_DISTRIBUTION_EVENLY
+)
+
+func Clay__SpaceDistributionAlongAxis(childAlignment Clay_ChildAlignment, xAxis bool) Clay__SpaceDistribution {
+	if xAxis {
+		switch childAlignment.X {
+		case CLAY_ALIGN_X_SPACE_BETWEEN:
+			return CLAY__SPACE_DISTRIBUTION_BETWEEN
+		case CLAY_ALIGN_X_SPACE_AROUND:
+			return CLAY__SPACE_DISTRIBUTION_AROUND
+		case CLAY_ALIGN_X_SPACE_EVENLY:
+			return CLAY__SPACE_DISTRIBUTION_EVENLY
+		}
+		return CLAY__SPACE_DISTRIBUTION_NONE
+	}
+	switch childAlignment.Y {
+	case CLAY_ALIGN_Y_SPACE_BETWEEN:
+		return CLAY__SPACE_DISTRIBUTION_BETWEEN
+	case CLAY_ALIGN_Y_SPACE_AROUND:
+		return CLAY__SPACE_DISTRIBUTION_AROUND
+	case CLAY_ALIGN_Y_SPACE_EVENLY:
+		return CLAY__SPACE_DISTRIBUTION_EVENLY
+	}
+	return CLAY__SPACE_DISTRIBUTION_NONE
+}
+
+// Returns the space before the first child and the space added to the child gap between each pair of children when
+// distributing extraSpace. Overflowing children (negative extraSpace) are aligned to the start.
+func Clay__DistributeSpace(distribution Clay__SpaceDistribution, extraSpace float32, childCount int32) (float32, float32) {
+	if extraSpace <= 0 || childCount == 0 {
+		return 0, 0
+	}
+	switch distribution {
+	case CLAY__SPACE_DISTRIBUTION_BETWEEN:
+		if childCount == 1 {
+			return 0, 0
+		}
+		return 0, extraSpace / float32(childCount-1)
+	case CLAY__SPACE_DISTRIBUTION_AROUND:
+		gap := extraSpace / float32(childCount)
+		return gap / 2, gap
+	case CLAY__SPACE_DISTRIBUTION_EVENLY:
+		gap := extraSpace / float32(childCount+1)
+		return gap, gap
+	}
+	return 0, 0
+}
+
+// Returns the alignment of the child inside its parent, with the parent's ChildAlignment along its non layout axis
+// replaced by the child's AlignSelf.
+func Clay__ResolveChildAlignment(parentConfig *Clay_LayoutConfig, childElement *Clay_LayoutElement) Clay_ChildAlignment {
+	childAlignment := parentConfig.ChildAlignment
+	if Clay__LayoutDirectionIsHorizontal(parentConfig.LayoutDirection) {
+		switch childElement.LayoutConfig.AlignSelf {
+		case CLAY_ALIGN_SELF_START:
+			childAlignment.Y = CLAY_ALIGN_Y_TOP
+		case CLAY_ALIGN_SELF_CENTER:
+			childAlignment.Y = CLAY_ALIGN_Y_CENTER
+		case CLAY_ALIGN_SELF_END:
+			childAlignment.Y = CLAY_ALIGN_Y_BOTTOM
+		case CLAY_ALIGN_SELF_BASELINE:
+			childAlignment.Y = CLAY_ALIGN_Y_BASELINE
+		}
+		return childAlignment
+	}
+	switch childElement.LayoutConfig.AlignSelf {
+	case CLAY_ALIGN_SELF_START, CLAY_ALIGN_SELF_BASELINE:
+		childAlignment.X = CLAY_ALIGN_X_LEFT
+	case CLAY_ALIGN_SELF_CENTER:
+		childAlignment.X = CLAY_ALIGN_X_CENTER
+	case CLAY_ALIGN_SELF_END:
+		childAlignment.X = CLAY_ALIGN_X_RIGHT
+	}
+	return childAlignment
+}
+
+func Clay__ChildAlignsToBaseline(parentConfig *Clay_LayoutConfig, childElement *Clay_LayoutElement) bool {
+	return Clay__LayoutDirectionIsHorizontal(parentConfig.LayoutDirection) && Clay__ResolveChildAlignment(parentConfig, childElement).Y == CLAY_ALIGN_Y_BASELINE
+}
+
+// Returns the distance from the top of the element to its first text baseline. The baseline of a text element is
+// found with the largest ascent of its words reported by the Clay__MeasureTextMetricsFunction, or is the bottom of its
+// first line without one. Other elements use the baseline of their first child offset by their top padding, or their bottom edge if
+// they have no children.
+func Clay__GetElementBaseline(element *Clay_LayoutElement) float32 {
+	currentContext := Clay_GetCurrentContext()
+	if Clay__ElementHasConfig(element, CLAY__ELEMENT_CONFIG_TYPE_TEXT) {
+		textConfig := Clay__FindElementConfigWithType(element, CLAY__ELEMENT_CONFIG_TYPE_TEXT).TextElementConfig
+		textElementData := element.ChildrenOrTextContent.TextElementData
+		lineHeight := textElementData.PreferredDimensions.Height
+		if textConfig.LineHeight > 0 {
+			lineHeight = float32(textConfig.LineHeight)
+		}
+		if MeasureTextMetricsFunction == nil {
+			return lineHeight
+		}
+		if len(textElementData.Spans) > 0 {
+			if textElementData.WrappedLines.Length() == 0 {
+				return lineHeight
+			}
+			return Clay__RichTextLineBaseline(textElementData, Clay__Slice_Get(&textElementData.WrappedLines, 0)) + (lineHeight-textElementData.PreferredDimensions.Height)/2
+		}
+		// Text is vertically centered in lines taller than the measured text
+		return textElementData.Ascent + (lineHeight-textElementData.PreferredDimensions.Height)/2
+	}
+	if element.ChildrenOrTextContent.Children.Length > 0 {
+		// The topmost child of a CLAY_BOTTOM_TO_TOP element is its last one
+		firstChildIndex := 0
+		if element.LayoutConfig.LayoutDirection == CLAY_BOTTOM_TO_TOP {
+			firstChildIndex = int(element.ChildrenOrTextContent.Children.Length) - 1
+		}
+		firstChild := Clay__Array_Get(&currentContext.LayoutElements, element.ChildrenOrTextContent.Children.Elements[firstChildIndex])
+		return float32(element.LayoutConfig.Padding.Top+firstChild.LayoutConfig.Margin.Top) + Clay__GetElementBaseline(firstChild)
+	}
+	return element.Dimensions.Height
+}
+
+// Returns the largest baseline of the baseline aligned children of the element, and the largest distance from that
+// baseline to the bottom of those children once aligned. Returns false if no children are aligned to their baseline.
+func Clay__GetChildrenBaseline(element *Clay_LayoutElement) (float32, float32, bool) {
+	currentContext := Clay_GetCurrentContext()
+	var baseline, descent float32
+	found := false
+	for i := int32(0); i < int32(element.ChildrenOrTextContent.Children.Length); i++ {
+		childElement := Clay__Array_Get(&currentContext.LayoutElements, element.ChildrenOrTextContent.Children.Elements[i])
+		if !Clay__ChildAlignsToBaseline(element.LayoutConfig, childElement) {
+			continue
+		}
+		// Measured from the top of the child's margin, so the margins stay outside the aligned line
+		childBaseline := float32(childElement.LayoutConfig.Margin.Top) + Clay__GetElementBaseline(childElement)
+		baseline = CLAY__MAX(baseline, childBaseline)
+		descent = CLAY__MAX(descent, Clay__OuterSizeAlongAxis(childElement, false)-childBaseline)
+		found = true
+	}
+	return baseline, descent, found
+}
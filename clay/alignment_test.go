@@ -0,0 +1,229 @@
+package clay
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func testFloatsEqual(a float32, b float32) bool {
+	return math.Abs(float64(a-b)) < 0.01
+}
+
+func TestSpaceDistributionAlongLayoutAxis(t *testing.T) {
+	// Three 50 pixel children with a 10 pixel gap leave 130 pixels to distribute in a 300 pixel container
+	tests := []struct {
+		name      string
+		alignment Clay_ChildAlignment
+		direction Clay_LayoutDirection
+		expected  []float32
+	}{
+		{"space between", Clay_ChildAlignment{X: CLAY_ALIGN_X_SPACE_BETWEEN}, CLAY_LEFT_TO_RIGHT, []float32{0, 125, 250}},
+		{"space around", Clay_ChildAlignment{X: CLAY_ALIGN_X_SPACE_AROUND}, CLAY_LEFT_TO_RIGHT, []float32{21.67, 125, 228.33}},
+		{"space evenly", Clay_ChildAlignment{X: CLAY_ALIGN_X_SPACE_EVENLY}, CLAY_LEFT_TO_RIGHT, []float32{32.5, 125, 217.5}},
+		{"space between vertical", Clay_ChildAlignment{Y: CLAY_ALIGN_Y_SPACE_BETWEEN}, CLAY_TOP_TO_BOTTOM, []float32{0, 125, 250}},
+		{"space evenly vertical", Clay_ChildAlignment{Y: CLAY_ALIGN_Y_SPACE_EVENLY}, CLAY_TOP_TO_BOTTOM, []float32{32.5, 125, 217.5}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			newTestContext(t, 800, 600)
+			runTestLayout(func() {
+				CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{},
+					CLAY(CLAY_ID("container"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{
+						Sizing:          Clay_Sizing{Width: CLAY_SIZING_FIXED(300), Height: CLAY_SIZING_FIXED(300)},
+						ChildGap:        10,
+						ChildAlignment:  test.alignment,
+						LayoutDirection: test.direction,
+					}},
+						testFixedBox("child0", 50, 50),
+						testFixedBox("child1", 50, 50),
+						testFixedBox("child2", 50, 50),
+					),
+				)
+			})
+			for i, expected := range test.expected {
+				box := testBoundingBox(t, fmt.Sprintf("child%d", i))
+				mainOffset, crossOffset := box.X, box.Y
+				if test.direction == CLAY_TOP_TO_BOTTOM {
+					mainOffset, crossOffset = box.Y, box.X
+				}
+				if !testFloatsEqual(mainOffset, expected) || crossOffset != 0 {
+					t.Errorf("child%d: expected offset %v along the layout axis and 0 across it, got %v", i, expected, box)
+				}
+			}
+		})
+	}
+}
+
+func TestSpaceDistributionEdgeCases(t *testing.T) {
+	t.Run("single child with space between stays at the start", func(t *testing.T) {
+		newTestContext(t, 800, 600)
+		runTestLayout(func() {
+			CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{},
+				CLAY(CLAY_ID("container"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{
+					Sizing:         Clay_Sizing{Width: CLAY_SIZING_FIXED(300)},
+					Padding:        CLAY_PADDING_ALL(10),
+					ChildAlignment: Clay_ChildAlignment{X: CLAY_ALIGN_X_SPACE_BETWEEN},
+				}}, testFixedBox("child", 50, 50)),
+			)
+		})
+		testExpectBoundingBox(t, "child", Clay_BoundingBox{X: 10, Y: 10, Width: 50, Height: 50})
+	})
+
+	t.Run("overflowing children are aligned to the start", func(t *testing.T) {
+		newTestContext(t, 800, 600)
+		runTestLayout(func() {
+			CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{},
+				CLAY(CLAY_ID("container"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{
+					Sizing:         Clay_Sizing{Width: CLAY_SIZING_FIXED(80)},
+					ChildGap:       10,
+					ChildAlignment: Clay_ChildAlignment{X: CLAY_ALIGN_X_SPACE_EVENLY},
+				}}, testFixedBox("child0", 50, 50), testFixedBox("child1", 50, 50)),
+			)
+		})
+		testExpectBoundingBox(t, "child0", Clay_BoundingBox{X: 0, Y: 0, Width: 50, Height: 50})
+		testExpectBoundingBox(t, "child1", Clay_BoundingBox{X: 60, Y: 0, Width: 50, Height: 50})
+	})
+
+	t.Run("wrapped lines distribute space individually", func(t *testing.T) {
+		newTestContext(t, 800, 600)
+		runTestLayout(func() {
+			CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{},
+				CLAY(CLAY_ID("container"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{
+					Sizing:         Clay_Sizing{Width: CLAY_SIZING_FIXED(200)},
+					ChildAlignment: Clay_ChildAlignment{X: CLAY_ALIGN_X_SPACE_BETWEEN},
+					Wrap:           CLAY_WRAP_WRAP,
+				}}, testFixedBox("child0", 80, 20), testFixedBox("child1", 80, 20), testFixedBox("child2", 60, 20), testFixedBox("child3", 60, 20)),
+			)
+		})
+		testExpectBoundingBox(t, "child1", Clay_BoundingBox{X: 120, Y: 0, Width: 80, Height: 20})
+		testExpectBoundingBox(t, "child2", Clay_BoundingBox{X: 0, Y: 20, Width: 60, Height: 20})
+		testExpectBoundingBox(t, "child3", Clay_BoundingBox{X: 140, Y: 20, Width: 60, Height: 20})
+	})
+}
+
+func TestSpaceDistributionBorderBetweenChildren(t *testing.T) {
+	newTestContext(t, 800, 600)
+	commands := runTestLayout(func() {
+		CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{},
+			CLAY(CLAY_ID("container"), Clay_ElementDeclaration{
+				Layout: Clay_LayoutConfig{
+					Sizing:         Clay_Sizing{Width: CLAY_SIZING_FIXED(300), Height: CLAY_SIZING_FIXED(50)},
+					ChildGap:       10,
+					ChildAlignment: Clay_ChildAlignment{X: CLAY_ALIGN_X_SPACE_EVENLY},
+				},
+				Border: Clay_BorderElementConfig{Color: Clay_Color{A: 255}, Width: Clay_BorderWidth{BetweenChildren: 2}},
+			},
+				testFixedBox("child0", 50, 50),
+				testFixedBox("child1", 50, 50),
+				testFixedBox("child2", 50, 50),
+			),
+		)
+	})
+
+	// Borders start in the middle of each gap, between 82.5 and 125 and between 175 and 217.5
+	expected := []float32{103.75, 196.25}
+	found := 0
+	for _, command := range commands {
+		if command.CommandType != CLAY_RENDER_COMMAND_TYPE_RECTANGLE || command.BoundingBox.Width != 2 {
+			continue
+		}
+		if found < len(expected) && !testFloatsEqual(command.BoundingBox.X, expected[found]) {
+			t.Errorf("border %d: expected x %v, got %v", found, expected[found], command.BoundingBox.X)
+		}
+		found++
+	}
+	if found != len(expected) {
+		t.Fatalf("expected %d borders between children, got %d", len(expected), found)
+	}
+}
+
+// Measures every byte as half the font size wide, with the baseline at three quarters of the font size.
+func testMeasureTextMetrics(text Clay_StringSlice, config *Clay_TextElementConfig, userData interface{}) Clay_TextMetrics {
+	return Clay_TextMetrics{
+		Dimensions: Clay_Dimensions{Width: float32(text.Length) * float32(config.FontSize) / 2, Height: float32(config.FontSize)},
+		Ascent:     float32(config.FontSize) * 3 / 4,
+	}
+}
+
+func TestAlignSelf(t *testing.T) {
+	newTestContext(t, 800, 600)
+	alignedBox := func(id string, alignSelf Clay_LayoutAlignSelf) ClayContainer {
+		return CLAY(CLAY_ID(id), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{
+			Sizing:    Clay_Sizing{Width: CLAY_SIZING_FIXED(20), Height: CLAY_SIZING_FIXED(20)},
+			AlignSelf: alignSelf,
+		}})
+	}
+	runTestLayout(func() {
+		CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{LayoutDirection: CLAY_TOP_TO_BOTTOM}},
+			CLAY(CLAY_ID("row"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{
+				Sizing:         Clay_Sizing{Width: CLAY_SIZING_FIXED(100), Height: CLAY_SIZING_FIXED(100)},
+				ChildAlignment: Clay_ChildAlignment{Y: CLAY_ALIGN_Y_CENTER},
+			}},
+				alignedBox("rowAuto", CLAY_ALIGN_SELF_AUTO),
+				alignedBox("rowStart", CLAY_ALIGN_SELF_START),
+				alignedBox("rowEnd", CLAY_ALIGN_SELF_END),
+			),
+			CLAY(CLAY_ID("column"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{
+				Sizing:          Clay_Sizing{Width: CLAY_SIZING_FIXED(100), Height: CLAY_SIZING_FIXED(100)},
+				LayoutDirection: CLAY_TOP_TO_BOTTOM,
+			}},
+				alignedBox("columnCenter", CLAY_ALIGN_SELF_CENTER),
+				alignedBox("columnEnd", CLAY_ALIGN_SELF_END),
+			),
+		)
+	})
+
+	testExpectBoundingBox(t, "rowAuto", Clay_BoundingBox{X: 0, Y: 40, Width: 20, Height: 20})
+	testExpectBoundingBox(t, "rowStart", Clay_BoundingBox{X: 20, Y: 0, Width: 20, Height: 20})
+	testExpectBoundingBox(t, "rowEnd", Clay_BoundingBox{X: 40, Y: 80, Width: 20, Height: 20})
+	testExpectBoundingBox(t, "columnCenter", Clay_BoundingBox{X: 40, Y: 100, Width: 20, Height: 20})
+	testExpectBoundingBox(t, "columnEnd", Clay_BoundingBox{X: 80, Y: 120, Width: 20, Height: 20})
+}
+
+func TestBaselineAlignment(t *testing.T) {
+	layout := func() {
+		CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{},
+			CLAY(CLAY_ID("row"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{
+				Padding:        CLAY_PADDING_ALL(5),
+				ChildAlignment: Clay_ChildAlignment{Y: CLAY_ALIGN_Y_BASELINE},
+			}},
+				CLAY(CLAY_ID("large"), Clay_ElementDeclaration{}, CLAY_TEXT("Title", TextWithFontSize(40))),
+				CLAY(CLAY_ID("small"), Clay_ElementDeclaration{}, CLAY_TEXT("subtitle", TextWithFontSize(20))),
+				CLAY(CLAY_ID("badge"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{
+					Sizing:    Clay_Sizing{Width: CLAY_SIZING_FIXED(10), Height: CLAY_SIZING_FIXED(10)},
+					AlignSelf: CLAY_ALIGN_SELF_END,
+				}}),
+			),
+		)
+	}
+
+	t.Run("with text metrics", func(t *testing.T) {
+		newTestContext(t, 800, 600)
+		Clay_SetMeasureTextMetricsFunction(testMeasureTextMetrics, nil)
+		runTestLayout(layout)
+		// Baselines are at 30 and 15 pixels, so the small text is moved down by 15 pixels
+		testExpectBoundingBox(t, "large", Clay_BoundingBox{X: 5, Y: 5, Width: 100, Height: 40})
+		testExpectBoundingBox(t, "small", Clay_BoundingBox{X: 105, Y: 20, Width: 80, Height: 20})
+		testExpectBoundingBox(t, "badge", Clay_BoundingBox{X: 185, Y: 35, Width: 10, Height: 10})
+		testExpectBoundingBox(t, "row", Clay_BoundingBox{X: 0, Y: 0, Width: 200, Height: 50})
+	})
+
+	t.Run("without text metrics aligns the bottom of the first line", func(t *testing.T) {
+		newTestContext(t, 800, 600)
+		runTestLayout(func() {
+			CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{},
+				CLAY(CLAY_ID("row"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{
+					ChildAlignment: Clay_ChildAlignment{Y: CLAY_ALIGN_Y_BASELINE},
+				}},
+					CLAY_TEXT("label", TextWithLineHeight(40)),
+					CLAY(CLAY_ID("box"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{
+						Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(10), Height: CLAY_SIZING_FIXED(10)},
+					}}),
+				),
+			)
+		})
+		testExpectBoundingBox(t, "box", Clay_BoundingBox{X: 50, Y: 30, Width: 10, Height: 10})
+		testExpectBoundingBox(t, "row", Clay_BoundingBox{X: 0, Y: 0, Width: 60, Height: 40})
+	})
+}
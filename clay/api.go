@@ -1,5 +1,7 @@
 package clay
 
+import "unsafe"
+
 type CLAY_CONTAINER_FUNC func(elementID Clay_ElementId, elementDeclaration Clay_ElementDeclaration, content ...CLAY_CONTAINER_FUNC)
 
 type ClayContainer interface {
@@ -64,14 +66,140 @@ func CLAY_TEXT(text string, options ...TextOption) ClayContainer {
 	}
 }
 
+// Declares a text element with the provided id, so that its layout can be queried with e.g. Clay_GetTextLines.
+func CLAY_TEXT_WITH_ID(elementID Clay_ElementId, text string, options ...TextOption) ClayContainer {
+	textConfig := DefaultTextElementConfig()
+	for _, option := range options {
+		option(textConfig)
+	}
+	return &claContainer{
+		wrapper: func() {
+			Clay__OpenTextElementWithId(elementID, CLAY_STRING(text), textConfig)
+		},
+	}
+}
+
+// Declares an editable text element with the provided id and current text. It is laid out like CLAY_TEXT, and is
+// always at least one line tall so that an empty input has room for the caret. A CLAY_RENDER_COMMAND_TYPE_TEXT_INPUT
+// command for the whole element precedes its text commands, for the renderer to draw the selection and caret and to
+// handle input. The text itself is owned by the caller, e.g. the renderer's editing state for the id.
+func CLAY_TEXT_INPUT(elementID Clay_ElementId, text string, options ...TextOption) ClayContainer {
+	textConfig := DefaultTextElementConfig()
+	for _, option := range options {
+		option(textConfig)
+	}
+	return &claContainer{
+		wrapper: func() {
+			Clay__OpenTextInputElement(elementID, CLAY_STRING(text), textConfig)
+		},
+	}
+}
+
+// A span of a CLAY_RICH_TEXT element, declared with CLAY_TEXT_SPAN or CLAY_TEXT_SPAN_WITH_ID.
+type TextSpan struct {
+	id      Clay_ElementId
+	text    string
+	options []TextOption
+}
+
+// Declares a span of text. The options are applied on top of the options of the rich text element it belongs to.
+func CLAY_TEXT_SPAN(text string, options ...TextOption) TextSpan {
+	return TextSpan{text: text, options: options}
+}
+
+// Declares a span of text that Clay_PointerOver reports as the provided id while the pointer is over it.
+func CLAY_TEXT_SPAN_WITH_ID(elementID Clay_ElementId, text string, options ...TextOption) TextSpan {
+	return TextSpan{id: elementID, text: text, options: options}
+}
+
+// Declares a single text element made of multiple spans that wrap together as one paragraph, each with its own
+// styling. The options apply to every span, and control the wrapping, line height and alignment of the paragraph.
+func CLAY_RICH_TEXT(spans []TextSpan, options ...TextOption) ClayContainer {
+	return CLAY_RICH_TEXT_WITH_ID(Clay_ElementId{}, spans, options...)
+}
+
+// Declares a rich text element with the provided id, see CLAY_RICH_TEXT and CLAY_TEXT_WITH_ID.
+func CLAY_RICH_TEXT_WITH_ID(elementID Clay_ElementId, spans []TextSpan, options ...TextOption) ClayContainer {
+	textConfig := DefaultTextElementConfig()
+	for _, option := range options {
+		option(textConfig)
+	}
+	textSpans := make([]Clay_TextSpan, len(spans))
+	for i, span := range spans {
+		spanConfig := *textConfig
+		for _, option := range span.options {
+			option(&spanConfig)
+		}
+		textSpans[i] = Clay_TextSpan{Text: CLAY_STRING(span.text), Id: span.id, Config: &spanConfig}
+	}
+	return &claContainer{
+		wrapper: func() {
+			Clay__OpenRichTextElement(elementID, textSpans, textConfig)
+		},
+	}
+}
+
+// Opens an element with the provided id and declaration. The elements declared until the matching Clay_CloseElement
+// are its children. Unlike CLAY, declaring elements this way doesn't allocate, which matters for layouts that declare
+// thousands of elements every frame:
+//
+//	clay.Clay_OpenElement(clay.CLAY_ID("sidebar"), &sidebarDeclaration)
+//	for i := range items {
+//		clay.Clay_Text(items[i].Label, &itemTextConfig)
+//	}
+//	clay.Clay_CloseElement()
+//
+// The declaration is copied, so it can be reused for the next element right away.
+func Clay_OpenElement(elementID Clay_ElementId, elementDeclaration *Clay_ElementDeclaration) {
+	Clay__OpenElementWithId(elementID)
+	Clay__ConfigureOpenElementPtr(elementDeclaration)
+}
+
+// Opens an element with an id generated from its position within its parent, see Clay_OpenElement.
+func Clay_OpenElementAutoId(elementDeclaration *Clay_ElementDeclaration) {
+	Clay__OpenElement()
+	Clay__ConfigureOpenElementPtr(elementDeclaration)
+}
+
+// Closes the element opened by the last Clay_OpenElement or Clay_OpenElementAutoId that is still open.
+func Clay_CloseElement() {
+	Clay__CloseElement()
+}
+
+// Declares a text element like CLAY_TEXT, without allocating. The text config is used as is rather than copied, so it
+// must not change until the layout has ended. Sharing one config between all text with the same style is cheapest.
+func Clay_Text(text string, textConfig *Clay_TextElementConfig) {
+	Clay__OpenTextElement(CLAY_STRING(text), textConfig)
+}
+
+// Declares a text element like Clay_Text for text that lives for the entire lifetime of the program, see
+// CLAY_STATIC_STRING.
+func Clay_StaticText(text string, textConfig *Clay_TextElementConfig) {
+	Clay__OpenTextElement(CLAY_STATIC_STRING(text), textConfig)
+}
+
+// Declares a text element with the provided id, see Clay_Text and CLAY_TEXT_WITH_ID.
+func Clay_TextWithId(elementID Clay_ElementId, text string, textConfig *Clay_TextElementConfig) {
+	Clay__OpenTextElementWithId(elementID, CLAY_STRING(text), textConfig)
+}
+
+// Returns a Clay_String that refers to the bytes of label rather than a copy of them, so they must never be modified.
 func CLAY_STRING(label string) Clay_String {
 	return Clay_String{
-		IsStaticallyAllocated: true,
-		Length:                int32(len(label)),
-		Chars:                 []byte(label),
+		Length: int32(len(label)),
+		Chars:  unsafe.Slice(unsafe.StringData(label), len(label)),
 	}
 }
 
+// Returns a Clay_String like CLAY_STRING for a string that lives for the entire lifetime of the program, like a string
+// constant. Text measurements of static strings are cached by the address of their bytes instead of their contents, so
+// strings whose memory may later hold other text, like those built with fmt.Sprintf, must use CLAY_STRING instead.
+func CLAY_STATIC_STRING(label string) Clay_String {
+	text := CLAY_STRING(label)
+	text.IsStaticallyAllocated = true
+	return text
+}
+
 // Note: If a compile error led you here, you might be trying to use CLAY_ID with something other than a string literal.
 // To construct an ID with a dynamic string, use CLAY_SID instead.
 func CLAY_ID(label string) Clay_ElementId {
@@ -91,6 +219,15 @@ func CLAY_PADDING_ALL(padding uint16) Clay_Padding {
 	}
 }
 
+func CLAY_MARGIN_ALL(margin uint16) Clay_Margin {
+	return Clay_Margin{
+		Left:   margin,
+		Right:  margin,
+		Top:    margin,
+		Bottom: margin,
+	}
+}
+
 // func CLAY_BORDER_OUTSIDE(widthValue uint16) Clay_BorderWidth {
 // 	return Clay_BorderWidth{
 // 		Left:            widthValue,
@@ -125,6 +262,15 @@ func CLAY_SIZING_GROW(minMax Clay_SizingMinMax) Clay_SizingAxis {
 		Size: Clay_SizingAxisSize{MinMax: minMax},
 	}
 }
+
+func CLAY_SIZING_GROW_WEIGHTED(growWeight float32, minMax Clay_SizingMinMax) Clay_SizingAxis {
+	return Clay_SizingAxis{
+		Type:       CLAY__SIZING_TYPE_GROW,
+		Size:       Clay_SizingAxisSize{MinMax: minMax},
+		GrowWeight: growWeight,
+	}
+}
+
 func CLAY_SIZING_FIXED(fixedSize float32) Clay_SizingAxis {
 	return Clay_SizingAxis{
 		Type: CLAY__SIZING_TYPE_FIXED,
@@ -142,12 +288,39 @@ func CLAY_SIZING_PERCENT(percentOfParent float32) Clay_SizingAxis {
 	}
 }
 
+func CLAY_GRID_TRACK_FIT(minMax Clay_SizingMinMax) Clay_GridTrack {
+	return Clay_GridTrack{Sizing: CLAY_SIZING_FIT(minMax)}
+}
+
+func CLAY_GRID_TRACK_GROW(growWeight float32) Clay_GridTrack {
+	return Clay_GridTrack{Sizing: CLAY_SIZING_GROW_WEIGHTED(growWeight, Clay_SizingMinMax{})}
+}
+
+func CLAY_GRID_TRACK_FIXED(fixedSize float32) Clay_GridTrack {
+	return Clay_GridTrack{Sizing: CLAY_SIZING_FIXED(fixedSize)}
+}
+
+func CLAY_GRID_TRACK_PERCENT(percentOfGrid float32) Clay_GridTrack {
+	return Clay_GridTrack{Sizing: CLAY_SIZING_PERCENT(percentOfGrid)}
+}
+
 func Clay_SetMeasureTextFunction(measureTextFunction Clay__MeasureTextFunction, userData interface{}) {
 	currentContext := Clay_GetCurrentContext()
 	MeasureTextFunction = measureTextFunction
+	MeasureTextMetricsFunction = nil
 	currentContext.MeasureTextUserData = userData
 }
 
+// Sets a text measurement function that also reports the ascent of the measured text, which is used to find the
+// baseline of text elements for CLAY_ALIGN_Y_BASELINE. Replaces the function set with Clay_SetMeasureTextFunction.
+// Without it, the baseline of text is assumed to be at the bottom of its first line.
+func Clay_SetMeasureTextMetricsFunction(measureTextMetricsFunction Clay__MeasureTextMetricsFunction, userData interface{}) {
+	Clay_SetMeasureTextFunction(func(text Clay_StringSlice, config *Clay_TextElementConfig, userData interface{}) Clay_Dimensions {
+		return measureTextMetricsFunction(text, config, userData).Dimensions
+	}, userData)
+	MeasureTextMetricsFunction = measureTextMetricsFunction
+}
+
 func Clay_SetQueryScrollOffsetFunction(queryScrollOffsetFunction Clay__QueryScrollOffsetFunction, userData interface{}) {
 	currentContext := Clay_GetCurrentContext()
 	QueryScrollOffsetFunction = queryScrollOffsetFunction
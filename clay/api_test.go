@@ -0,0 +1,115 @@
+package clay
+
+import (
+	"reflect"
+	"testing"
+)
+
+var (
+	testListDeclaration = Clay_ElementDeclaration{
+		Layout: Clay_LayoutConfig{
+			Sizing:          Clay_Sizing{Width: CLAY_SIZING_GROW(Clay_SizingMinMax{}), Height: CLAY_SIZING_GROW(Clay_SizingMinMax{})},
+			LayoutDirection: CLAY_TOP_TO_BOTTOM,
+		},
+		Clip: Clay_ClipElementConfig{Vertical: true},
+	}
+	testRowDeclaration = Clay_ElementDeclaration{
+		Layout:          Clay_LayoutConfig{Padding: CLAY_PADDING_ALL(4), ChildGap: 8},
+		BackgroundColor: Clay_Color{R: 240, G: 240, B: 240, A: 255},
+		CornerRadius:    CLAY_CORNER_RADIUS(4),
+		Border:          Clay_BorderElementConfig{Color: Clay_Color{A: 255}, Width: Clay_BorderWidth{Bottom: 1}},
+	}
+	testIconDeclaration = Clay_ElementDeclaration{
+		Layout:          Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(16), Height: CLAY_SIZING_FIXED(16)}},
+		BackgroundColor: Clay_Color{B: 255, A: 255},
+	}
+	testLabelConfig = Clay_TextElementConfig{TextColor: Clay_Color{A: 255}, FontSize: 16}
+	testRowLabels   = []string{"first", "second", "third", "fourth", "fifth"}
+	testRowIds      = testMakeRowIds(1000)
+)
+
+func testMakeRowIds(rowCount int) []Clay_ElementId {
+	ids := make([]Clay_ElementId, rowCount)
+	for i := range ids {
+		ids[i] = Clay__HashNumber(uint32(i), CLAY_ID("row").Id)
+	}
+	return ids
+}
+
+// Declares a list of rows with the allocation free declaration API.
+func testDeclareRows(rowCount int) {
+	Clay_OpenElement(CLAY_ID("list"), &testListDeclaration)
+	for i := 0; i < rowCount; i++ {
+		Clay_OpenElement(testRowIds[i], &testRowDeclaration)
+		Clay_OpenElementAutoId(&testIconDeclaration)
+		Clay_CloseElement()
+		Clay_Text(testRowLabels[i%len(testRowLabels)], &testLabelConfig)
+		Clay_CloseElement()
+	}
+	Clay_CloseElement()
+}
+
+// Declares the same list of rows as testDeclareRows with CLAY.
+func testDeclareRowsWithContainers(rowCount int) {
+	rows := make([]ClayContainer, rowCount)
+	for i := range rows {
+		rows[i] = CLAY(testRowIds[i], testRowDeclaration,
+			CLAY_AUTO_ID(testIconDeclaration),
+			CLAY_TEXT(testRowLabels[i%len(testRowLabels)], TextWithColor(testLabelConfig.TextColor), TextWithFontSize(testLabelConfig.FontSize)),
+		)
+	}
+	CLAY_ROOT(CLAY_ID("list"), testListDeclaration, rows...)
+}
+
+func TestOpenElementMatchesContainers(t *testing.T) {
+	newTestContext(t, 400, 600)
+	expected := append([]Clay_RenderCommand(nil), runTestLayout(func() { testDeclareRowsWithContainers(20) })...)
+	renderCommands := runTestLayout(func() { testDeclareRows(20) })
+
+	if len(renderCommands) != len(expected) {
+		t.Fatalf("expected %d render commands, got %d", len(expected), len(renderCommands))
+	}
+	for i := range expected {
+		if !reflect.DeepEqual(renderCommands[i], expected[i]) {
+			t.Errorf("render command %d: expected %+v, got %+v", i, expected[i], renderCommands[i])
+		}
+	}
+}
+
+func TestOpenElementDoesNotAllocate(t *testing.T) {
+	newTestContext(t, 400, 600)
+	// The first layout fills the text measurement cache
+	runTestLayout(func() { testDeclareRows(1000) })
+
+	// Beginning a layout allocates its arrays, which doesn't depend on the number of elements
+	layoutAllocs := testing.AllocsPerRun(10, func() {
+		Clay_BeginLayout()
+	})
+	allocs := testing.AllocsPerRun(10, func() {
+		Clay_BeginLayout()
+		testDeclareRows(1000)
+	})
+	if allocs != layoutAllocs {
+		t.Errorf("expected declaring 3000 elements not to allocate, got %v allocations", allocs-layoutAllocs)
+	}
+}
+
+func BenchmarkDeclareWithOpenElement(b *testing.B) {
+	newTestContext(b, 400, 600)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Clay_BeginLayout()
+		testDeclareRows(1000)
+	}
+}
+
+func BenchmarkDeclareWithContainers(b *testing.B) {
+	newTestContext(b, 400, 600)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Clay_BeginLayout()
+		testDeclareRowsWithContainers(1000)
+	}
+}
@@ -31,12 +31,26 @@ type Clay_LayoutElement struct {
 	ElementConfigs        Clay__Slice[Clay_ElementConfig]
 	Id                    uint32
 	FloatingChildrenCount uint16
+	// One more than the index in GridLayoutDatas of the element's grid layout data, or 0 if it isn't laid out as a grid.
+	GridLayoutDataIndex int32
+	// One more than the index in ScrollContainerDatas of the element's scroll container data, or 0 if it doesn't clip.
+	ScrollContainerDataIndex int32
+	// A hash of the declaration of the element and its children, set while incremental layout is enabled.
+	// See Clay__HashElementDeclaration.
+	DeclarationHash uint64
+	// One more than the index in the incremental layout cache of the results of the last layout that the width of
+	// the element was restored from, or 0 if it was calculated. See Clay__ReuseCachedSizes.
+	CachedLayoutIndex int32
+	// The height of the element once the heights of wrapped text have been propagated to it, before it is sized
+	// along the Y axis.
+	ContentHeight float32
 }
 
 type Clay_SharedElementConfig struct {
-	BackgroundColor Clay_Color
-	CornerRadius    Clay_CornerRadius
-	UserData        interface{}
+	BackgroundColor    Clay_Color
+	BackgroundGradient Clay_Gradient
+	CornerRadius       Clay_CornerRadius
+	UserData           interface{}
 }
 
 type Clay__ElementConfigType uint8
@@ -51,6 +65,9 @@ const (
 	CLAY__ELEMENT_CONFIG_TYPE_TEXT
 	CLAY__ELEMENT_CONFIG_TYPE_CUSTOM
 	CLAY__ELEMENT_CONFIG_TYPE_SHARED
+	CLAY__ELEMENT_CONFIG_TYPE_TRANSITION
+	CLAY__ELEMENT_CONFIG_TYPE_TRANSFORM
+	CLAY__ELEMENT_CONFIG_TYPE_SHADOW
 )
 
 type Clay_ElementConfigUnion struct {
@@ -62,6 +79,9 @@ type Clay_ElementConfigUnion struct {
 	ClipElementConfig        *Clay_ClipElementConfig
 	BorderElementConfig      *Clay_BorderElementConfig
 	SharedElementConfig      *Clay_SharedElementConfig
+	TransitionElementConfig  *Clay_TransitionElementConfig
+	TransformElementConfig   *Clay_TransformElementConfig
+	ShadowElementConfig      *Clay_ShadowElementConfig
 }
 
 type Clay_ElementConfig struct {
@@ -78,6 +98,15 @@ type Clay_LayoutElementHashMapItem struct { // todo get this struct into a singl
 	NextIndex             int32
 	Generation            uint32
 	DebugData             *Clay__DebugElementData
+	// The id of the element this element was declared inside of during the current layout. Used to reconstruct declaration paths.
+	ParentId uint32
+	// The generation in which a duplicate declaration of this ID was last reported, so that each duplicate ID is only reported once per layout.
+	DuplicateReportedGeneration uint32
+	// The index of the results of this element in the incremental layout cache, and the generation they were laid out in.
+	LayoutCacheIndex      int32
+	LayoutCacheGeneration uint32
+	// The index of the animation state of this element in TransitionDatas, only valid while the data there has the same id.
+	TransitionDataIndex int32
 }
 
 type Clay_AspectRatioElementConfig struct {
@@ -151,6 +180,7 @@ type Clay_FloatingElementConfig struct {
 	// Controls how mouse pointer events like hover and click are captured or passed through to elements underneath a floating element.
 	// CLAY_POINTER_CAPTURE_MODE_CAPTURE (default) - "Capture" the pointer event and don't allow events like hover and click to pass through to elements underneath.
 	// CLAY_POINTER_CAPTURE_MODE_PASSTHROUGH - Transparently pass through pointer events like hover and click to elements underneath the floating element.
+	// CLAY_POINTER_CAPTURE_MODE_PARENT - Capture pointer events, and also report the element this floating element is attached to (and its ancestors) as hovered.
 	PointerCaptureMode Clay_PointerCaptureMode
 	// Controls which element a floating element is "attached" to (i.e. relative offset from).
 	// CLAY_ATTACH_TO_NONE (default) - Disables floating for this element.
@@ -180,16 +210,20 @@ type Clay_ClipElementConfig struct {
 }
 
 type Clay_ElementDeclaration struct {
-	Layout          Clay_LayoutConfig
-	BackgroundColor Clay_Color
-	CornerRadius    Clay_CornerRadius
-	AspectRatio     Clay_AspectRatioElementConfig
-	Image           Clay_ImageElementConfig
-	Floating        Clay_FloatingElementConfig
-	Custom          Clay_CustomElementConfig
-	Clip            Clay_ClipElementConfig
-	Border          Clay_BorderElementConfig
-	UserData        interface{}
+	Layout             Clay_LayoutConfig
+	BackgroundColor    Clay_Color
+	BackgroundGradient Clay_Gradient
+	CornerRadius       Clay_CornerRadius
+	AspectRatio        Clay_AspectRatioElementConfig
+	Image              Clay_ImageElementConfig
+	Floating           Clay_FloatingElementConfig
+	Custom             Clay_CustomElementConfig
+	Clip               Clay_ClipElementConfig
+	Border             Clay_BorderElementConfig
+	Shadow             Clay_ShadowElementConfig
+	Transition         Clay_TransitionElementConfig
+	Transform          Clay_TransformElementConfig
+	UserData           interface{}
 }
 
 func Clay_Initialize(arena Clay_Arena, layoutDimensions Clay_Dimensions, errorHandler Clay_ErrorHandler) *Clay_Context {
@@ -204,6 +238,7 @@ func Clay_Initialize(arena Clay_Arena, layoutDimensions Clay_Dimensions, errorHa
 	newContext := &Clay_Context{
 		MaxElementCount:              Clay__defaultMaxElementCount,
 		MaxMeasureTextCacheWordCount: Clay__defaultMaxMeasureTextWordCacheCount,
+		MaxDynamicStringDataBytes:    Clay__defaultMaxDynamicStringDataBytes,
 		ErrorHandler:                 Clay__ErrorHandlerFunctionDefault,
 		LayoutDimensions:             layoutDimensions,
 		InternalArena:                arena,
@@ -216,6 +251,9 @@ func Clay_Initialize(arena Clay_Arena, layoutDimensions Clay_Dimensions, errorHa
 	if oldContext != nil {
 		newContext.MaxElementCount = oldContext.MaxElementCount
 		newContext.MaxMeasureTextCacheWordCount = oldContext.MaxMeasureTextCacheWordCount
+		if oldContext.MaxDynamicStringDataBytes > 0 {
+			newContext.MaxDynamicStringDataBytes = oldContext.MaxDynamicStringDataBytes
+		}
 
 	}
 
@@ -268,6 +306,63 @@ func Clay_SetDebugModeEnabled(enabled bool) {
 	currentContext.DebugModeEnabled = enabled
 }
 
+// When enabled, elements declared with an ID that was already used during the current layout are given a new ID
+// derived from the original (see Clay_ElementId.Offset) instead of sharing the first element's hash map entry.
+// CLAY_ERROR_TYPE_DUPLICATE_ID is still reported, but layout, hover and scroll state keep working for every element.
+func Clay_SetDisambiguateDuplicateIds(enabled bool) {
+	currentContext := Clay_GetCurrentContext()
+	currentContext.DisambiguateDuplicateIds = enabled
+}
+
+// When enabled, the final layout is mirrored horizontally for right-to-left locales. CLAY_LEFT_TO_RIGHT containers then
+// place their first child on the right, and left / right alignment, padding, margins, floating attach points, corner
+// radii and border widths all trade sides. Text itself is not reordered, that is left to the text renderer.
+func Clay_SetRTLEnabled(enabled bool) {
+	currentContext := Clay_GetCurrentContext()
+	currentContext.RTLEnabled = enabled
+}
+
+// When enabled, each element is hashed as it is declared, together with its configs, text and children. Subtrees that
+// were declared the same way as during the last layout then reuse its results instead of being laid out again: their
+// sizes and wrapped text are reused while their root has the same size, and their render commands while it has the same
+// bounding box.
+// Values passed through to the renderer, like UserData, are compared by identity for pointers and by value for strings,
+// numbers and booleans. Elements holding any other kind of value are always laid out again.
+// The results are only reused as long as the text measurement function measures text the same way, e.g. disable and
+// enable incremental layout again to discard them after fonts have been reloaded.
+func Clay_SetIncrementalLayoutEnabled(enabled bool) {
+	currentContext := Clay_GetCurrentContext()
+	if !enabled {
+		currentContext.IncrementalLayout = nil
+	} else if currentContext.IncrementalLayout == nil {
+		currentContext.IncrementalLayout = &Clay__IncrementalLayoutCache{}
+	}
+}
+
+func Clay_IsIncrementalLayoutEnabled() bool {
+	currentContext := Clay_GetCurrentContext()
+	return currentContext.IncrementalLayout != nil
+}
+
+// Advances the animations of elements declared with a Transition by deltaTime, the time in seconds that passed since
+// the last frame. Call it before each layout, which then animates by the time passed since the layout before.
+func Clay_UpdateTransitions(deltaTime float32) {
+	currentContext := Clay_GetCurrentContext()
+	currentContext.TransitionDeltaTime += deltaTime
+}
+
+// Returns true if the last layout had transitions that haven't finished, in which case the layout should be calculated
+// again for the next frame even if nothing else changed.
+func Clay_TransitionsActive() bool {
+	currentContext := Clay_GetCurrentContext()
+	return currentContext.TransitionsActive
+}
+
+func Clay_IsRTLEnabled() bool {
+	currentContext := Clay_GetCurrentContext()
+	return currentContext.RTLEnabled
+}
+
 func Clay_IsDebugModeEnabled() bool {
 	currentContext := Clay_GetCurrentContext()
 	return currentContext.DebugModeEnabled
@@ -279,11 +374,8 @@ func Clay_EndLayout() []Clay_RenderCommand {
 	Clay__CloseElement()
 
 	if currentContext.OpenLayoutElementStack.Length() > 1 {
-		currentContext.ErrorHandler.ErrorHandlerFunction(Clay_ErrorData{
-			ErrorType: CLAY_ERROR_TYPE_UNBALANCED_OPEN_CLOSE,
-			ErrorText: CLAY_STRING("There were still open layout elements when EndLayout was called. This results from an unequal number of calls to Clay__OpenElement and Clay__CloseElement."),
-			UserData:  currentContext.ErrorHandler.UserData,
-		})
+		openElementIndex := Clay__Array_GetValue(&currentContext.OpenLayoutElementStack, currentContext.OpenLayoutElementStack.Length()-1)
+		Clay__ReportElementError(CLAY_ERROR_TYPE_UNBALANCED_OPEN_CLOSE, CLAY_STRING("There were still open layout elements when EndLayout was called. This results from an unequal number of calls to Clay__OpenElement and Clay__CloseElement."), Clay__GetElementIdForLayoutElement(openElementIndex))
 		return []Clay_RenderCommand{}
 	}
 
@@ -322,7 +414,12 @@ func Clay_EndLayout() []Clay_RenderCommand {
 		})
 	}
 
+	Clay__ResolveFloatingAttachments()
 	Clay__CalculateFinalLayout()
+	if currentContext.RTLEnabled {
+		Clay__MirrorLayoutHorizontally()
+	}
+	Clay__ApplyTransitions()
 	return mem.MArray_GetAll(&currentContext.RenderCommands)
 
 }
@@ -27,6 +27,7 @@ func Clay__AddHashMapItem(elementId Clay_ElementId, layoutElement *Clay_LayoutEl
 		LayoutElement: layoutElement,
 		NextIndex:     -1,
 		Generation:    currentContext.Generation + 1,
+		ParentId:      Clay__GetDeclaringParentId(layoutElement),
 	}
 
 	// Perform modulo with uint32 first to avoid negative results, then cast to int32
@@ -44,12 +45,9 @@ func Clay__AddHashMapItem(elementId Clay_ElementId, layoutElement *Clay_LayoutEl
 				hashItem.DebugData.Collision = false
 				hashItem.OnHoverFunction = nil
 				hashItem.HoverFunctionUserData = 0
+				hashItem.ParentId = item.ParentId
 			} else { // Multiple collisions this frame - two elements have the same ID
-				currentContext.ErrorHandler.ErrorHandlerFunction(Clay_ErrorData{
-					ErrorType: CLAY_ERROR_TYPE_DUPLICATE_ID,
-					ErrorText: CLAY_STRING("An element with this ID was already previously declared during this layout."),
-					UserData:  currentContext.ErrorHandler.UserData,
-				})
+				Clay__ReportDuplicateId(hashItem, elementId)
 				if currentContext.DebugModeEnabled {
 					hashItem.DebugData.Collision = true
 				}
@@ -69,3 +67,73 @@ func Clay__AddHashMapItem(elementId Clay_ElementId, layoutElement *Clay_LayoutEl
 	}
 	return hashItem
 }
+
+// Returns the id of the element that the provided layout element is being declared inside of.
+func Clay__GetDeclaringParentId(layoutElement *Clay_LayoutElement) uint32 {
+	currentContext := Clay_GetCurrentContext()
+	stackIndex := currentContext.OpenLayoutElementStack.Length() - 1
+	if stackIndex >= 0 && Clay__Array_Get(&currentContext.LayoutElements, Clay__Array_GetValue(&currentContext.OpenLayoutElementStack, stackIndex)) == layoutElement {
+		stackIndex--
+	}
+	if stackIndex < 0 {
+		return 0
+	}
+	return Clay__Array_Get(&currentContext.LayoutElements, Clay__Array_GetValue(&currentContext.OpenLayoutElementStack, stackIndex)).Id
+}
+
+// Reports CLAY_ERROR_TYPE_DUPLICATE_ID for elementId, which collides with existingItem declared earlier in this layout.
+// Each ID is only reported once per layout, no matter how many times it is declared.
+func Clay__ReportDuplicateId(existingItem *Clay_LayoutElementHashMapItem, elementId Clay_ElementId) {
+	currentContext := Clay_GetCurrentContext()
+	if existingItem.DuplicateReportedGeneration == currentContext.Generation+1 {
+		return
+	}
+	existingItem.DuplicateReportedGeneration = currentContext.Generation + 1
+	currentContext.ErrorHandler.ErrorHandlerFunction(Clay_ErrorData{
+		ErrorType:         CLAY_ERROR_TYPE_DUPLICATE_ID,
+		ErrorText:         CLAY_STRING("An element with this ID was already previously declared during this layout."),
+		UserData:          currentContext.ErrorHandler.UserData,
+		ElementId:         elementId,
+		ParentIds:         Clay__GetOpenElementParentIds(elementId.Id),
+		PreviousParentIds: Clay__GetDeclarationParentIds(existingItem.ParentId),
+	})
+}
+
+// Reconstructs the chain of element IDs leading to an element declared during the current layout, outermost first,
+// by following the ParentId of each hash map item starting at parentId.
+func Clay__GetDeclarationParentIds(parentId uint32) []Clay_ElementId {
+	currentContext := Clay_GetCurrentContext()
+	var parentIds []Clay_ElementId
+	for parentId != 0 && int32(len(parentIds)) < currentContext.LayoutElements.Length() {
+		parentItem := Clay__GetHashMapItem(parentId)
+		if parentItem == &Clay_LayoutElementHashMapItem_DEFAULT || parentItem.Generation != currentContext.Generation+1 {
+			break
+		}
+		parentIds = append(parentIds, parentItem.ElementId)
+		parentId = parentItem.ParentId
+	}
+	for i, j := 0, len(parentIds)-1; i < j; i, j = i+1, j-1 {
+		parentIds[i], parentIds[j] = parentIds[j], parentIds[i]
+	}
+	return parentIds
+}
+
+// Derives a new ID from a duplicate element ID by hashing it with an increasing offset, until an ID is found that
+// hasn't been declared yet during this layout. The result is stable across frames as long as declaration order is.
+func Clay__DisambiguateElementId(elementId Clay_ElementId) Clay_ElementId {
+	currentContext := Clay_GetCurrentContext()
+	for offset := uint32(1); ; offset++ {
+		candidate := Clay__DisambiguateElementIdAt(elementId, offset)
+		existingItem := Clay__GetHashMapItem(candidate.Id)
+		if existingItem == &Clay_LayoutElementHashMapItem_DEFAULT || existingItem.Generation <= currentContext.Generation {
+			return candidate
+		}
+	}
+}
+
+func Clay__DisambiguateElementIdAt(elementId Clay_ElementId, offset uint32) Clay_ElementId {
+	candidate := Clay__HashNumber(offset, elementId.Id)
+	candidate.BaseId = elementId.BaseId
+	candidate.StringId = elementId.StringId
+	return candidate
+}
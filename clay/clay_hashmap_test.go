@@ -0,0 +1,80 @@
+package clay
+
+import (
+	"testing"
+)
+
+func declareDuplicateItems() {
+	CLAY_ROOT(CLAY_ID("outer"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{LayoutDirection: CLAY_TOP_TO_BOTTOM}},
+		CLAY(CLAY_ID("first"), Clay_ElementDeclaration{},
+			CLAY(CLAY_ID("item"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(10), Height: CLAY_SIZING_FIXED(10)}}}),
+		),
+		CLAY(CLAY_ID("second"), Clay_ElementDeclaration{},
+			CLAY(CLAY_ID("item"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(20), Height: CLAY_SIZING_FIXED(20)}}}),
+			CLAY(CLAY_ID("item"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(30), Height: CLAY_SIZING_FIXED(30)}}}),
+		),
+	)
+}
+
+func TestDuplicateIds(t *testing.T) {
+	t.Run("reported once per id per layout with both declaration sites", func(t *testing.T) {
+		reported := newTestContext(t, 800, 600)
+		for frame := 1; frame <= 2; frame++ {
+			runTestLayout(declareDuplicateItems)
+			if len(*reported) != frame {
+				t.Fatalf("frame %d: expected %d errors in total, got %d", frame, frame, len(*reported))
+			}
+		}
+		errorData := (*reported)[0]
+		if errorData.ErrorType != CLAY_ERROR_TYPE_DUPLICATE_ID {
+			t.Fatalf("expected DUPLICATE_ID, got %s", errorData.ErrorType)
+		}
+		if got := errorData.Path(); got != "Clay__RootContainer > outer > second > item" {
+			t.Errorf("unexpected duplicate path %q", got)
+		}
+		previous := Clay_ErrorData{ElementId: errorData.ElementId, ParentIds: errorData.PreviousParentIds}
+		if got := previous.Path(); got != "Clay__RootContainer > outer > first > item" {
+			t.Errorf("unexpected previous declaration path %q", got)
+		}
+	})
+
+	t.Run("lenient mode gives every duplicate its own id", func(t *testing.T) {
+		reported := newTestContext(t, 800, 600)
+		Clay_SetDisambiguateDuplicateIds(true)
+		runTestLayout(declareDuplicateItems)
+		if len(*reported) != 1 {
+			t.Fatalf("expected the duplicate to still be reported once, got %d errors", len(*reported))
+		}
+
+		first := testBoundingBox(t, "item")
+		if first.Width != 10 {
+			t.Errorf("expected CLAY_ID(\"item\") to refer to the first declaration, got width %v", first.Width)
+		}
+		seen := map[uint32]bool{CLAY_ID("item").Id: true}
+		expectedWidths := []float32{20, 30}
+		for offset, width := range expectedWidths {
+			elementId := Clay__DisambiguateElementIdAt(CLAY_ID("item"), uint32(offset+1))
+			if seen[elementId.Id] {
+				t.Fatalf("disambiguated id %d is not unique", elementId.Id)
+			}
+			seen[elementId.Id] = true
+			item := Clay__GetHashMapItem(elementId.Id)
+			if item.BoundingBox.Width != width {
+				t.Errorf("expected duplicate %d to have width %v, got %v", offset+1, width, item.BoundingBox.Width)
+			}
+		}
+	})
+
+	t.Run("text after an anonymous floating sibling is not a duplicate", func(t *testing.T) {
+		reported := newTestContext(t, 800, 600)
+		runTestLayout(func() {
+			CLAY_ROOT(CLAY_ID("outer"), Clay_ElementDeclaration{},
+				CLAY_AUTO_ID(Clay_ElementDeclaration{Floating: Clay_FloatingElementConfig{AttachTo: CLAY_ATTACH_TO_PARENT}}),
+				CLAY_TEXT("label"),
+			)
+		})
+		if len(*reported) != 0 {
+			t.Errorf("expected no errors, got %v", (*reported)[0].ErrorType)
+		}
+	})
+}
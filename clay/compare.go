@@ -15,6 +15,6 @@ func Clay__MemCmp(s1 unsafe.Pointer, s2 unsafe.Pointer, length int32) bool {
 }
 
 func Clay__MemCmpTyped[T any](s1 *T, s2 *T) bool {
-	size := unsafe.Sizeof(new(T))
+	size := unsafe.Sizeof(*s1)
 	return Clay__MemCmp(unsafe.Pointer(s1), unsafe.Pointer(s2), int32(size))
 }
@@ -0,0 +1,16 @@
+package clay
+
+import "testing"
+
+func TestMemCmpTypedComparesWholeValue(t *testing.T) {
+	// Only the last field differs, past the size of a pointer
+	a := Clay_CornerRadius{}
+	b := Clay_CornerRadius{BottomRight: 1}
+	if Clay__MemCmpTyped(&a, &b) {
+		t.Errorf("expected values that differ in their last field not to be equal")
+	}
+	b.BottomRight = 0
+	if !Clay__MemCmpTyped(&a, &b) {
+		t.Errorf("expected equal values to be equal")
+	}
+}
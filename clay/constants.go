@@ -4,6 +4,7 @@ import "math"
 
 const Clay__defaultMaxElementCount int32 = 8192
 const Clay__defaultMaxMeasureTextWordCacheCount int32 = 16384
+const Clay__defaultMaxDynamicStringDataBytes int32 = 65536
 
 const CLAY__MAXFLOAT = math.MaxFloat32
 const UINT32_MAX uint32 = math.MaxUint32
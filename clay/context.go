@@ -5,6 +5,7 @@ var Clay__currentContext *Clay_Context
 type Clay_Context struct {
 	MaxElementCount              int32
 	MaxMeasureTextCacheWordCount int32
+	MaxDynamicStringDataBytes    int32 // Bytes available each frame for text generated by clay, like truncated lines. 0 leaves no room for any.
 	WarningsEnabled              bool
 	ErrorHandler                 Clay_ErrorHandler
 
@@ -20,6 +21,14 @@ type Clay_Context struct {
 	DebugModeEnabled              bool
 	DisableCulling                bool
 	ExternalScrollHandlingEnabled bool
+	DisambiguateDuplicateIds      bool
+	RTLEnabled                    bool
+	// The results of the last layout while incremental layout is enabled, or nil. See Clay_SetIncrementalLayoutEnabled.
+	IncrementalLayout *Clay__IncrementalLayoutCache
+	// The time in seconds that transitions advance by during the next layout, see Clay_UpdateTransitions.
+	TransitionDeltaTime float32
+	// Set while the last layout had transitions that haven't finished, see Clay_TransitionsActive.
+	TransitionsActive bool
 
 	DebugSelectedElementId uint32
 	Generation             uint32
@@ -52,12 +61,18 @@ type Clay_Context struct {
 	CustomElementConfigs      Clay__Array[Clay_CustomElementConfig]
 	BorderElementConfigs      Clay__Array[Clay_BorderElementConfig]
 	SharedElementConfigs      Clay__Array[Clay_SharedElementConfig]
+	TransitionElementConfigs  Clay__Array[Clay_TransitionElementConfig]
+	TransformElementConfigs   Clay__Array[Clay_TransformElementConfig]
+	ShadowElementConfigs      Clay__Array[Clay_ShadowElementConfig]
 
 	// Misc Data Structures
 	LayoutElementIdStrings             Clay__Array[Clay_String]
 	WrappedTextLines                   Clay__Array[Clay__WrappedTextLine]
+	WrappedTextFragments               Clay__Array[Clay__WrappedTextFragment]
 	LayoutElementTreeNodeArray1        Clay__Array[Clay__LayoutElementTreeNode]
 	LayoutElementTreeRoots             Clay__Array[Clay__LayoutElementTreeRoot]
+	LayoutElementTreeRootsSortBuffer   Clay__Array[Clay__LayoutElementTreeRoot]
+	ElementConfigSortBuffer            Clay__Array[int32]
 	LayoutElementsHashMapInternal      Clay__Array[Clay_LayoutElementHashMapItem]
 	LayoutElementsHashMap              Clay__Array[int32]
 	MeasureTextHashMapInternal         Clay__Array[Clay__MeasureTextCacheItem]
@@ -70,7 +85,13 @@ type Clay_Context struct {
 	ScrollContainerDatas               Clay__Array[Clay__ScrollContainerDataInternal]
 	TreeNodeVisited                    Clay__Array[bool]
 	DynamicStringData                  Clay__Array[byte] // char
+	PreviousDynamicStringData          Clay__Array[byte]
 	DebugElementData                   Clay__Array[Clay__DebugElementData]
+	GridLayoutDatas                    Clay__Array[Clay__GridLayoutData]
+	GridItemPlacements                 Clay__Array[Clay__GridItemPlacement]
+	GridTrackSizes                     Clay__Array[float32]
+	TransitionElements                 Clay__Array[Clay__TransitionElement]
+	TransitionDatas                    Clay__Array[Clay__TransitionDataInternal]
 }
 
 func Clay_SetCurrentContext(context *Clay_Context) {
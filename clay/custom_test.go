@@ -0,0 +1,57 @@
+package clay
+
+import (
+	"testing"
+)
+
+type testChartData struct {
+	series []float32
+}
+
+func TestCustomElements(t *testing.T) {
+	chart := &testChartData{series: []float32{1, 2, 3}}
+	newTestContext(t, 800, 600)
+	commands := runTestLayout(func() {
+		CLAY_ROOT(CLAY_ID("outer"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{Padding: CLAY_PADDING_ALL(10), ChildGap: 5}},
+			CLAY(CLAY_ID("legend"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(100), Height: CLAY_SIZING_FIXED(50)}}}),
+			CLAY(CLAY_ID("chart"), Clay_ElementDeclaration{
+				Layout:          Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(300), Height: CLAY_SIZING_FIXED(200)}},
+				BackgroundColor: Clay_Color{R: 10, G: 20, B: 30, A: 255},
+				CornerRadius:    CLAY_CORNER_RADIUS(4),
+				Custom:          Clay_CustomElementConfig{CustomData: chart},
+			}),
+		)
+	})
+
+	chartId := CLAY_ID("chart").Id
+	var customCommands []Clay_RenderCommand
+	for _, command := range commands {
+		if command.Id != chartId {
+			continue
+		}
+		if command.CommandType != CLAY_RENDER_COMMAND_TYPE_CUSTOM {
+			t.Errorf("expected only a CUSTOM command for the chart, got %s", command.CommandType)
+			continue
+		}
+		customCommands = append(customCommands, command)
+	}
+	if len(customCommands) != 1 {
+		t.Fatalf("expected 1 CUSTOM command, got %d", len(customCommands))
+	}
+
+	command := customCommands[0]
+	expectedBox := Clay_BoundingBox{X: 115, Y: 10, Width: 300, Height: 200}
+	if command.BoundingBox != expectedBox {
+		t.Errorf("expected bounding box %v, got %v", expectedBox, command.BoundingBox)
+	}
+	customData := command.RenderData.Custom
+	if customData.CustomData != chart {
+		t.Errorf("expected CustomData to be passed through, got %v", customData.CustomData)
+	}
+	if customData.BackgroundColor != (Clay_Color{R: 10, G: 20, B: 30, A: 255}) {
+		t.Errorf("unexpected background color %v", customData.BackgroundColor)
+	}
+	if customData.CornerRadius != CLAY_CORNER_RADIUS(4) {
+		t.Errorf("unexpected corner radius %v", customData.CornerRadius)
+	}
+}
@@ -7,6 +7,11 @@ var CLAY__STRING_DEFAULT = Clay_String{Length: 0, Chars: make([]byte, 0)}
 
 var CLAY__SPACECHAR = Clay_String{Length: 1, Chars: []byte{' '}}
 
+var CLAY__ELLIPSIS = Clay_String{Length: 3, Chars: []byte("…")}
+
+// U+00AD SOFT HYPHEN, which marks where a word may be hyphenated.
+var CLAY__SOFT_HYPHEN = Clay_String{Length: 2, Chars: []byte("\u00ad")}
+
 var Clay_LayoutConfig_DEFAULT = Clay_LayoutConfig{}
 
 var Clay__CornerRadius_DEFAULT = Clay_CornerRadius{}
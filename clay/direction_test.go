@@ -0,0 +1,134 @@
+package clay
+
+import "testing"
+
+func TestReversedLayoutDirections(t *testing.T) {
+	t.Run("right to left", func(t *testing.T) {
+		newTestContext(t, 800, 600)
+		runTestLayout(func() {
+			CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{},
+				CLAY(CLAY_ID("parent"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{
+					Sizing:          Clay_Sizing{Width: CLAY_SIZING_FIXED(300)},
+					Padding:         Clay_Padding{Left: 10, Right: 20},
+					ChildGap:        10,
+					ChildAlignment:  Clay_ChildAlignment{X: CLAY_ALIGN_X_RIGHT},
+					LayoutDirection: CLAY_RIGHT_TO_LEFT,
+				}},
+					testFixedBox("a", 50, 20),
+					testFixedBox("b", 30, 40),
+				),
+			)
+		})
+
+		testExpectBoundingBox(t, "a", Clay_BoundingBox{X: 230, Y: 0, Width: 50, Height: 20})
+		testExpectBoundingBox(t, "b", Clay_BoundingBox{X: 190, Y: 0, Width: 30, Height: 40})
+		testExpectBoundingBox(t, "parent", Clay_BoundingBox{X: 0, Y: 0, Width: 300, Height: 40})
+	})
+
+	t.Run("bottom to top", func(t *testing.T) {
+		newTestContext(t, 800, 600)
+		runTestLayout(func() {
+			CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{},
+				CLAY(CLAY_ID("parent"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{
+					Padding:         CLAY_PADDING_ALL(5),
+					ChildGap:        5,
+					LayoutDirection: CLAY_BOTTOM_TO_TOP,
+				}},
+					testFixedBox("a", 50, 20),
+					testFixedBox("b", 30, 30),
+				),
+			)
+		})
+
+		testExpectBoundingBox(t, "b", Clay_BoundingBox{X: 5, Y: 5, Width: 30, Height: 30})
+		testExpectBoundingBox(t, "a", Clay_BoundingBox{X: 5, Y: 40, Width: 50, Height: 20})
+		testExpectBoundingBox(t, "parent", Clay_BoundingBox{X: 0, Y: 0, Width: 60, Height: 65})
+	})
+
+	t.Run("wrapped lines keep their order", func(t *testing.T) {
+		newTestContext(t, 800, 600)
+		runTestLayout(func() {
+			CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{},
+				CLAY(CLAY_ID("parent"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{
+					Sizing:          Clay_Sizing{Width: CLAY_SIZING_FIXED(100)},
+					LayoutDirection: CLAY_RIGHT_TO_LEFT,
+					Wrap:            CLAY_WRAP_WRAP,
+				}},
+					testFixedBox("a", 40, 20),
+					testFixedBox("b", 40, 20),
+					testFixedBox("c", 40, 20),
+				),
+			)
+		})
+
+		testExpectBoundingBox(t, "a", Clay_BoundingBox{X: 40, Y: 0, Width: 40, Height: 20})
+		testExpectBoundingBox(t, "b", Clay_BoundingBox{X: 0, Y: 0, Width: 40, Height: 20})
+		testExpectBoundingBox(t, "c", Clay_BoundingBox{X: 0, Y: 20, Width: 40, Height: 20})
+	})
+}
+
+func TestRTLMirrorsLayout(t *testing.T) {
+	newTestContext(t, 800, 600)
+	Clay_SetRTLEnabled(true)
+	commands := runTestLayout(func() {
+		CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{},
+			CLAY(CLAY_ID("parent"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{
+				Sizing:   Clay_Sizing{Width: CLAY_SIZING_FIXED(200)},
+				Padding:  Clay_Padding{Left: 10},
+				ChildGap: 10,
+			}},
+				CLAY(CLAY_ID("a"), Clay_ElementDeclaration{
+					Layout:          Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(50), Height: CLAY_SIZING_FIXED(20)}},
+					BackgroundColor: Clay_Color{R: 255, A: 255},
+					CornerRadius:    Clay_CornerRadius{TopLeft: 5},
+					Border:          Clay_BorderElementConfig{Color: Clay_Color{A: 255}, Width: Clay_BorderWidth{Left: 2}},
+				}),
+				testFixedBox("b", 30, 20),
+				CLAY(CLAY_ID("popover"), Clay_ElementDeclaration{
+					Layout: Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(40), Height: CLAY_SIZING_FIXED(10)}},
+					Floating: Clay_FloatingElementConfig{
+						AttachTo:     CLAY_ATTACH_TO_PARENT,
+						AttachPoints: Clay_FloatingAttachPoints{Element: CLAY_ATTACH_POINT_LEFT_TOP, Parent: CLAY_ATTACH_POINT_LEFT_BOTTOM},
+						Offset:       Clay_Vector2{X: 5},
+					},
+				}),
+			),
+		)
+	})
+
+	// Every box trades sides within the 800 wide layout, so padding and children start from the right
+	testExpectBoundingBox(t, "parent", Clay_BoundingBox{X: 600, Y: 0, Width: 200, Height: 20})
+	testExpectBoundingBox(t, "a", Clay_BoundingBox{X: 740, Y: 0, Width: 50, Height: 20})
+	testExpectBoundingBox(t, "b", Clay_BoundingBox{X: 700, Y: 0, Width: 30, Height: 20})
+	testExpectBoundingBox(t, "popover", Clay_BoundingBox{X: 755, Y: 20, Width: 40, Height: 10})
+
+	// Borders are emitted with an ID derived from the element's, so match them by their box instead
+	mirroredBox := Clay_BoundingBox{X: 740, Y: 0, Width: 50, Height: 20}
+	foundRectangle, foundBorder := false, false
+	for _, command := range commands {
+		if command.BoundingBox != mirroredBox {
+			continue
+		}
+		switch command.CommandType {
+		case CLAY_RENDER_COMMAND_TYPE_RECTANGLE:
+			foundRectangle = true
+			if command.RenderData.Rectangle.CornerRadius != (Clay_CornerRadius{TopRight: 5}) {
+				t.Errorf("expected the top left corner radius to move to the top right, got %v", command.RenderData.Rectangle.CornerRadius)
+			}
+		case CLAY_RENDER_COMMAND_TYPE_BORDER:
+			foundBorder = true
+			if command.RenderData.Border.Width != (Clay_BorderWidth{Right: 2}) {
+				t.Errorf("expected the left border to move to the right, got %v", command.RenderData.Border.Width)
+			}
+		}
+	}
+	if !foundRectangle || !foundBorder {
+		t.Fatalf("expected rectangle and border render commands for a")
+	}
+
+	// Hit testing uses the mirrored boxes
+	Clay_SetPointerState(Clay_Vector2{X: 745, Y: 10}, false)
+	if !Clay_PointerOver(CLAY_ID("a")) || Clay_PointerOver(CLAY_ID("b")) {
+		t.Errorf("expected only a to be hovered at its mirrored position")
+	}
+}
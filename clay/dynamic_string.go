@@ -0,0 +1,87 @@
+package clay
+
+import (
+	"unsafe"
+
+	"github.com/zodimo/clay-go/pkg/mem"
+)
+
+// Empties the buffer for the text generated by the next layout. Text generated by the last layout stays alive in
+// PreviousDynamicStringData, as the render commands kept by incremental layout and exit transitions use it, while
+// the buffer of the layout before it is reused. Commands that are kept for longer have their text moved out of it with
+// Clay__MoveDynamicStrings.
+func Clay__ResetDynamicStringData(context *Clay_Context, arena *Clay_Arena) {
+	context.DynamicStringData, context.PreviousDynamicStringData = context.PreviousDynamicStringData, context.DynamicStringData
+	if context.MaxDynamicStringDataBytes <= 0 {
+		// Without any capacity, generating text reports CLAY_ERROR_TYPE_DYNAMIC_STRING_CAPACITY_EXCEEDED
+		context.DynamicStringData = Clay__Array[byte]{}
+		return
+	}
+	Clay__Array_Reallocate_Arena(&context.DynamicStringData, context.MaxDynamicStringDataBytes, arena)
+}
+
+// Reports whether chars lies in the memory of data.
+func Clay__DynamicStringDataContains(data *Clay__Array[byte], chars []byte) bool {
+	memory := data.InternalArray()
+	if len(chars) == 0 || cap(memory) == 0 {
+		return false
+	}
+	start := uintptr(unsafe.Pointer(unsafe.SliceData(memory[:1])))
+	address := uintptr(unsafe.Pointer(unsafe.SliceData(chars)))
+	return address >= start && address < start+uintptr(cap(memory))
+}
+
+// Copies the text of the render commands that was generated during the last layout into the buffer of the current
+// layout, for render commands that incremental layout reuses.
+func Clay__MoveDynamicStrings(renderCommands []Clay_RenderCommand) {
+	currentContext := Clay_GetCurrentContext()
+	for i := range renderCommands {
+		renderCommand := &renderCommands[i]
+		if renderCommand.CommandType != CLAY_RENDER_COMMAND_TYPE_TEXT {
+			continue
+		}
+		text := &renderCommand.RenderData.Text.StringContents
+		if Clay__DynamicStringDataContains(&currentContext.PreviousDynamicStringData, text.Chars) {
+			text.Chars = Clay__MoveDynamicString(text.Chars[:text.Length])
+		}
+	}
+}
+
+// Returns a copy of chars, which was generated during the last layout, in the buffer of the current layout. Returns
+// chars itself if the buffer is full.
+func Clay__MoveDynamicString(chars []byte) []byte {
+	currentContext := Clay_GetCurrentContext()
+	stringStart := currentContext.DynamicStringData.Length()
+	length := int32(len(chars))
+	if stringStart+length > currentContext.DynamicStringData.Capacity() {
+		if !currentContext.BooleanWarnings.MaxDynamicStringDataExceeded {
+			Clay__ReportError(CLAY_ERROR_TYPE_DYNAMIC_STRING_CAPACITY_EXCEEDED, CLAY_STRING("Clay ran out of capacity while reusing generated text. Try setting Clay_Context.MaxDynamicStringDataBytes to a higher value."))
+			currentContext.BooleanWarnings.MaxDynamicStringDataExceeded = true
+		}
+		return chars
+	}
+	Clay__Array_Grow(&currentContext.DynamicStringData, length)
+	moved := mem.MArray_GetSlice(&currentContext.DynamicStringData, stringStart, stringStart+length)
+	copy(moved, chars)
+	return moved
+}
+
+// Copies the text of the render commands that was generated during the current layout into storage, which is reused
+// across layouts, for render commands that are kept until an exit transition finishes.
+func Clay__KeepDynamicStrings(renderCommands []Clay_RenderCommand, storage *[]byte) {
+	currentContext := Clay_GetCurrentContext()
+	*storage = (*storage)[:0]
+	for i := range renderCommands {
+		renderCommand := &renderCommands[i]
+		if renderCommand.CommandType != CLAY_RENDER_COMMAND_TYPE_TEXT {
+			continue
+		}
+		text := &renderCommand.RenderData.Text.StringContents
+		if !Clay__DynamicStringDataContains(&currentContext.DynamicStringData, text.Chars) {
+			continue
+		}
+		start := len(*storage)
+		*storage = append(*storage, text.Chars[:text.Length]...)
+		text.Chars = (*storage)[start:len(*storage):len(*storage)]
+	}
+}
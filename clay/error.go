@@ -1,5 +1,10 @@
 package clay
 
+import (
+	"strconv"
+	"strings"
+)
+
 type Clay_ErrorHandler struct {
 	ErrorHandlerFunction func(errorData Clay_ErrorData)
 	UserData             interface{}
@@ -35,6 +40,8 @@ const (
 	CLAY_ERROR_TYPE_INTERNAL_ERROR
 	// Clay__OpenElement was called more times than Clay__CloseElement, so there were still remaining open elements when the layout ended.
 	CLAY_ERROR_TYPE_UNBALANCED_OPEN_CLOSE
+	// Clay ran out of space for the text it generates while laying out, like truncated and hyphenated lines. This limit can be increased with Clay_Context.MaxDynamicStringDataBytes.
+	CLAY_ERROR_TYPE_DYNAMIC_STRING_CAPACITY_EXCEEDED
 )
 
 func (e Clay_ErrorType) String() string {
@@ -57,6 +64,8 @@ func (e Clay_ErrorType) String() string {
 		return "INTERNAL_ERROR"
 	case CLAY_ERROR_TYPE_UNBALANCED_OPEN_CLOSE:
 		return "UNBALANCED_OPEN_CLOSE"
+	case CLAY_ERROR_TYPE_DYNAMIC_STRING_CAPACITY_EXCEEDED:
+		return "DYNAMIC_STRING_CAPACITY_EXCEEDED"
 	default:
 		return "UNKNOWN_ERROR_TYPE"
 	}
@@ -78,4 +87,132 @@ type Clay_ErrorData struct {
 	ErrorText Clay_String
 	// A transparent pointer passed through from when the error handler was first provided.
 	UserData interface{}
+
+	// The ID of the element that caused the error, if the error is associated with a specific element.
+	// For errors that are not tied to an element (e.g. capacity errors) this is the zero value.
+	ElementId Clay_ElementId
+	// The IDs of the elements that were open when the error occurred, outermost first, not including ElementId itself.
+	// This is only populated for errors raised while elements are being declared.
+	ParentIds []Clay_ElementId
+	// For CLAY_ERROR_TYPE_DUPLICATE_ID, the IDs of the parents of the first element declared with ElementId, outermost first.
+	PreviousParentIds []Clay_ElementId
+}
+
+// Implements the error interface, so that a Clay_ErrorType can be used as the target of errors.Is.
+func (e Clay_ErrorType) Error() string {
+	return "clay: " + e.String()
+}
+
+// Returns the path of the offending element as a sequence of IDs, outermost first, e.g. "Clay__RootContainer > Sidebar > #2791340921".
+// Elements without a string ID are printed as their numeric hash.
+func (errorData Clay_ErrorData) Path() string {
+	if errorData.ElementId.Id == 0 && len(errorData.ParentIds) == 0 {
+		return ""
+	}
+	var path strings.Builder
+	for _, parentId := range errorData.ParentIds {
+		path.WriteString(Clay__ElementIdToString(parentId))
+		path.WriteString(" > ")
+	}
+	path.WriteString(Clay__ElementIdToString(errorData.ElementId))
+	return path.String()
+}
+
+// Wraps the error data in a Clay_Error, so that it can be returned, wrapped and matched as a regular Go error.
+func (errorData Clay_ErrorData) Err() error {
+	return &Clay_Error{Data: errorData}
+}
+
+// Clay_Error adapts Clay_ErrorData to the error interface. It unwraps to its Clay_ErrorType, so callers can match
+// errors reported through the error handler with errors.Is(err, CLAY_ERROR_TYPE_DUPLICATE_ID).
+type Clay_Error struct {
+	Data Clay_ErrorData
+}
+
+func (e *Clay_Error) Error() string {
+	message := e.Data.ErrorType.Error()
+	if e.Data.ErrorText.Length > 0 {
+		message += ": " + string(e.Data.ErrorText.Chars[:e.Data.ErrorText.Length])
+	}
+	if path := e.Data.Path(); path != "" {
+		message += " (element: " + path
+		if e.Data.ErrorType == CLAY_ERROR_TYPE_DUPLICATE_ID && len(e.Data.PreviousParentIds) > 0 {
+			previous := Clay_ErrorData{ElementId: e.Data.ElementId, ParentIds: e.Data.PreviousParentIds}
+			message += ", previously declared at: " + previous.Path()
+		}
+		message += ")"
+	}
+	return message
+}
+
+func (e *Clay_Error) Unwrap() error {
+	return e.Data.ErrorType
+}
+
+func Clay__ElementIdToString(elementId Clay_ElementId) string {
+	if elementId.StringId.Length == 0 {
+		return "#" + strconv.FormatUint(uint64(elementId.Id), 10)
+	}
+	id := string(elementId.StringId.Chars[:elementId.StringId.Length])
+	if elementId.Offset != 0 {
+		id += "[" + strconv.FormatUint(uint64(elementId.Offset), 10) + "]"
+	}
+	return id
+}
+
+// Reports an error that isn't associated with a particular element.
+func Clay__ReportError(errorType Clay_ErrorType, errorText Clay_String) {
+	currentContext := Clay_GetCurrentContext()
+	currentContext.ErrorHandler.ErrorHandlerFunction(Clay_ErrorData{
+		ErrorType: errorType,
+		ErrorText: errorText,
+		UserData:  currentContext.ErrorHandler.UserData,
+	})
+}
+
+// Reports an error caused by the element with the provided ID. Must only be called while elements are being declared,
+// as the parent chain is read from the open layout element stack.
+func Clay__ReportElementError(errorType Clay_ErrorType, errorText Clay_String, elementId Clay_ElementId) {
+	currentContext := Clay_GetCurrentContext()
+	currentContext.ErrorHandler.ErrorHandlerFunction(Clay_ErrorData{
+		ErrorType: errorType,
+		ErrorText: errorText,
+		UserData:  currentContext.ErrorHandler.UserData,
+		ElementId: elementId,
+		ParentIds: Clay__GetOpenElementParentIds(elementId.Id),
+	})
+}
+
+// Returns the IDs of the currently open elements, outermost first. The top of the stack is skipped if it is the element with excludeId.
+func Clay__GetOpenElementParentIds(excludeId uint32) []Clay_ElementId {
+	currentContext := Clay_GetCurrentContext()
+	stackLength := currentContext.OpenLayoutElementStack.Length()
+	if stackLength > 0 && Clay__Array_Get(&currentContext.LayoutElements, Clay__Array_GetValue(&currentContext.OpenLayoutElementStack, stackLength-1)).Id == excludeId {
+		stackLength--
+	}
+	parentIds := make([]Clay_ElementId, 0, stackLength)
+	for i := int32(0); i < stackLength; i++ {
+		elementIndex := Clay__Array_GetValue(&currentContext.OpenLayoutElementStack, i)
+		// The root container index is pushed onto the stack a second time by Clay_BeginLayout
+		if i > 0 && elementIndex == Clay__Array_GetValue(&currentContext.OpenLayoutElementStack, i-1) {
+			continue
+		}
+		parentIds = append(parentIds, Clay__GetElementIdForLayoutElement(elementIndex))
+	}
+	return parentIds
+}
+
+// Returns the full element ID (including its string ID) of the layout element at the provided index.
+func Clay__GetElementIdForLayoutElement(elementIndex int32) Clay_ElementId {
+	currentContext := Clay_GetCurrentContext()
+	layoutElement := Clay__Array_Get(&currentContext.LayoutElements, elementIndex)
+	hashMapItem := Clay__GetHashMapItem(layoutElement.Id)
+	if hashMapItem != &Clay_LayoutElementHashMapItem_DEFAULT {
+		return hashMapItem.ElementId
+	}
+	elementId := Clay_ElementId{Id: layoutElement.Id}
+	if elementIndex < currentContext.LayoutElementIdStrings.Length() {
+		elementId.StringId = Clay__Array_GetValue(&currentContext.LayoutElementIdStrings, elementIndex)
+	}
+	return elementId
 }
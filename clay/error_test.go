@@ -0,0 +1,76 @@
+package clay
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrorData(t *testing.T) {
+	t.Run("duplicate id includes element and parent chain", func(t *testing.T) {
+		reported := newTestContext(t, 800, 600)
+		runTestLayout(func() {
+			CLAY_ROOT(CLAY_ID("outer"), Clay_ElementDeclaration{},
+				CLAY(CLAY_ID("list"), Clay_ElementDeclaration{},
+					CLAY(CLAY_ID("item"), Clay_ElementDeclaration{}),
+					CLAY(CLAY_ID("item"), Clay_ElementDeclaration{}),
+				),
+			)
+		})
+		if len(*reported) != 1 {
+			t.Fatalf("expected 1 error, got %d", len(*reported))
+		}
+		errorData := (*reported)[0]
+		if errorData.ErrorType != CLAY_ERROR_TYPE_DUPLICATE_ID {
+			t.Errorf("expected DUPLICATE_ID, got %s", errorData.ErrorType)
+		}
+		if errorData.ElementId.Id != CLAY_ID("item").Id {
+			t.Errorf("expected the offending element id to be item, got %d", errorData.ElementId.Id)
+		}
+		if got := errorData.Path(); got != "Clay__RootContainer > outer > list > item" {
+			t.Errorf("unexpected path %q", got)
+		}
+	})
+
+	t.Run("percentage over 1 reports the configured element", func(t *testing.T) {
+		reported := newTestContext(t, 800, 600)
+		runTestLayout(func() {
+			CLAY_ROOT(CLAY_ID("outer"), Clay_ElementDeclaration{},
+				CLAY(CLAY_ID("wide"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{Sizing: Clay_Sizing{Width: Clay_SizingAxis{Type: CLAY__SIZING_TYPE_PERCENT, Size: Clay_SizingAxisSize{Percent: 1.5}}}}}),
+			)
+		})
+		if len(*reported) != 1 {
+			t.Fatalf("expected 1 error, got %d", len(*reported))
+		}
+		if got := (*reported)[0].Path(); got != "Clay__RootContainer > outer > wide" {
+			t.Errorf("unexpected path %q", got)
+		}
+	})
+}
+
+func TestClayError(t *testing.T) {
+	err := fmt.Errorf("layout failed: %w", Clay_ErrorData{
+		ErrorType: CLAY_ERROR_TYPE_FLOATING_CONTAINER_PARENT_NOT_FOUND,
+		ErrorText: CLAY_STRING("parent not found"),
+		ElementId: CLAY_ID("tooltip"),
+		ParentIds: []Clay_ElementId{CLAY_ID("screen")},
+	}.Err())
+
+	if !errors.Is(err, CLAY_ERROR_TYPE_FLOATING_CONTAINER_PARENT_NOT_FOUND) {
+		t.Error("expected errors.Is to match the error type")
+	}
+	if errors.Is(err, CLAY_ERROR_TYPE_DUPLICATE_ID) {
+		t.Error("expected errors.Is not to match a different error type")
+	}
+	var clayError *Clay_Error
+	if !errors.As(err, &clayError) {
+		t.Fatal("expected errors.As to find a *Clay_Error")
+	}
+	if clayError.Data.ElementId.Id != CLAY_ID("tooltip").Id {
+		t.Error("expected the element id to be preserved")
+	}
+	expected := "layout failed: clay: FLOATING_CONTAINER_PARENT_NOT_FOUND: parent not found (element: screen > tooltip)"
+	if err.Error() != expected {
+		t.Errorf("expected %q, got %q", expected, err.Error())
+	}
+}
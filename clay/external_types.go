@@ -1,4 +1,5 @@
 package clay
 
 type Clay__MeasureTextFunction func(text Clay_StringSlice, config *Clay_TextElementConfig, userData interface{}) Clay_Dimensions
+type Clay__MeasureTextMetricsFunction func(text Clay_StringSlice, config *Clay_TextElementConfig, userData interface{}) Clay_TextMetrics
 type Clay__QueryScrollOffsetFunction func(elementId uint32, userData interface{}) Clay_Vector2
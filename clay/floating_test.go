@@ -0,0 +1,150 @@
+package clay
+
+import (
+	"testing"
+)
+
+var testAttachPointNames = []string{
+	"LEFT_TOP", "LEFT_CENTER", "LEFT_BOTTOM",
+	"CENTER_TOP", "CENTER_CENTER", "CENTER_BOTTOM",
+	"RIGHT_TOP", "RIGHT_CENTER", "RIGHT_BOTTOM",
+}
+
+// Returns the expected top left corner of a floating element with the provided dimensions, attached to parent.
+func expectedFloatingPosition(parent Clay_BoundingBox, element Clay_Dimensions, attachPoints Clay_FloatingAttachPoints, offset Clay_Vector2) Clay_Vector2 {
+	parentX, parentY := int(attachPoints.Parent)/3, int(attachPoints.Parent)%3
+	elementX, elementY := int(attachPoints.Element)/3, int(attachPoints.Element)%3
+	return Clay_Vector2{
+		X: parent.X + parent.Width*float32(parentX)/2 - element.Width*float32(elementX)/2 + offset.X,
+		Y: parent.Y + parent.Height*float32(parentY)/2 - element.Height*float32(elementY)/2 + offset.Y,
+	}
+}
+
+func TestFloatingAttachModes(t *testing.T) {
+	floatingSize := Clay_Dimensions{Width: 40, Height: 20}
+	offset := Clay_Vector2{X: 3, Y: 4}
+	anchorBox := Clay_BoundingBox{X: 50, Y: 50, Width: 200, Height: 100}
+	rootBox := Clay_BoundingBox{X: 0, Y: 0, Width: 800, Height: 600}
+
+	floating := func(attachTo Clay_FloatingAttachToElement, attachPoints Clay_FloatingAttachPoints) ClayContainer {
+		return CLAY(CLAY_ID("floating"), Clay_ElementDeclaration{
+			Layout: Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(floatingSize.Width), Height: CLAY_SIZING_FIXED(floatingSize.Height)}},
+			Floating: Clay_FloatingElementConfig{
+				AttachTo:     attachTo,
+				AttachPoints: attachPoints,
+				ParentId:     CLAY_ID("anchor").Id,
+				Offset:       offset,
+			},
+		})
+	}
+	anchor := func(content ...ClayContainer) ClayContainer {
+		return CLAY(CLAY_ID("anchor"), Clay_ElementDeclaration{
+			Layout: Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(anchorBox.Width), Height: CLAY_SIZING_FIXED(anchorBox.Height)}},
+		}, content...)
+	}
+	rootDeclaration := Clay_ElementDeclaration{Layout: Clay_LayoutConfig{
+		Sizing:  Clay_Sizing{Width: CLAY_SIZING_GROW(Clay_SizingMinMax{}), Height: CLAY_SIZING_GROW(Clay_SizingMinMax{})},
+		Padding: CLAY_PADDING_ALL(50),
+	}}
+
+	modes := []struct {
+		name   string
+		parent Clay_BoundingBox
+		layout func(attachPoints Clay_FloatingAttachPoints)
+	}{
+		{"parent", anchorBox, func(attachPoints Clay_FloatingAttachPoints) {
+			CLAY_ROOT(CLAY_ID("outer"), rootDeclaration, anchor(floating(CLAY_ATTACH_TO_PARENT, attachPoints)))
+		}},
+		{"element with id declared before", anchorBox, func(attachPoints Clay_FloatingAttachPoints) {
+			CLAY_ROOT(CLAY_ID("outer"), rootDeclaration, anchor(), floating(CLAY_ATTACH_TO_ELEMENT_WITH_ID, attachPoints))
+		}},
+		{"element with id declared later", anchorBox, func(attachPoints Clay_FloatingAttachPoints) {
+			CLAY_ROOT(CLAY_ID("outer"), rootDeclaration, floating(CLAY_ATTACH_TO_ELEMENT_WITH_ID, attachPoints), anchor())
+		}},
+		{"root", rootBox, func(attachPoints Clay_FloatingAttachPoints) {
+			CLAY_ROOT(CLAY_ID("outer"), rootDeclaration, anchor(floating(CLAY_ATTACH_TO_ROOT, attachPoints)))
+		}},
+	}
+
+	for _, mode := range modes {
+		t.Run(mode.name, func(t *testing.T) {
+			reported := newTestContext(t, 800, 600)
+			for parentPoint := CLAY_ATTACH_POINT_LEFT_TOP; parentPoint <= CLAY_ATTACH_POINT_RIGHT_BOTTOM; parentPoint++ {
+				for elementPoint := CLAY_ATTACH_POINT_LEFT_TOP; elementPoint <= CLAY_ATTACH_POINT_RIGHT_BOTTOM; elementPoint++ {
+					attachPoints := Clay_FloatingAttachPoints{Element: elementPoint, Parent: parentPoint}
+					runTestLayout(func() { mode.layout(attachPoints) })
+
+					if got := testBoundingBox(t, "anchor"); got != anchorBox {
+						t.Fatalf("unexpected anchor bounding box %v", got)
+					}
+					expected := expectedFloatingPosition(mode.parent, floatingSize, attachPoints, offset)
+					got := testBoundingBox(t, "floating")
+					if got.X != expected.X || got.Y != expected.Y || got.Width != floatingSize.Width || got.Height != floatingSize.Height {
+						t.Errorf("parent %s, element %s: expected position %v, got %v",
+							testAttachPointNames[parentPoint], testAttachPointNames[elementPoint], expected, got)
+					}
+				}
+			}
+			if len(*reported) != 0 {
+				t.Errorf("expected no errors, got %v", (*reported)[0].ErrorType)
+			}
+		})
+	}
+}
+
+func TestFloatingAttachToElementWithId(t *testing.T) {
+	t.Run("missing parent is reported", func(t *testing.T) {
+		reported := newTestContext(t, 800, 600)
+		runTestLayout(func() {
+			CLAY_ROOT(CLAY_ID("outer"), Clay_ElementDeclaration{},
+				CLAY(CLAY_ID("tooltip"), Clay_ElementDeclaration{Floating: Clay_FloatingElementConfig{
+					AttachTo: CLAY_ATTACH_TO_ELEMENT_WITH_ID,
+					ParentId: CLAY_ID("missing").Id,
+				}}),
+			)
+		})
+		if len(*reported) != 1 || (*reported)[0].ErrorType != CLAY_ERROR_TYPE_FLOATING_CONTAINER_PARENT_NOT_FOUND {
+			t.Fatalf("expected a single FLOATING_CONTAINER_PARENT_NOT_FOUND error, got %v", *reported)
+		}
+		if got := (*reported)[0].Path(); got != "Clay__RootContainer > outer > tooltip" {
+			t.Errorf("unexpected path %q", got)
+		}
+	})
+
+	t.Run("inherits the clip of a parent declared later", func(t *testing.T) {
+		newTestContext(t, 800, 600)
+		commands := runTestLayout(func() {
+			CLAY_ROOT(CLAY_ID("outer"), Clay_ElementDeclaration{},
+				CLAY(CLAY_ID("popover"), Clay_ElementDeclaration{
+					Layout:          Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(10), Height: CLAY_SIZING_FIXED(10)}},
+					BackgroundColor: Clay_Color{R: 255, A: 255},
+					Floating: Clay_FloatingElementConfig{
+						AttachTo: CLAY_ATTACH_TO_ELEMENT_WITH_ID,
+						ParentId: CLAY_ID("trigger").Id,
+						ClipTo:   CLAY_CLIP_TO_ATTACHED_PARENT,
+					},
+				}),
+				CLAY(CLAY_ID("scroll"), Clay_ElementDeclaration{
+					Layout: Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(100), Height: CLAY_SIZING_FIXED(100)}},
+					Clip:   Clay_ClipElementConfig{Vertical: true},
+				},
+					CLAY(CLAY_ID("trigger"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(50), Height: CLAY_SIZING_FIXED(50)}}}),
+				),
+			)
+		})
+		popoverId := CLAY_ID("popover").Id
+		for i, command := range commands {
+			if command.Id != popoverId || command.CommandType != CLAY_RENDER_COMMAND_TYPE_RECTANGLE {
+				continue
+			}
+			if i == 0 || commands[i-1].CommandType != CLAY_RENDER_COMMAND_TYPE_SCISSOR_START {
+				t.Fatalf("expected the popover to be preceded by a SCISSOR_START command")
+			}
+			if scissor := commands[i-1].BoundingBox; scissor != testBoundingBox(t, "scroll") {
+				t.Errorf("expected the popover to be clipped to the scroll container, got %v", scissor)
+			}
+			return
+		}
+		t.Fatal("popover rectangle was not rendered")
+	})
+}
@@ -2,6 +2,10 @@ package clay
 
 var QueryScrollOffsetFunction Clay__QueryScrollOffsetFunction = nil
 var MeasureTextFunction Clay__MeasureTextFunction = nil
+var MeasureTextMetricsFunction Clay__MeasureTextMetricsFunction = nil
+
+// Kept outside of the context's arena, as the segmenter's buffers are allocated by Go.
+var TextSegmenter Clay__TextSegmenter
 
 func Clay__QueryScrollOffset(elementId uint32, userData interface{}) Clay_Vector2 {
 	if QueryScrollOffsetFunction == nil {
@@ -16,3 +20,13 @@ func Clay__MeasureText(text Clay_StringSlice, config *Clay_TextElementConfig, us
 	}
 	return MeasureTextFunction(text, config, userData)
 }
+
+// Measures text with the Clay__MeasureTextMetricsFunction when one is set, or reports the bottom of the text as its
+// ascent otherwise.
+func Clay__MeasureTextMetrics(text Clay_StringSlice, config *Clay_TextElementConfig, userData interface{}) Clay_TextMetrics {
+	if MeasureTextMetricsFunction == nil {
+		dimensions := Clay__MeasureText(text, config, userData)
+		return Clay_TextMetrics{Dimensions: dimensions, Ascent: dimensions.Height}
+	}
+	return MeasureTextMetricsFunction(text, config, userData)
+}
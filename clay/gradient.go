@@ -0,0 +1,25 @@
+package clay
+
+// Returns whether a gradient has a type and any stops to fill with.
+func Clay_GradientIsSet(gradient *Clay_Gradient) bool {
+	return gradient.Type != CLAY_GRADIENT_TYPE_NONE && len(gradient.Stops) > 0
+}
+
+// Mirrors the points of a gradient horizontally across the center of its element.
+func Clay__MirrorGradient(gradient Clay_Gradient) Clay_Gradient {
+	gradient.Start.X = 1 - gradient.Start.X
+	gradient.End.X = 1 - gradient.End.X
+	return gradient
+}
+
+// Returns a gradient with the alpha of its colors multiplied by opacity. The stops are owned by the element
+// declaration, so the faded stops are appended to stops instead, which is reused across layouts to avoid allocating.
+func Clay__FadeGradient(gradient Clay_Gradient, opacity float32, stops *[]Clay_GradientStop) Clay_Gradient {
+	start := len(*stops)
+	for _, stop := range gradient.Stops {
+		stop.Color.A *= opacity
+		*stops = append(*stops, stop)
+	}
+	gradient.Stops = (*stops)[start:len(*stops):len(*stops)]
+	return gradient
+}
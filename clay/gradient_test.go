@@ -0,0 +1,91 @@
+package clay
+
+import (
+	"reflect"
+	"testing"
+)
+
+func testGradientPanel(gradient Clay_Gradient, transition Clay_TransitionElementConfig) func() {
+	return func() {
+		CLAY_ROOT(CLAY_ID("panel"), Clay_ElementDeclaration{
+			Layout:             Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(100), Height: CLAY_SIZING_FIXED(50)}},
+			BackgroundGradient: gradient,
+			CornerRadius:       Clay_CornerRadius{TopLeft: 4},
+			Transition:         transition,
+		})
+	}
+}
+
+func TestGradientFillsRectangle(t *testing.T) {
+	newTestContext(t, 800, 600)
+	gradient := Clay_Gradient{
+		Type:  CLAY_GRADIENT_TYPE_LINEAR,
+		Start: Clay_Vector2{X: 0.25},
+		End:   Clay_Vector2{X: 1, Y: 1},
+		Stops: []Clay_GradientStop{{Color: testTransitionRed}, {Color: testTransitionBlue, Position: 1}},
+	}
+	renderCommands := runTestLayout(testGradientPanel(gradient, Clay_TransitionElementConfig{}))
+
+	rectangle := testFindRenderCommand(t, renderCommands, "panel", CLAY_RENDER_COMMAND_TYPE_RECTANGLE)
+	if !reflect.DeepEqual(rectangle.RenderData.Rectangle.BackgroundGradient, gradient) {
+		t.Errorf("expected the rectangle to be filled with %+v, got %+v", gradient, rectangle.RenderData.Rectangle.BackgroundGradient)
+	}
+
+	// Gradients without stops don't fill anything
+	renderCommands = runTestLayout(testGradientPanel(Clay_Gradient{Type: CLAY_GRADIENT_TYPE_LINEAR}, Clay_TransitionElementConfig{}))
+	if len(renderCommands) != 0 {
+		t.Errorf("expected no render commands, got %v", testRenderCommandTypes(renderCommands))
+	}
+}
+
+func TestGradientIsMirroredInRTL(t *testing.T) {
+	newTestContext(t, 800, 600)
+	Clay_SetRTLEnabled(true)
+	gradient := Clay_Gradient{
+		Type:  CLAY_GRADIENT_TYPE_RADIAL,
+		Start: Clay_Vector2{X: 0.25, Y: 0.5},
+		End:   Clay_Vector2{X: 1, Y: 0.5},
+		Stops: []Clay_GradientStop{{Color: testTransitionRed}},
+	}
+	renderCommands := runTestLayout(testGradientPanel(gradient, Clay_TransitionElementConfig{}))
+
+	mirrored := testFindRenderCommand(t, renderCommands, "panel", CLAY_RENDER_COMMAND_TYPE_RECTANGLE).RenderData.Rectangle.BackgroundGradient
+	if mirrored.Start != (Clay_Vector2{X: 0.75, Y: 0.5}) || mirrored.End != (Clay_Vector2{X: 0, Y: 0.5}) {
+		t.Errorf("expected the gradient points to be mirrored, got %+v and %+v", mirrored.Start, mirrored.End)
+	}
+}
+
+func TestGradientFadesWithTransition(t *testing.T) {
+	newTestContext(t, 800, 600)
+	stops := []Clay_GradientStop{{Color: testTransitionRed}, {Color: testTransitionBlue, Position: 1}}
+	gradient := Clay_Gradient{Type: CLAY_GRADIENT_TYPE_LINEAR, End: Clay_Vector2{Y: 1}, Stops: stops}
+	transition := Clay_TransitionElementConfig{Duration: 1, Enter: Clay_TransitionEnterExitConfig{Enabled: true}}
+	testRunTransitionLayout(0, testGradientPanel(gradient, transition))
+
+	renderCommands := testRunTransitionLayout(0.5, testGradientPanel(gradient, transition))
+	faded := testFindRenderCommand(t, renderCommands, "panel", CLAY_RENDER_COMMAND_TYPE_RECTANGLE).RenderData.Rectangle.BackgroundGradient
+	for i, stop := range faded.Stops {
+		if stop.Color.A != 127.5 {
+			t.Errorf("expected stop %d to be faded to half its alpha, got %v", i, stop.Color.A)
+		}
+	}
+	if stops[0].Color.A != 255 || stops[1].Color.A != 255 {
+		t.Errorf("expected the declared stops not to be changed, got %+v", stops)
+	}
+}
+
+func TestGradientFadeReusesStops(t *testing.T) {
+	gradient := Clay_Gradient{Type: CLAY_GRADIENT_TYPE_LINEAR, Stops: []Clay_GradientStop{{Color: testTransitionRed}, {Color: testTransitionBlue, Position: 1}}}
+	stops := make([]Clay_GradientStop, 0, 4)
+	allocations := testing.AllocsPerRun(10, func() {
+		stops = stops[:0]
+		first := Clay__FadeGradient(gradient, 0.5, &stops)
+		second := Clay__FadeGradient(gradient, 0.25, &stops)
+		if first.Stops[1].Color.A != 127.5 || second.Stops[1].Color.A != 63.75 {
+			t.Fatalf("expected stops faded to 127.5 and 63.75, got %+v and %+v", first.Stops, second.Stops)
+		}
+	})
+	if allocations != 0 {
+		t.Errorf("expected fading into reused stops not to allocate, got %v allocations", allocations)
+	}
+}
@@ -0,0 +1,351 @@
+package clay
+
+import "github.com/zodimo/clay-go/pkg/mem"
+
+// The cells occupied by a child of a grid, as 0-based track indexes.
+type Clay__GridItemPlacement struct {
+	Column     int32
+	Row        int32
+	ColumnSpan int32
+	RowSpan    int32
+}
+
+// Per frame layout data of an element laid out as a grid.
+type Clay__GridLayoutData struct {
+	ColumnCount     int32
+	RowCount        int32
+	PlacementsStart int32 // Index in GridItemPlacements of the placement of the grid's first child.
+	TrackSizesStart int32 // Index in GridTrackSizes of the first column size, followed by the row sizes.
+}
+
+// Returns the grid layout data of the element, or nil if the element isn't laid out as a grid.
+func Clay__GetGridLayoutData(element *Clay_LayoutElement) *Clay__GridLayoutData {
+	if element.GridLayoutDataIndex == 0 {
+		return nil
+	}
+	return Clay__Array_Get(&Clay_GetCurrentContext().GridLayoutDatas, element.GridLayoutDataIndex-1)
+}
+
+func Clay__GridTrackAt(tracks []Clay_GridTrack, index int32) Clay_GridTrack {
+	if index < int32(len(tracks)) {
+		return tracks[index]
+	}
+	return Clay_GridTrack{}
+}
+
+func Clay__GridTrackIsContentSized(track Clay_GridTrack) bool {
+	return track.Sizing.Type == CLAY__SIZING_TYPE_FIT || track.Sizing.Type == CLAY__SIZING_TYPE_GROW
+}
+
+func Clay__GridTrackMaxSize(track Clay_GridTrack) float32 {
+	if track.Sizing.Size.MinMax.Max <= 0 {
+		return CLAY__MAXFLOAT
+	}
+	return track.Sizing.Size.MinMax.Max
+}
+
+// Returns the track definitions, track count, gap and track sizes of the grid along the provided axis.
+func Clay__GridAxis(element *Clay_LayoutElement, gridData *Clay__GridLayoutData, xAxis bool) ([]Clay_GridTrack, int32, float32, []float32) {
+	currentContext := Clay_GetCurrentContext()
+	trackSizes := mem.MArray_GetAll(&currentContext.GridTrackSizes)
+	if xAxis {
+		start := gridData.TrackSizesStart
+		return element.LayoutConfig.Grid.Columns, gridData.ColumnCount, float32(element.LayoutConfig.Grid.ColumnGap), trackSizes[start : start+gridData.ColumnCount]
+	}
+	start := gridData.TrackSizesStart + gridData.ColumnCount
+	return element.LayoutConfig.Grid.Rows, gridData.RowCount, float32(element.LayoutConfig.Grid.RowGap), trackSizes[start : start+gridData.RowCount]
+}
+
+// Returns the position and span of the placement along the provided axis.
+func Clay__GridPlacementAlongAxis(placement *Clay__GridItemPlacement, xAxis bool) (int32, int32) {
+	if xAxis {
+		return placement.Column, placement.ColumnSpan
+	}
+	return placement.Row, placement.RowSpan
+}
+
+// Returns the size of the tracks from start to start + span, including the gaps between them.
+func Clay__GridSpanSize(trackSizes []float32, gap float32, start int32, span int32) float32 {
+	size := float32(span-1) * gap
+	for i := start; i < start+span; i++ {
+		size += trackSizes[i]
+	}
+	return size
+}
+
+// Returns the total size of all tracks and gaps along the provided axis, excluding padding.
+func Clay__GridContentSize(element *Clay_LayoutElement, gridData *Clay__GridLayoutData, xAxis bool) float32 {
+	_, trackCount, gap, trackSizes := Clay__GridAxis(element, gridData, xAxis)
+	if trackCount == 0 {
+		return 0
+	}
+	return Clay__GridSpanSize(trackSizes, gap, 0, trackCount)
+}
+
+func Clay__GridCellsOverlap(a *Clay__GridItemPlacement, b *Clay__GridItemPlacement) bool {
+	return a.Column < b.Column+b.ColumnSpan && b.Column < a.Column+a.ColumnSpan && a.Row < b.Row+b.RowSpan && b.Row < a.Row+a.RowSpan
+}
+
+// Returns true if the cells of the placement aren't occupied by any of the provided earlier placements.
+func Clay__GridCellsAreFree(placements []Clay__GridItemPlacement, placement *Clay__GridItemPlacement) bool {
+	for i := range placements {
+		if Clay__GridCellsOverlap(&placements[i], placement) {
+			return false
+		}
+	}
+	return true
+}
+
+// Resolves the cells of every child of the grid. Children with an explicit Column and Row are placed there, children
+// with only one of them search the other axis for free cells, and the rest fill the next free cells row by row.
+func Clay__PlaceGridItems(element *Clay_LayoutElement, gridData *Clay__GridLayoutData) {
+	currentContext := Clay_GetCurrentContext()
+	placements := mem.MArray_GetAll(&currentContext.GridItemPlacements)[gridData.PlacementsStart : gridData.PlacementsStart+int32(element.ChildrenOrTextContent.Children.Length)]
+	columnCount := gridData.ColumnCount
+	rowCount := int32(len(element.LayoutConfig.Grid.Rows))
+	cursorRow, cursorColumn := int32(0), int32(0)
+	for i := range placements {
+		childElement := Clay__Array_Get(&currentContext.LayoutElements, element.ChildrenOrTextContent.Children.Elements[i])
+		gridItem := childElement.LayoutConfig.GridItem
+		placement := Clay__GridItemPlacement{
+			ColumnSpan: CLAY__MIN(CLAY__MAX(int32(gridItem.ColumnSpan), 1), columnCount),
+			RowSpan:    CLAY__MAX(int32(gridItem.RowSpan), 1),
+		}
+		switch {
+		case gridItem.Column > 0 && gridItem.Row > 0:
+			placement.Column = CLAY__MIN(int32(gridItem.Column)-1, columnCount-placement.ColumnSpan)
+			placement.Row = int32(gridItem.Row) - 1
+		case gridItem.Column > 0:
+			placement.Column = CLAY__MIN(int32(gridItem.Column)-1, columnCount-placement.ColumnSpan)
+			for !Clay__GridCellsAreFree(placements[:i], &placement) {
+				placement.Row++
+			}
+		case gridItem.Row > 0:
+			placement.Row = int32(gridItem.Row) - 1
+			for placement.Column+placement.ColumnSpan < columnCount && !Clay__GridCellsAreFree(placements[:i], &placement) {
+				placement.Column++
+			}
+		default:
+			placement.Row, placement.Column = cursorRow, cursorColumn
+			for {
+				if placement.Column+placement.ColumnSpan > columnCount {
+					placement.Row++
+					placement.Column = 0
+					continue
+				}
+				if Clay__GridCellsAreFree(placements[:i], &placement) {
+					break
+				}
+				placement.Column++
+			}
+			cursorRow, cursorColumn = placement.Row, placement.Column+placement.ColumnSpan
+		}
+		placements[i] = placement
+		rowCount = CLAY__MAX(rowCount, placement.Row+placement.RowSpan)
+	}
+	gridData.RowCount = rowCount
+}
+
+// Sizes the tracks of the grid along the provided axis. FIXED and PERCENT tracks are sized from their definition, FIT
+// and GROW tracks wrap to the children placed in them, then GROW tracks share any space left in availableSize by weight.
+// An availableSize below 0 means the size of the grid isn't known yet, and only content sizes are calculated.
+func Clay__SizeGridTracks(element *Clay_LayoutElement, gridData *Clay__GridLayoutData, xAxis bool, availableSize float32, useMinDimensions bool) {
+	currentContext := Clay_GetCurrentContext()
+	tracks, trackCount, gap, trackSizes := Clay__GridAxis(element, gridData, xAxis)
+	if trackCount == 0 {
+		return
+	}
+	for i := int32(0); i < trackCount; i++ {
+		track := Clay__GridTrackAt(tracks, i)
+		switch track.Sizing.Type {
+		case CLAY__SIZING_TYPE_FIXED:
+			trackSizes[i] = track.Sizing.Size.MinMax.Min
+		case CLAY__SIZING_TYPE_PERCENT:
+			trackSizes[i] = CLAY__MAX(0, availableSize-float32(trackCount-1)*gap) * track.Sizing.Size.Percent
+		default:
+			trackSizes[i] = track.Sizing.Size.MinMax.Min
+		}
+	}
+
+	placements := mem.MArray_GetAll(&currentContext.GridItemPlacements)[gridData.PlacementsStart : gridData.PlacementsStart+int32(element.ChildrenOrTextContent.Children.Length)]
+	// Children spanning a single track are measured first, so that spanning children only add the size still missing.
+	// Once the width of the grid is known, GROW columns only wrap to the minimum width of their children, so that
+	// children such as wrapping text can be compressed to fit the column's share of the grid.
+	for _, spanning := range []bool{false, true} {
+		for i := range placements {
+			start, span := Clay__GridPlacementAlongAxis(&placements[i], xAxis)
+			if (span > 1) != spanning {
+				continue
+			}
+			childElement := Clay__Array_Get(&currentContext.LayoutElements, element.ChildrenOrTextContent.Children.Elements[i])
+			if Clay__SizingAlongAxis(childElement.LayoutConfig.Sizing, xAxis).Type == CLAY__SIZING_TYPE_PERCENT {
+				continue
+			}
+			for track := start + span - 1; track >= start; track-- {
+				gridTrack := Clay__GridTrackAt(tracks, track)
+				if !Clay__GridTrackIsContentSized(gridTrack) {
+					continue
+				}
+				childSize := Clay__SizeAlongAxis(childElement.Dimensions, xAxis)
+				if useMinDimensions || (xAxis && availableSize >= 0 && gridTrack.Sizing.Type == CLAY__SIZING_TYPE_GROW) {
+					childSize = Clay__SizeAlongAxis(childElement.MinDimensions, xAxis)
+				}
+				childSize += Clay__MarginSizeAlongAxis(childElement, xAxis)
+				trackSizes[track] += CLAY__MAX(0, childSize-Clay__GridSpanSize(trackSizes, gap, start, span))
+				break
+			}
+		}
+	}
+
+	growSpace := availableSize - float32(trackCount-1)*gap
+	var totalGrowWeight float32
+	for i := int32(0); i < trackCount; i++ {
+		track := Clay__GridTrackAt(tracks, i)
+		if Clay__GridTrackIsContentSized(track) {
+			trackSizes[i] = CLAY__MIN(trackSizes[i], Clay__GridTrackMaxSize(track))
+		}
+		if track.Sizing.Type == CLAY__SIZING_TYPE_GROW {
+			totalGrowWeight += Clay__SizingGrowWeight(track.Sizing)
+		} else {
+			growSpace -= trackSizes[i]
+		}
+	}
+	if availableSize < 0 || totalGrowWeight == 0 || growSpace <= 0 {
+		return
+	}
+
+	// Find the size of one unit of grow weight. Tracks whose content is larger than their share, or whose share is
+	// larger than their max size, are frozen at that size and the unit is recalculated for the remaining tracks.
+	growUnit := growSpace / totalGrowWeight
+	for iteration := int32(0); iteration < trackCount; iteration++ {
+		remainingSpace := growSpace
+		var remainingWeight float32
+		for i := int32(0); i < trackCount; i++ {
+			track := Clay__GridTrackAt(tracks, i)
+			if track.Sizing.Type != CLAY__SIZING_TYPE_GROW {
+				continue
+			}
+			share := growUnit * Clay__SizingGrowWeight(track.Sizing)
+			if share < trackSizes[i] || share > Clay__GridTrackMaxSize(track) {
+				remainingSpace -= CLAY__MIN(CLAY__MAX(share, trackSizes[i]), Clay__GridTrackMaxSize(track))
+			} else {
+				remainingWeight += Clay__SizingGrowWeight(track.Sizing)
+			}
+		}
+		if remainingWeight == 0 {
+			break
+		}
+		nextGrowUnit := CLAY__MAX(0, remainingSpace/remainingWeight)
+		if Clay__FloatEqual(nextGrowUnit, growUnit) {
+			break
+		}
+		growUnit = nextGrowUnit
+	}
+	for i := int32(0); i < trackCount; i++ {
+		track := Clay__GridTrackAt(tracks, i)
+		if track.Sizing.Type == CLAY__SIZING_TYPE_GROW {
+			trackSizes[i] = CLAY__MIN(CLAY__MAX(growUnit*Clay__SizingGrowWeight(track.Sizing), trackSizes[i]), Clay__GridTrackMaxSize(track))
+		}
+	}
+}
+
+// Resolves the placement of the children of a grid element that is being closed, and sizes the element to fit its
+// tracks along both axes.
+func Clay__CloseGridElement(element *Clay_LayoutElement, clipHorizontal bool, clipVertical bool) {
+	currentContext := Clay_GetCurrentContext()
+	layoutConfig := element.LayoutConfig
+	childCount := int32(element.ChildrenOrTextContent.Children.Length)
+	columnCount := int32(len(layoutConfig.Grid.Columns))
+	// Upper bound of the number of rows the children can be placed in, to check capacity before placing them
+	maxRowCount := int32(len(layoutConfig.Grid.Rows))
+	for i := int32(0); i < childCount; i++ {
+		childElement := Clay__Array_Get(&currentContext.LayoutElements, element.ChildrenOrTextContent.Children.Elements[i])
+		maxRowCount += CLAY__MAX(int32(childElement.LayoutConfig.GridItem.Row), 1) + CLAY__MAX(int32(childElement.LayoutConfig.GridItem.RowSpan), 1)
+	}
+	if currentContext.GridLayoutDatas.Length() == currentContext.GridLayoutDatas.Capacity() ||
+		currentContext.GridItemPlacements.Length()+childCount > currentContext.GridItemPlacements.Capacity() ||
+		currentContext.GridTrackSizes.Length()+columnCount+maxRowCount > currentContext.GridTrackSizes.Capacity() {
+		Clay__ReportError(CLAY_ERROR_TYPE_ELEMENTS_CAPACITY_EXCEEDED, CLAY_STRING("Clay ran out of capacity while attempting to lay out a grid. Try using Clay_SetMaxElementCount() with a higher value."))
+		return
+	}
+
+	element.GridLayoutDataIndex = currentContext.GridLayoutDatas.Length() + 1
+	gridData := Clay__Array_Add(&currentContext.GridLayoutDatas, Clay__GridLayoutData{
+		ColumnCount:     columnCount,
+		PlacementsStart: currentContext.GridItemPlacements.Length(),
+		TrackSizesStart: currentContext.GridTrackSizes.Length(),
+	})
+	for i := int32(0); i < childCount; i++ {
+		Clay__Array_Add(&currentContext.GridItemPlacements, Clay__GridItemPlacement{})
+	}
+	Clay__PlaceGridItems(element, gridData)
+	for i := int32(0); i < gridData.ColumnCount+gridData.RowCount; i++ {
+		Clay__Array_Add(&currentContext.GridTrackSizes, 0)
+	}
+
+	leftRightPadding := float32(layoutConfig.Padding.Left + layoutConfig.Padding.Right)
+	topBottomPadding := float32(layoutConfig.Padding.Top + layoutConfig.Padding.Bottom)
+	Clay__SizeGridTracks(element, gridData, true, -1, true)
+	Clay__SizeGridTracks(element, gridData, false, -1, true)
+	element.MinDimensions = Clay_Dimensions{Width: leftRightPadding, Height: topBottomPadding}
+	if !clipHorizontal {
+		element.MinDimensions.Width += Clay__GridContentSize(element, gridData, true)
+	}
+	if !clipVertical {
+		element.MinDimensions.Height += Clay__GridContentSize(element, gridData, false)
+	}
+	Clay__SizeGridTracks(element, gridData, true, -1, false)
+	Clay__SizeGridTracks(element, gridData, false, -1, false)
+	element.Dimensions = Clay_Dimensions{
+		Width:  leftRightPadding + Clay__GridContentSize(element, gridData, true),
+		Height: topBottomPadding + Clay__GridContentSize(element, gridData, false),
+	}
+}
+
+// Sizes the tracks of a grid along the provided axis to fill the element, then sizes each child to the cells it spans.
+// GROW children fill their cells, and FIT children larger than their cells are compressed towards their minimum size.
+func Clay__SizeGridChildrenAlongAxis(parent *Clay_LayoutElement, gridData *Clay__GridLayoutData, xAxis bool) {
+	currentContext := Clay_GetCurrentContext()
+	Clay__SizeGridTracks(parent, gridData, xAxis, Clay__InnerSizeAlongAxis(parent, xAxis), false)
+	_, _, gap, trackSizes := Clay__GridAxis(parent, gridData, xAxis)
+	for childOffset := int32(0); childOffset < int32(parent.ChildrenOrTextContent.Children.Length); childOffset++ {
+		childElement := Clay__Array_Get(&currentContext.LayoutElements, parent.ChildrenOrTextContent.Children.Elements[childOffset])
+		start, span := Clay__GridPlacementAlongAxis(Clay__Array_Get(&currentContext.GridItemPlacements, gridData.PlacementsStart+childOffset), xAxis)
+		cellSize := CLAY__MAX(0, Clay__GridSpanSize(trackSizes, gap, start, span)-Clay__MarginSizeAlongAxis(childElement, xAxis))
+		childSizing := Clay__SizingAlongAxis(childElement.LayoutConfig.Sizing, xAxis)
+		childSize := Clay__SizeAlongAxis(childElement.Dimensions, xAxis)
+		switch {
+		case childSizing.Type == CLAY__SIZING_TYPE_PERCENT:
+			Clay__SetSizeAlongAxis(&childElement.Dimensions, xAxis, cellSize*childSizing.Size.Percent)
+			Clay__UpdateAspectRatioBox(childElement)
+			continue
+		case !Clay__ChildIsResizableAlongAxis(childElement, xAxis):
+			continue
+		case childSizing.Type == CLAY__SIZING_TYPE_GROW:
+			childSize = CLAY__MIN(cellSize, childSizing.Size.MinMax.Max)
+		default:
+			childSize = CLAY__MIN(childSize, cellSize)
+		}
+		Clay__SetSizeAlongAxis(&childElement.Dimensions, xAxis, CLAY__MAX(Clay__SizeAlongAxis(childElement.MinDimensions, xAxis), childSize))
+	}
+}
+
+// Returns the offset of a grid child's margin box from the grid's top left corner, aligned inside its cells by the grid's
+// ChildAlignment.
+func Clay__GridChildOffset(parent *Clay_LayoutElement, gridData *Clay__GridLayoutData, childOffset int32, childElement *Clay_LayoutElement) Clay_Vector2 {
+	currentContext := Clay_GetCurrentContext()
+	placement := Clay__Array_Get(&currentContext.GridItemPlacements, gridData.PlacementsStart+childOffset)
+	var offset [2]float32
+	for axis, xAxis := range []bool{true, false} {
+		_, _, gap, trackSizes := Clay__GridAxis(parent, gridData, xAxis)
+		start, span := Clay__GridPlacementAlongAxis(placement, xAxis)
+		paddingStart, _ := Clay__PaddingAlongAxis(parent.LayoutConfig.Padding, xAxis)
+		cellStart := paddingStart
+		if start > 0 {
+			cellStart += Clay__GridSpanSize(trackSizes, gap, 0, start) + gap
+		}
+		extraSpace := Clay__GridSpanSize(trackSizes, gap, start, span) - Clay__OuterSizeAlongAxis(childElement, xAxis)
+		offset[axis] = cellStart + CLAY__MAX(0, extraSpace)*Clay__ChildAlignmentFactor(parent.LayoutConfig.ChildAlignment, xAxis)
+	}
+	return Clay_Vector2{X: offset[0], Y: offset[1]}
+}
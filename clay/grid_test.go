@@ -0,0 +1,126 @@
+package clay
+
+import (
+	"fmt"
+	"testing"
+)
+
+func testGridCell(id string, gridItem Clay_GridItemConfig, sizing Clay_Sizing) ClayContainer {
+	return CLAY(CLAY_ID(id), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{Sizing: sizing, GridItem: gridItem}})
+}
+
+func TestGridTrackSizing(t *testing.T) {
+	newTestContext(t, 800, 600)
+	fill := Clay_Sizing{Width: CLAY_SIZING_GROW(Clay_SizingMinMax{}), Height: CLAY_SIZING_FIXED(20)}
+	runTestLayout(func() {
+		CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{},
+			CLAY(CLAY_ID("grid"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{
+				Sizing:  Clay_Sizing{Width: CLAY_SIZING_FIXED(440)},
+				Padding: CLAY_PADDING_ALL(10),
+				Grid: Clay_GridLayoutConfig{
+					Columns:   []Clay_GridTrack{CLAY_GRID_TRACK_FIXED(100), CLAY_GRID_TRACK_GROW(1), CLAY_GRID_TRACK_GROW(2)},
+					Rows:      []Clay_GridTrack{CLAY_GRID_TRACK_FIT(Clay_SizingMinMax{}), CLAY_GRID_TRACK_FIXED(50)},
+					ColumnGap: 10,
+					RowGap:    5,
+				},
+			}},
+				testGridCell("cell0", Clay_GridItemConfig{}, fill),
+				testGridCell("cell1", Clay_GridItemConfig{}, fill),
+				testGridCell("cell2", Clay_GridItemConfig{}, fill),
+				testGridCell("cell3", Clay_GridItemConfig{}, fill),
+				testGridCell("cell4", Clay_GridItemConfig{}, fill),
+				testGridCell("cell5", Clay_GridItemConfig{}, Clay_Sizing{Width: CLAY_SIZING_GROW(Clay_SizingMinMax{}), Height: CLAY_SIZING_GROW(Clay_SizingMinMax{})}),
+			),
+		)
+	})
+
+	// 300 pixels remain after the fixed column and gaps, shared 1:2 between the GROW columns
+	columnX := []float32{10, 120, 230}
+	columnWidths := []float32{100, 100, 200}
+	for i := 0; i < 5; i++ {
+		testExpectBoundingBox(t, fmt.Sprintf("cell%d", i), Clay_BoundingBox{X: columnX[i%3], Y: 10 + float32(i/3)*25, Width: columnWidths[i%3], Height: 20})
+	}
+	testExpectBoundingBox(t, "cell5", Clay_BoundingBox{X: 230, Y: 35, Width: 200, Height: 50})
+	testExpectBoundingBox(t, "grid", Clay_BoundingBox{X: 0, Y: 0, Width: 440, Height: 95})
+}
+
+func TestGridAlignsColumnsAcrossRows(t *testing.T) {
+	newTestContext(t, 800, 600)
+	grow := Clay_Sizing{Width: CLAY_SIZING_GROW(Clay_SizingMinMax{}), Height: CLAY_SIZING_FIXED(20)}
+	runTestLayout(func() {
+		CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{},
+			CLAY(CLAY_ID("grid"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{
+				Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(300)},
+				Grid: Clay_GridLayoutConfig{
+					Columns: []Clay_GridTrack{CLAY_GRID_TRACK_FIT(Clay_SizingMinMax{}), CLAY_GRID_TRACK_GROW(1)},
+				},
+			}},
+				testFixedBox("label0", 50, 20),
+				testGridCell("value0", Clay_GridItemConfig{}, grow),
+				testFixedBox("label1", 80, 20),
+				testGridCell("value1", Clay_GridItemConfig{}, grow),
+			),
+		)
+	})
+
+	testExpectBoundingBox(t, "label0", Clay_BoundingBox{X: 0, Y: 0, Width: 50, Height: 20})
+	testExpectBoundingBox(t, "value0", Clay_BoundingBox{X: 80, Y: 0, Width: 220, Height: 20})
+	testExpectBoundingBox(t, "label1", Clay_BoundingBox{X: 0, Y: 20, Width: 80, Height: 20})
+	testExpectBoundingBox(t, "value1", Clay_BoundingBox{X: 80, Y: 20, Width: 220, Height: 20})
+}
+
+func TestGridPlacementAndSpans(t *testing.T) {
+	newTestContext(t, 800, 600)
+	fill := Clay_Sizing{Width: CLAY_SIZING_GROW(Clay_SizingMinMax{}), Height: CLAY_SIZING_GROW(Clay_SizingMinMax{})}
+	runTestLayout(func() {
+		CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{},
+			CLAY(CLAY_ID("grid"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{
+				Grid: Clay_GridLayoutConfig{
+					Columns: []Clay_GridTrack{CLAY_GRID_TRACK_FIXED(50), CLAY_GRID_TRACK_FIXED(50), CLAY_GRID_TRACK_FIXED(50)},
+					Rows:    []Clay_GridTrack{CLAY_GRID_TRACK_FIXED(30), CLAY_GRID_TRACK_FIXED(30), CLAY_GRID_TRACK_FIXED(30)},
+				},
+			}},
+				testGridCell("header", Clay_GridItemConfig{ColumnSpan: 3}, fill),
+				testGridCell("sidebar", Clay_GridItemConfig{Column: 3, Row: 2, RowSpan: 2}, fill),
+				testGridCell("auto0", Clay_GridItemConfig{}, fill),
+				testGridCell("auto1", Clay_GridItemConfig{}, fill),
+				testGridCell("auto2", Clay_GridItemConfig{}, fill),
+			),
+		)
+	})
+
+	testExpectBoundingBox(t, "header", Clay_BoundingBox{X: 0, Y: 0, Width: 150, Height: 30})
+	testExpectBoundingBox(t, "sidebar", Clay_BoundingBox{X: 100, Y: 30, Width: 50, Height: 60})
+	// Automatically placed children skip the cells taken by the sidebar
+	testExpectBoundingBox(t, "auto0", Clay_BoundingBox{X: 0, Y: 30, Width: 50, Height: 30})
+	testExpectBoundingBox(t, "auto1", Clay_BoundingBox{X: 50, Y: 30, Width: 50, Height: 30})
+	testExpectBoundingBox(t, "auto2", Clay_BoundingBox{X: 0, Y: 60, Width: 50, Height: 30})
+	testExpectBoundingBox(t, "grid", Clay_BoundingBox{X: 0, Y: 0, Width: 150, Height: 90})
+}
+
+func TestGridFitsContentAndWrappedText(t *testing.T) {
+	newTestContext(t, 800, 600)
+	runTestLayout(func() {
+		CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{},
+			CLAY(CLAY_ID("column"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{LayoutDirection: CLAY_TOP_TO_BOTTOM}},
+				CLAY(CLAY_ID("grid"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{
+					Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(200)},
+					Grid: Clay_GridLayoutConfig{
+						Columns: []Clay_GridTrack{CLAY_GRID_TRACK_FIXED(100), CLAY_GRID_TRACK_GROW(1)},
+						RowGap:  10,
+					},
+				}},
+					testFixedBox("label0", 60, 20),
+					CLAY_TEXT("hello world"),
+					testFixedBox("label1", 60, 20),
+				),
+				testFixedBox("below", 10, 10),
+			),
+		)
+	})
+
+	// The text is narrowed to its 100 pixel column, wraps onto two lines and makes the first row taller
+	testExpectBoundingBox(t, "label1", Clay_BoundingBox{X: 0, Y: 50, Width: 60, Height: 20})
+	testExpectBoundingBox(t, "grid", Clay_BoundingBox{X: 0, Y: 0, Width: 200, Height: 70})
+	testExpectBoundingBox(t, "below", Clay_BoundingBox{X: 0, Y: 70, Width: 10, Height: 10})
+}
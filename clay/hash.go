@@ -46,31 +46,29 @@ func Clay__HashData(data []byte, length int32) uint32 {
 	return hash
 }
 
+// Returns the key of the text measurement cache entry for text measured with config. Static strings are identified by
+// the address and length of their bytes, which never hold other text, so they are not hashed character by character.
+// Any other string is identified by its contents, so that text that is built again every frame still hits the cache.
+// Besides the font, the line height and wrap mode are part of the key as a measure text function may depend on them.
 func Clay__HashStringContentsWithConfig(text *Clay_String, config *Clay_TextElementConfig) uint32 {
 	hash := uint32(0)
 
 	if text.IsStaticallyAllocated {
-		hash += uint32(uintptr(unsafe.Pointer(&text.Chars)))
-		hash += (hash << 10)
-		hash ^= (hash >> 6)
-		hash += uint32(text.Length)
-		hash += (hash << 10)
-		hash ^= (hash >> 6)
+		address := uint64(uintptr(unsafe.Pointer(unsafe.SliceData(text.Chars))))
+		for _, value := range [...]uint32{uint32(address), uint32(address >> 32), uint32(text.Length)} {
+			hash += value
+			hash += (hash << 10)
+			hash ^= (hash >> 6)
+		}
 	} else {
-		hash = Clay__HashData(text.Chars, text.Length) % UINT32_MAX
+		hash = Clay__HashData(text.Chars, text.Length)
 	}
 
-	hash += uint32(config.FontId)
-	hash += (hash << 10)
-	hash ^= (hash >> 6)
-
-	hash += uint32(config.FontSize)
-	hash += (hash << 10)
-	hash ^= (hash >> 6)
-
-	hash += uint32(config.LetterSpacing)
-	hash += (hash << 10)
-	hash ^= (hash >> 6)
+	for _, value := range [...]uint32{uint32(config.FontId), uint32(config.FontSize), uint32(config.LetterSpacing), uint32(config.LineHeight), uint32(config.WrapMode)} {
+		hash += value
+		hash += (hash << 10)
+		hash ^= (hash >> 6)
+	}
 
 	hash += (hash << 3)
 	hash ^= (hash >> 11)
@@ -0,0 +1,45 @@
+package clay
+
+import (
+	"testing"
+
+	"github.com/zodimo/clay-go/pkg/mem"
+)
+
+// Measures every byte as 10 units wide and every line as 20 units high.
+func testMeasureText(text Clay_StringSlice, config *Clay_TextElementConfig, userData interface{}) Clay_Dimensions {
+	return Clay_Dimensions{Width: float32(text.Length) * 10, Height: 20}
+}
+
+// Initializes a fresh clay context with a fixed-width text measurement function.
+// Errors reported by clay are collected into the returned slice.
+func newTestContext(tb testing.TB, width, height float32) *[]Clay_ErrorData {
+	tb.Helper()
+	arena, err := mem.NewArena(make([]byte, 4*1024*1024))
+	if err != nil {
+		tb.Fatalf("failed to create arena: %v", err)
+	}
+	errors := &[]Clay_ErrorData{}
+	Clay_Initialize(*arena, Clay_Dimensions{Width: width, Height: height}, NewErrorHandler(func(errorData Clay_ErrorData) {
+		*errors = append(*errors, errorData)
+	}, nil))
+	Clay_SetMeasureTextFunction(testMeasureText, nil)
+	return errors
+}
+
+// Runs a single layout pass over the provided declarations and returns the resulting render commands.
+func runTestLayout(layout func()) []Clay_RenderCommand {
+	Clay_BeginLayout()
+	layout()
+	return Clay_EndLayout()
+}
+
+// Returns the bounding box of the element with the provided ID after the last layout pass.
+func testBoundingBox(tb testing.TB, id string) Clay_BoundingBox {
+	tb.Helper()
+	item := Clay__GetHashMapItem(CLAY_ID(id).Id)
+	if item == &Clay_LayoutElementHashMapItem_DEFAULT {
+		tb.Fatalf("element %q was not found", id)
+	}
+	return item.BoundingBox
+}
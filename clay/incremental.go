@@ -0,0 +1,671 @@
+package clay
+
+import (
+	"math"
+	"reflect"
+
+	"github.com/zodimo/clay-go/pkg/mem"
+)
+
+// The results of laying out a single element, kept by incremental layout for the next layout.
+type Clay__CachedLayoutElement struct {
+	Id              uint32
+	DeclarationHash uint64
+	// The shape of the declaration, compared along with the hash so that a hash collision alone can't make the results of
+	// a changed element look reusable. Text elements have the length of their text as their child count.
+	ChildCount  int32
+	ConfigTypes uint32
+	Dimensions  Clay_Dimensions
+	BoundingBox Clay_BoundingBox
+	// The size of the content of scroll containers.
+	ContentSize Clay_Dimensions
+	ZIndex      int16
+	// The SCISSOR_END commands of clip elements take their ids from the child count of the tree root.
+	RootChildCount uint16
+	// False for elements whose children are sized with data that only lives for a single layout, like grids, or that
+	// are sized again after sizing has finished, like elements with an aspect ratio.
+	ReusableSizes bool
+	// The range of render commands produced by the element and its descendants.
+	RenderCommandsStart int32
+	RenderCommandsEnd   int32
+	// The number of descendants of the element, which directly follow it in depth first order. Floating elements are
+	// laid out as separate trees and are not included.
+	DescendantCount int32
+	// See Clay_LayoutElement.ContentHeight.
+	ContentHeight float32
+	// The range of the wrapped lines of text elements in WrappedTextLines, followed by their fragments from
+	// WrappedFragmentsStart in WrappedTextFragments.
+	WrappedLinesStart     int32
+	WrappedLinesEnd       int32
+	WrappedFragmentsStart int32
+}
+
+// The cached results of a single layout.
+type Clay__CachedLayout struct {
+	Elements             []Clay__CachedLayoutElement
+	RenderCommands       []Clay_RenderCommand
+	WrappedTextLines     []Clay__WrappedTextLine
+	WrappedTextFragments []Clay__WrappedTextFragment
+}
+
+// The state of incremental layout, see Clay_SetIncrementalLayoutEnabled. Elements find their results in Previous through
+// the LayoutCacheIndex of their hash map item, while the results of the current layout are collected into Current.
+type Clay__IncrementalLayoutCache struct {
+	Previous Clay__CachedLayout
+	Current  Clay__CachedLayout
+	// Set once Previous holds a complete layout.
+	Recorded bool
+	// Set while the current layout can reuse Previous, which requires it to have been made with the same settings.
+	Reusable                      bool
+	LayoutDimensions              Clay_Dimensions
+	DisableCulling                bool
+	ExternalScrollHandlingEnabled bool
+	// The number of elements whose render commands were reused by the last layout.
+	ReusedElementCount int32
+}
+
+// Prepares the incremental layout cache for the layout that is being calculated.
+func Clay__BeginIncrementalLayout() {
+	currentContext := Clay_GetCurrentContext()
+	cache := currentContext.IncrementalLayout
+	if cache == nil {
+		return
+	}
+	cache.Reusable = cache.Recorded &&
+		cache.LayoutDimensions == currentContext.LayoutDimensions &&
+		cache.DisableCulling == currentContext.DisableCulling &&
+		cache.ExternalScrollHandlingEnabled == currentContext.ExternalScrollHandlingEnabled &&
+		!currentContext.DebugModeEnabled &&
+		!currentContext.BooleanWarnings.MaxElementsExceeded
+	cache.Current.Elements = cache.Current.Elements[:0]
+	cache.Current.RenderCommands = cache.Current.RenderCommands[:0]
+	cache.Current.WrappedTextLines = cache.Current.WrappedTextLines[:0]
+	cache.Current.WrappedTextFragments = cache.Current.WrappedTextFragments[:0]
+	cache.ReusedElementCount = 0
+}
+
+// Keeps the results of the layout that was just calculated for the next layout. Called before the layout is mirrored for
+// RTL, so that the cache always holds unmirrored results.
+func Clay__EndIncrementalLayout() {
+	currentContext := Clay_GetCurrentContext()
+	cache := currentContext.IncrementalLayout
+	if cache == nil {
+		return
+	}
+	cache.Current.RenderCommands = append(cache.Current.RenderCommands, currentContext.RenderCommands.InternalArray()...)
+	cache.Previous, cache.Current = cache.Current, cache.Previous
+	cache.Recorded = !currentContext.BooleanWarnings.MaxElementsExceeded && !currentContext.BooleanWarnings.MaxRenderCommandsExceeded
+	cache.LayoutDimensions = currentContext.LayoutDimensions
+	cache.DisableCulling = currentContext.DisableCulling
+	cache.ExternalScrollHandlingEnabled = currentContext.ExternalScrollHandlingEnabled
+}
+
+// Returns the index in the cache of the results of the last layout for element, or -1 if there are none or the element
+// wasn't declared the same way.
+func Clay__GetCachedLayoutElementIndex(element *Clay_LayoutElement) int32 {
+	currentContext := Clay_GetCurrentContext()
+	cache := currentContext.IncrementalLayout
+	if cache == nil || !cache.Reusable || element.DeclarationHash == 0 {
+		return -1
+	}
+	hashMapItem := Clay__GetHashMapItem(element.Id)
+	if hashMapItem == &Clay_LayoutElementHashMapItem_DEFAULT || hashMapItem.LayoutCacheGeneration != currentContext.Generation-1 {
+		return -1
+	}
+	index := hashMapItem.LayoutCacheIndex
+	if index < 0 || index >= int32(len(cache.Previous.Elements)) {
+		return -1
+	}
+	if !Clay__CachedLayoutElementMatches(element, &cache.Previous.Elements[index]) {
+		return -1
+	}
+	return index
+}
+
+// Reports whether element was declared the same way as the element whose results cached holds: with the same id,
+// declaration hash, config types and number of children.
+func Clay__CachedLayoutElementMatches(element *Clay_LayoutElement, cached *Clay__CachedLayoutElement) bool {
+	return cached.Id == element.Id &&
+		cached.DeclarationHash == element.DeclarationHash &&
+		cached.ChildCount == Clay__CachedChildCount(element) &&
+		cached.ConfigTypes == Clay__ElementConfigTypes(element)
+}
+
+// Reports whether the descendants of the element cached at index were declared the same way as the descendants of
+// element, see Clay__CachedLayoutElementMatches.
+func Clay__CachedDescendantsMatch(element *Clay_LayoutElement, index int32) bool {
+	cache := Clay_GetCurrentContext().IncrementalLayout
+	cached := &cache.Previous.Elements[index]
+	remaining, matches := Clay__MatchCachedDescendants(element, cache.Previous.Elements[index+1:index+1+cached.DescendantCount])
+	return matches && len(remaining) == 0
+}
+
+// Compares the descendants of element with cached, which holds them in depth first order. Returns the part of cached
+// that follows the descendants and whether all of them match.
+func Clay__MatchCachedDescendants(element *Clay_LayoutElement, cached []Clay__CachedLayoutElement) ([]Clay__CachedLayoutElement, bool) {
+	if Clay__ElementHasConfig(element, CLAY__ELEMENT_CONFIG_TYPE_TEXT) {
+		return cached, true
+	}
+	currentContext := Clay_GetCurrentContext()
+	for i := uint16(0); i < element.ChildrenOrTextContent.Children.Length; i++ {
+		child := Clay__Array_Get(&currentContext.LayoutElements, element.ChildrenOrTextContent.Children.Elements[i])
+		if len(cached) == 0 || !Clay__CachedLayoutElementMatches(child, &cached[0]) {
+			return cached, false
+		}
+		var matches bool
+		if cached, matches = Clay__MatchCachedDescendants(child, cached[1:]); !matches {
+			return cached, false
+		}
+	}
+	return cached, true
+}
+
+// Returns the number of children of element, or the length of its text for text elements.
+func Clay__CachedChildCount(element *Clay_LayoutElement) int32 {
+	if Clay__ElementHasConfig(element, CLAY__ELEMENT_CONFIG_TYPE_TEXT) {
+		return element.ChildrenOrTextContent.TextElementData.Text.Length
+	}
+	return int32(element.ChildrenOrTextContent.Children.Length)
+}
+
+// Returns a bit for each type of config element was declared with.
+func Clay__ElementConfigTypes(element *Clay_LayoutElement) uint32 {
+	var configTypes uint32
+	for i := int32(0); i < element.ElementConfigs.Length(); i++ {
+		configTypes |= 1 << Clay__Slice_Get(&element.ElementConfigs, i).Type
+	}
+	return configTypes
+}
+
+// Returns the index in the cache of the results of the last layout for element, or -1, along with whether its
+// descendants are known to have been declared the same way, as they are once its width was restored.
+func Clay__GetCachedSubtreeIndex(element *Clay_LayoutElement) (int32, bool) {
+	if element.CachedLayoutIndex != 0 {
+		return element.CachedLayoutIndex - 1, true
+	}
+	return Clay__GetCachedLayoutElementIndex(element), false
+}
+
+// Sizes the descendants of element along one axis like the last layout did, if element was declared the same way and
+// has the same size. Returns false if the sizes have to be calculated instead.
+func Clay__ReuseCachedSizes(element *Clay_LayoutElement, xAxis bool) bool {
+	index, descendantsMatch := Clay__GetCachedSubtreeIndex(element)
+	if index == -1 {
+		return false
+	}
+	cache := Clay_GetCurrentContext().IncrementalLayout
+	cached := &cache.Previous.Elements[index]
+	// The final width is known once the X axis has been sized, but the height depends on text wrapped to that width
+	if element.Dimensions.Width != cached.Dimensions.Width || (!xAxis && element.Dimensions.Height != cached.Dimensions.Height) {
+		return false
+	}
+	if !descendantsMatch {
+		subtree := cache.Previous.Elements[index : index+1+cached.DescendantCount]
+		for i := range subtree {
+			if !subtree[i].ReusableSizes {
+				return false
+			}
+		}
+		if !Clay__CachedDescendantsMatch(element, index) {
+			return false
+		}
+	}
+	if xAxis {
+		element.CachedLayoutIndex = index + 1
+	}
+	Clay__RestoreCachedSizes(element, index, xAxis)
+	return true
+}
+
+// Copies the sizes along one axis of the descendants of the element cached at index, which follow it in depth first
+// order. Restoring their widths lets text wrapping and height propagation skip them too. Returns the index that
+// follows the descendants.
+func Clay__RestoreCachedSizes(element *Clay_LayoutElement, index int32, xAxis bool) int32 {
+	index++
+	if Clay__ElementHasConfig(element, CLAY__ELEMENT_CONFIG_TYPE_TEXT) {
+		return index
+	}
+	currentContext := Clay_GetCurrentContext()
+	cached := currentContext.IncrementalLayout.Previous.Elements
+	for i := uint16(0); i < element.ChildrenOrTextContent.Children.Length && index < int32(len(cached)); i++ {
+		child := Clay__Array_Get(&currentContext.LayoutElements, element.ChildrenOrTextContent.Children.Elements[i])
+		Clay__SetSizeAlongAxis(&child.Dimensions, xAxis, Clay__SizeAlongAxis(cached[index].Dimensions, xAxis))
+		if xAxis {
+			child.CachedLayoutIndex = index + 1
+		}
+		index = Clay__RestoreCachedSizes(child, index, xAxis)
+	}
+	return index
+}
+
+// Wraps the text of textElementData like the last layout did, if the width of its element was restored by
+// Clay__ReuseCachedSizes. Returns false if the text has to be wrapped instead.
+func Clay__RestoreCachedWrappedLines(textElementData *Clay__TextElementData, element *Clay_LayoutElement) bool {
+	if element.CachedLayoutIndex == 0 {
+		return false
+	}
+	currentContext := Clay_GetCurrentContext()
+	cache := currentContext.IncrementalLayout
+	cached := &cache.Previous.Elements[element.CachedLayoutIndex-1]
+	lines := cache.Previous.WrappedTextLines[cached.WrappedLinesStart:cached.WrappedLinesEnd]
+	fragments := cache.Previous.WrappedTextFragments[cached.WrappedFragmentsStart:]
+	fragmentCount := int32(0)
+	for i := range lines {
+		fragmentCount += lines[i].Fragments.Length()
+	}
+	if currentContext.WrappedTextLines.Length()+int32(len(lines)) > currentContext.WrappedTextLines.Capacity() ||
+		currentContext.WrappedTextFragments.Length()+fragmentCount > currentContext.WrappedTextFragments.Capacity() {
+		return false
+	}
+	for _, line := range lines {
+		fragmentsStart := currentContext.WrappedTextFragments.Length()
+		for _, fragment := range fragments[:line.Fragments.Length()] {
+			fragment.Line.Chars = Clay__RestoreCachedChars(fragment.Line, textElementData.Spans[fragment.SpanIndex].Text, fragment.StartOffset)
+			Clay__Array_Add(&currentContext.WrappedTextFragments, fragment)
+		}
+		fragments = fragments[line.Fragments.Length():]
+		line.Fragments = NewClay__Slice(mem.MArray_GetSlice(&currentContext.WrappedTextFragments, fragmentsStart, currentContext.WrappedTextFragments.Length()))
+		line.Line.Chars = Clay__RestoreCachedChars(line.Line, textElementData.Text, line.StartOffset)
+		Clay__Array_Add(&currentContext.WrappedTextLines, line)
+		Clay__Slice_Grow(&textElementData.WrappedLines, 1)
+	}
+	return true
+}
+
+// Returns the characters of a cached wrapped line or fragment for the current layout. Text generated by the last
+// layout, like truncated lines, is moved into the buffer of the current layout. Anything else is taken from text
+// again at startOffset, as the text the last layout was declared with may have been changed since.
+func Clay__RestoreCachedChars(line Clay_String, text Clay_String, startOffset int32) []byte {
+	if line.Chars == nil {
+		return nil
+	}
+	if Clay__DynamicStringDataContains(&Clay_GetCurrentContext().PreviousDynamicStringData, line.Chars) {
+		return Clay__MoveDynamicString(line.Chars[:line.Length])
+	}
+	return text.Chars[startOffset : startOffset+line.Length]
+}
+
+// Restores the heights element and its descendants had before they were sized along the Y axis during the last
+// layout, if the width of element was restored by Clay__ReuseCachedSizes. Returns false if the heights of their
+// wrapped text have to be propagated instead.
+func Clay__RestoreCachedContentHeights(element *Clay_LayoutElement) bool {
+	if element.CachedLayoutIndex == 0 {
+		return false
+	}
+	currentContext := Clay_GetCurrentContext()
+	element.ContentHeight = currentContext.IncrementalLayout.Previous.Elements[element.CachedLayoutIndex-1].ContentHeight
+	element.Dimensions.Height = element.ContentHeight
+	if Clay__ElementHasConfig(element, CLAY__ELEMENT_CONFIG_TYPE_TEXT) {
+		return true
+	}
+	for i := uint16(0); i < element.ChildrenOrTextContent.Children.Length; i++ {
+		Clay__RestoreCachedContentHeights(Clay__Array_Get(&currentContext.LayoutElements, element.ChildrenOrTextContent.Children.Elements[i]))
+	}
+	return true
+}
+
+// Adds the render commands that element and its descendants produced during the last layout and restores their bounding
+// boxes, if element was declared the same way and has the same bounding box. Returns false if they have to be
+// generated instead.
+func Clay__ReuseCachedRenderCommands(element *Clay_LayoutElement, boundingBox Clay_BoundingBox, zIndex int16, rootElement *Clay_LayoutElement) bool {
+	index, descendantsMatch := Clay__GetCachedSubtreeIndex(element)
+	if index == -1 {
+		return false
+	}
+	currentContext := Clay_GetCurrentContext()
+	cache := currentContext.IncrementalLayout
+	cached := &cache.Previous.Elements[index]
+	if cached.BoundingBox != boundingBox || cached.ZIndex != zIndex || cached.RootChildCount != rootElement.ChildrenOrTextContent.Children.Length {
+		return false
+	}
+	if !descendantsMatch && !Clay__CachedDescendantsMatch(element, index) {
+		return false
+	}
+	renderCommandsStart := currentContext.RenderCommands.Length()
+	renderCommandOffset := renderCommandsStart - cached.RenderCommandsStart
+	Clay__AddRenderCommands(cache.Previous.RenderCommands[cached.RenderCommandsStart:cached.RenderCommandsEnd])
+	Clay__MoveDynamicStrings(mem.MArray_GetSlice(&currentContext.RenderCommands, renderCommandsStart, currentContext.RenderCommands.Length()))
+	Clay__RestoreCachedElements(element, cache.Previous.Elements[index:index+1+cached.DescendantCount], renderCommandOffset)
+	return true
+}
+
+// Restores the bounding boxes of element and its descendants from cached, which holds them in depth first order, and
+// keeps them for the next layout. Returns the part of cached that follows the descendants.
+func Clay__RestoreCachedElements(element *Clay_LayoutElement, cached []Clay__CachedLayoutElement, renderCommandOffset int32) []Clay__CachedLayoutElement {
+	currentContext := Clay_GetCurrentContext()
+	cache := currentContext.IncrementalLayout
+	record := cached[0]
+	record.RenderCommandsStart += renderCommandOffset
+	record.RenderCommandsEnd += renderCommandOffset
+	if hashMapItem := Clay__GetHashMapItem(element.Id); hashMapItem != &Clay_LayoutElementHashMapItem_DEFAULT {
+		hashMapItem.BoundingBox = record.BoundingBox
+		hashMapItem.LayoutCacheIndex = int32(len(cache.Current.Elements))
+		hashMapItem.LayoutCacheGeneration = currentContext.Generation
+	}
+	if scrollContainerData := Clay__GetScrollContainerData(element); scrollContainerData != nil {
+		scrollContainerData.BoundingBox = record.BoundingBox
+		scrollContainerData.ContentSize = record.ContentSize
+	}
+	Clay__CacheWrappedTextLines(element, &record)
+	cache.Current.Elements = append(cache.Current.Elements, record)
+	cache.ReusedElementCount++
+	cached = cached[1:]
+	if Clay__ElementHasConfig(element, CLAY__ELEMENT_CONFIG_TYPE_TEXT) {
+		return cached
+	}
+	for i := uint16(0); i < element.ChildrenOrTextContent.Children.Length && len(cached) > 0; i++ {
+		child := Clay__Array_Get(&currentContext.LayoutElements, element.ChildrenOrTextContent.Children.Elements[i])
+		cached = Clay__RestoreCachedElements(child, cached, renderCommandOffset)
+	}
+	return cached
+}
+
+// Starts keeping the results of an element that is being positioned for the next layout, before any of its render
+// commands have been added. Returns the index of the results, to be passed to Clay__FinishCachedLayoutElement once the
+// element and its descendants are done, or -1 if incremental layout is disabled.
+func Clay__CacheLayoutElement(element *Clay_LayoutElement, hashMapItem *Clay_LayoutElementHashMapItem, boundingBox Clay_BoundingBox, zIndex int16, rootElement *Clay_LayoutElement) int32 {
+	currentContext := Clay_GetCurrentContext()
+	cache := currentContext.IncrementalLayout
+	if cache == nil {
+		return -1
+	}
+	index := int32(len(cache.Current.Elements))
+	cache.Current.Elements = append(cache.Current.Elements, Clay__CachedLayoutElement{
+		Id:                  element.Id,
+		DeclarationHash:     element.DeclarationHash,
+		ChildCount:          Clay__CachedChildCount(element),
+		ConfigTypes:         Clay__ElementConfigTypes(element),
+		Dimensions:          element.Dimensions,
+		BoundingBox:         boundingBox,
+		ZIndex:              zIndex,
+		RootChildCount:      rootElement.ChildrenOrTextContent.Children.Length,
+		ReusableSizes:       !Clay__ElementHasConfig(element, CLAY__ELEMENT_CONFIG_TYPE_ASPECT) && len(element.LayoutConfig.Grid.Columns) == 0,
+		RenderCommandsStart: currentContext.RenderCommands.Length(),
+		ContentHeight:       element.ContentHeight,
+	})
+	Clay__CacheWrappedTextLines(element, &cache.Current.Elements[index])
+	if hashMapItem != nil && hashMapItem != &Clay_LayoutElementHashMapItem_DEFAULT {
+		hashMapItem.LayoutCacheIndex = index
+		hashMapItem.LayoutCacheGeneration = currentContext.Generation
+	}
+	return index
+}
+
+// Completes the results started with Clay__CacheLayoutElement once the element and its descendants have been positioned.
+func Clay__FinishCachedLayoutElement(element *Clay_LayoutElement, index int32) {
+	cache := Clay_GetCurrentContext().IncrementalLayout
+	if cache == nil || index < 0 {
+		return
+	}
+	cached := &cache.Current.Elements[index]
+	cached.RenderCommandsEnd = Clay_GetCurrentContext().RenderCommands.Length()
+	cached.DescendantCount = int32(len(cache.Current.Elements)) - index - 1
+	if scrollContainerData := Clay__GetScrollContainerData(element); scrollContainerData != nil {
+		cached.ContentSize = scrollContainerData.ContentSize
+	}
+}
+
+// Keeps the wrapped lines of a text element for the next layout, see Clay__RestoreCachedWrappedLines.
+func Clay__CacheWrappedTextLines(element *Clay_LayoutElement, cached *Clay__CachedLayoutElement) {
+	cache := Clay_GetCurrentContext().IncrementalLayout
+	cached.WrappedLinesStart = int32(len(cache.Current.WrappedTextLines))
+	cached.WrappedFragmentsStart = int32(len(cache.Current.WrappedTextFragments))
+	if Clay__ElementHasConfig(element, CLAY__ELEMENT_CONFIG_TYPE_TEXT) {
+		wrappedLines := &element.ChildrenOrTextContent.TextElementData.WrappedLines
+		for i := int32(0); i < wrappedLines.Length(); i++ {
+			wrappedLine := Clay__Slice_Get(wrappedLines, i)
+			cache.Current.WrappedTextLines = append(cache.Current.WrappedTextLines, *wrappedLine)
+			for j := int32(0); j < wrappedLine.Fragments.Length(); j++ {
+				cache.Current.WrappedTextFragments = append(cache.Current.WrappedTextFragments, *Clay__Slice_Get(&wrappedLine.Fragments, j))
+			}
+		}
+	}
+	cached.WrappedLinesEnd = int32(len(cache.Current.WrappedTextLines))
+}
+
+// Returns the scroll container data of a clip element, or nil.
+func Clay__GetScrollContainerData(element *Clay_LayoutElement) *Clay__ScrollContainerDataInternal {
+	if element.ScrollContainerDataIndex == 0 {
+		return nil
+	}
+	return Clay__Array_Get(&Clay_GetCurrentContext().ScrollContainerDatas, element.ScrollContainerDataIndex-1)
+}
+
+// Returns a hash of everything element was declared with: its id, layout config, element configs and text, along with
+// the hashes of its children. Returns 0 if the element or one of its children can't be compared with the last layout,
+// see Clay__HashUserData.
+func Clay__HashElementDeclaration(element *Clay_LayoutElement) uint64 {
+	currentContext := Clay_GetCurrentContext()
+	hash := Clay__HashMix(0, element.Id)
+	hash = Clay__HashLayoutConfig(hash, element.LayoutConfig)
+	hashable := true
+	for i := int32(0); i < element.ElementConfigs.Length(); i++ {
+		var ok bool
+		hash, ok = Clay__HashElementConfig(hash, Clay__Slice_Get(&element.ElementConfigs, i))
+		hashable = hashable && ok
+	}
+	if Clay__ElementHasConfig(element, CLAY__ELEMENT_CONFIG_TYPE_TEXT) {
+		textElementData := element.ChildrenOrTextContent.TextElementData
+		hash = Clay__HashStringContents(hash, textElementData.Text)
+		hash = Clay__HashMixBool(hash, textElementData.Input)
+		for i := range textElementData.Spans {
+			span := &textElementData.Spans[i]
+			var ok bool
+			hash = Clay__HashMix(hash, span.Id.Id)
+			hash = Clay__HashStringContents(hash, span.Text)
+			hash, ok = Clay__HashTextElementConfig(hash, span.Config)
+			hashable = hashable && ok
+		}
+	} else {
+		for i := uint16(0); i < element.ChildrenOrTextContent.Children.Length; i++ {
+			child := Clay__Array_Get(&currentContext.LayoutElements, element.ChildrenOrTextContent.Children.Elements[i])
+			hashable = hashable && child.DeclarationHash != 0
+			hash = Clay__HashMixUint64(hash, child.DeclarationHash)
+		}
+	}
+	if !hashable {
+		return 0
+	}
+	hash ^= hash >> 33
+	hash *= 0xff51afd7ed558ccd
+	hash ^= hash >> 33
+	if hash == 0 { // Reserve zero for elements that can't be compared
+		hash = 1
+	}
+	return hash
+}
+
+// Mixes a value into a 64 bit hash that is being built up. Element ids are 32 bit hashes, so declaration hashes are
+// twice as wide to make it unlikely for a changed declaration to be taken for the one of the last layout.
+func Clay__HashMix(hash uint64, value uint32) uint64 {
+	hash = (hash ^ uint64(value)) * 0x9e3779b97f4a7c15
+	return hash ^ hash>>32
+}
+
+func Clay__HashMixFloat(hash uint64, value float32) uint64 {
+	return Clay__HashMix(hash, math.Float32bits(value))
+}
+
+func Clay__HashMixBool(hash uint64, value bool) uint64 {
+	if value {
+		return Clay__HashMix(hash, 1)
+	}
+	return Clay__HashMix(hash, 0)
+}
+
+func Clay__HashStringContents(hash uint64, text Clay_String) uint64 {
+	hash = Clay__HashMix(hash, uint32(text.Length))
+	for _, char := range text.Chars[:text.Length] {
+		hash = Clay__HashMix(hash, uint32(char))
+	}
+	return hash
+}
+
+func Clay__HashColor(hash uint64, color Clay_Color) uint64 {
+	hash = Clay__HashMixFloat(hash, color.R)
+	hash = Clay__HashMixFloat(hash, color.G)
+	hash = Clay__HashMixFloat(hash, color.B)
+	return Clay__HashMixFloat(hash, color.A)
+}
+
+func Clay__HashSizingAxis(hash uint64, sizing Clay_SizingAxis) uint64 {
+	hash = Clay__HashMix(hash, uint32(sizing.Type))
+	hash = Clay__HashMixFloat(hash, sizing.Size.MinMax.Min)
+	hash = Clay__HashMixFloat(hash, sizing.Size.MinMax.Max)
+	hash = Clay__HashMixFloat(hash, sizing.Size.Percent)
+	hash = Clay__HashMixFloat(hash, sizing.GrowWeight)
+	hash = Clay__HashMixFloat(hash, sizing.ShrinkWeight)
+	return Clay__HashMix(hash, uint32(sizing.ShrinkPriority))
+}
+
+func Clay__HashLayoutConfig(hash uint64, config *Clay_LayoutConfig) uint64 {
+	hash = Clay__HashSizingAxis(hash, config.Sizing.Width)
+	hash = Clay__HashSizingAxis(hash, config.Sizing.Height)
+	hash = Clay__HashMix(hash, uint32(config.Padding.Left)|uint32(config.Padding.Right)<<16)
+	hash = Clay__HashMix(hash, uint32(config.Padding.Top)|uint32(config.Padding.Bottom)<<16)
+	hash = Clay__HashMix(hash, uint32(config.Margin.Left)|uint32(config.Margin.Right)<<16)
+	hash = Clay__HashMix(hash, uint32(config.Margin.Top)|uint32(config.Margin.Bottom)<<16)
+	hash = Clay__HashMix(hash, uint32(config.ChildGap)|uint32(config.LineGap)<<16)
+	hash = Clay__HashMix(hash, uint32(config.ChildAlignment.X)|uint32(config.ChildAlignment.Y)<<8|uint32(config.LayoutDirection)<<16|uint32(config.Wrap)<<24)
+	hash = Clay__HashMix(hash, uint32(config.AlignSelf))
+	hash = Clay__HashMix(hash, uint32(len(config.Grid.Columns)))
+	for _, track := range config.Grid.Columns {
+		hash = Clay__HashSizingAxis(hash, track.Sizing)
+	}
+	hash = Clay__HashMix(hash, uint32(len(config.Grid.Rows)))
+	for _, track := range config.Grid.Rows {
+		hash = Clay__HashSizingAxis(hash, track.Sizing)
+	}
+	hash = Clay__HashMix(hash, uint32(config.Grid.ColumnGap)|uint32(config.Grid.RowGap)<<16)
+	hash = Clay__HashMix(hash, uint32(config.GridItem.Column)|uint32(config.GridItem.Row)<<16)
+	return Clay__HashMix(hash, uint32(config.GridItem.ColumnSpan)|uint32(config.GridItem.RowSpan)<<16)
+}
+
+func Clay__HashTextElementConfig(hash uint64, config *Clay_TextElementConfig) (uint64, bool) {
+	hash = Clay__HashColor(hash, config.TextColor)
+	hash = Clay__HashMix(hash, uint32(config.FontId)|uint32(config.FontSize)<<16)
+	hash = Clay__HashMix(hash, uint32(config.LetterSpacing)|uint32(config.LineHeight)<<16)
+	hash = Clay__HashMix(hash, uint32(config.WrapMode)|uint32(config.TextAlignment)<<8|uint32(config.Overflow)<<16)
+	hash = Clay__HashMix(hash, uint32(config.MaxLines))
+	return Clay__HashUserData(hash, config.UserData)
+}
+
+func Clay__HashGradient(hash uint64, gradient *Clay_Gradient) uint64 {
+	hash = Clay__HashMix(hash, uint32(gradient.Type))
+	hash = Clay__HashMixFloat(hash, gradient.Start.X)
+	hash = Clay__HashMixFloat(hash, gradient.Start.Y)
+	hash = Clay__HashMixFloat(hash, gradient.End.X)
+	hash = Clay__HashMixFloat(hash, gradient.End.Y)
+	for _, stop := range gradient.Stops {
+		hash = Clay__HashColor(hash, stop.Color)
+		hash = Clay__HashMixFloat(hash, stop.Position)
+	}
+	return Clay__HashMix(hash, uint32(len(gradient.Stops)))
+}
+
+// Mixes an element config into hash. Returns false if the config holds a value that can't be hashed.
+func Clay__HashElementConfig(hash uint64, config *Clay_ElementConfig) (uint64, bool) {
+	hash = Clay__HashMix(hash, uint32(config.Type))
+	switch config.Type {
+	case CLAY__ELEMENT_CONFIG_TYPE_BORDER:
+		border := config.Config.BorderElementConfig
+		hash = Clay__HashColor(hash, border.Color)
+		hash = Clay__HashMix(hash, uint32(border.Width.Left)|uint32(border.Width.Right)<<16)
+		hash = Clay__HashMix(hash, uint32(border.Width.Top)|uint32(border.Width.Bottom)<<16)
+		return Clay__HashMix(hash, uint32(border.Width.BetweenChildren)), true
+	case CLAY__ELEMENT_CONFIG_TYPE_FLOATING:
+		floating := config.Config.FloatingElementConfig
+		hash = Clay__HashMixFloat(hash, floating.Offset.X)
+		hash = Clay__HashMixFloat(hash, floating.Offset.Y)
+		hash = Clay__HashMixFloat(hash, floating.Expand.Width)
+		hash = Clay__HashMixFloat(hash, floating.Expand.Height)
+		hash = Clay__HashMix(hash, floating.ParentId)
+		hash = Clay__HashMix(hash, uint32(uint16(floating.ZIndex)))
+		hash = Clay__HashMix(hash, uint32(floating.AttachPoints.Element)|uint32(floating.AttachPoints.Parent)<<8)
+		return Clay__HashMix(hash, uint32(floating.PointerCaptureMode)|uint32(floating.AttachTo)<<8|uint32(floating.ClipTo)<<16), true
+	case CLAY__ELEMENT_CONFIG_TYPE_CLIP:
+		clip := config.Config.ClipElementConfig
+		hash = Clay__HashMixBool(hash, clip.Horizontal)
+		hash = Clay__HashMixBool(hash, clip.Vertical)
+		hash = Clay__HashMixFloat(hash, clip.ChildOffset.X)
+		return Clay__HashMixFloat(hash, clip.ChildOffset.Y), true
+	case CLAY__ELEMENT_CONFIG_TYPE_ASPECT:
+		return Clay__HashMixFloat(hash, config.Config.AspectRatioElementConfig.AspectRatio), true
+	case CLAY__ELEMENT_CONFIG_TYPE_IMAGE:
+		return Clay__HashUserData(hash, config.Config.ImageElementConfig.ImageData)
+	case CLAY__ELEMENT_CONFIG_TYPE_TEXT:
+		return Clay__HashTextElementConfig(hash, config.Config.TextElementConfig)
+	case CLAY__ELEMENT_CONFIG_TYPE_CUSTOM:
+		return Clay__HashUserData(hash, config.Config.CustomElementConfig.CustomData)
+	case CLAY__ELEMENT_CONFIG_TYPE_SHARED:
+		shared := config.Config.SharedElementConfig
+		hash = Clay__HashColor(hash, shared.BackgroundColor)
+		hash = Clay__HashGradient(hash, &shared.BackgroundGradient)
+		hash = Clay__HashMixFloat(hash, shared.CornerRadius.TopLeft)
+		hash = Clay__HashMixFloat(hash, shared.CornerRadius.TopRight)
+		hash = Clay__HashMixFloat(hash, shared.CornerRadius.BottomLeft)
+		hash = Clay__HashMixFloat(hash, shared.CornerRadius.BottomRight)
+		return Clay__HashUserData(hash, shared.UserData)
+	case CLAY__ELEMENT_CONFIG_TYPE_SHADOW:
+		shadow := config.Config.ShadowElementConfig
+		hash = Clay__HashColor(hash, shadow.Color)
+		hash = Clay__HashMixFloat(hash, shadow.Offset.X)
+		hash = Clay__HashMixFloat(hash, shadow.Offset.Y)
+		hash = Clay__HashMixFloat(hash, shadow.BlurRadius)
+		hash = Clay__HashMixFloat(hash, shadow.Spread)
+		return Clay__HashMixBool(hash, shadow.Inset), true
+	case CLAY__ELEMENT_CONFIG_TYPE_TRANSFORM:
+		transform := config.Config.TransformElementConfig
+		hash = Clay__HashMixFloat(hash, transform.Translation.X)
+		hash = Clay__HashMixFloat(hash, transform.Translation.Y)
+		hash = Clay__HashMixFloat(hash, transform.Scale.X)
+		hash = Clay__HashMixFloat(hash, transform.Scale.Y)
+		hash = Clay__HashMixBool(hash, transform.ScaleSet)
+		hash = Clay__HashMixFloat(hash, transform.Rotation)
+		hash = Clay__HashMixFloat(hash, transform.Origin.X)
+		hash = Clay__HashMixFloat(hash, transform.Origin.Y)
+		hash = Clay__HashMixFloat(hash, transform.Opacity)
+		return Clay__HashMixBool(hash, transform.OpacitySet), true
+	case CLAY__ELEMENT_CONFIG_TYPE_TRANSITION:
+		// Transitions move the render commands of the element and its descendants after every layout
+		return hash, false
+	}
+	return hash, true
+}
+
+// Mixes a value passed through to the renderer into hash. Pointers, maps, slices, channels and functions are hashed by
+// identity, and strings, numbers and booleans by value. Returns false for any other kind of value, as comparing those
+// would be too slow.
+func Clay__HashUserData(hash uint64, value any) (uint64, bool) {
+	if value == nil {
+		return hash, true
+	}
+	reflected := reflect.ValueOf(value)
+	switch reflected.Kind() {
+	case reflect.Bool:
+		return Clay__HashMixBool(hash, reflected.Bool()), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return Clay__HashMixUint64(hash, uint64(reflected.Int())), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return Clay__HashMixUint64(hash, reflected.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return Clay__HashMixUint64(hash, math.Float64bits(reflected.Float())), true
+	case reflect.String:
+		text := reflected.String()
+		hash = Clay__HashMix(hash, uint32(len(text)))
+		for i := 0; i < len(text); i++ {
+			hash = Clay__HashMix(hash, uint32(text[i]))
+		}
+		return hash, true
+	case reflect.Pointer, reflect.UnsafePointer, reflect.Map, reflect.Chan, reflect.Func:
+		return Clay__HashMixUint64(hash, uint64(reflected.Pointer())), true
+	case reflect.Slice:
+		hash = Clay__HashMixUint64(hash, uint64(reflected.Pointer()))
+		return Clay__HashMix(hash, uint32(reflected.Len())), true
+	}
+	return hash, false
+}
+
+func Clay__HashMixUint64(hash uint64, value uint64) uint64 {
+	return Clay__HashMix(Clay__HashMix(hash, uint32(value)), uint32(value>>32))
+}
@@ -0,0 +1,387 @@
+package clay
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/zodimo/clay-go/pkg/mem"
+)
+
+// Declares a list of items inside a scroll container, with a header, a grid, an aspect ratio box and a tooltip floating
+// next to the highlighted item.
+func testIncrementalLayout(highlighted int) func() {
+	return func() {
+		items := make([]ClayContainer, 0, 8)
+		for i := 0; i < 8; i++ {
+			color := Clay_Color{R: 200, G: 200, B: 200, A: 255}
+			if i == highlighted {
+				color = Clay_Color{R: 255, G: 100, B: 100, A: 255}
+			}
+			content := []ClayContainer{CLAY_TEXT(fmt.Sprintf("item number %d", i))}
+			if i == highlighted {
+				content = append(content, CLAY(CLAY_ID("tooltip"), Clay_ElementDeclaration{
+					Layout:          Clay_LayoutConfig{Padding: CLAY_PADDING_ALL(4)},
+					BackgroundColor: Clay_Color{A: 255},
+					Floating:        Clay_FloatingElementConfig{AttachTo: CLAY_ATTACH_TO_PARENT, AttachPoints: Clay_FloatingAttachPoints{Parent: CLAY_ATTACH_POINT_RIGHT_TOP}},
+				}, CLAY_TEXT("tooltip")))
+			}
+			items = append(items, CLAY(CLAY_ID(fmt.Sprintf("item%d", i)), Clay_ElementDeclaration{
+				Layout: Clay_LayoutConfig{
+					Sizing:  Clay_Sizing{Width: CLAY_SIZING_GROW(Clay_SizingMinMax{})},
+					Padding: CLAY_PADDING_ALL(8),
+				},
+				BackgroundColor: color,
+				Border:          Clay_BorderElementConfig{Color: Clay_Color{A: 255}, Width: Clay_BorderWidth{Bottom: 1}},
+			}, content...))
+		}
+		CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{
+			Layout: Clay_LayoutConfig{
+				Sizing:          Clay_Sizing{Width: CLAY_SIZING_GROW(Clay_SizingMinMax{}), Height: CLAY_SIZING_GROW(Clay_SizingMinMax{})},
+				LayoutDirection: CLAY_TOP_TO_BOTTOM,
+				ChildGap:        10,
+			},
+		},
+			CLAY(CLAY_ID("header"), Clay_ElementDeclaration{
+				Layout: Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_GROW(Clay_SizingMinMax{})}, ChildGap: 10},
+				Border: Clay_BorderElementConfig{Color: Clay_Color{A: 255}, Width: Clay_BorderWidth{BetweenChildren: 2}},
+			},
+				CLAY_TEXT("title"),
+				CLAY_TEXT("a subtitle that wraps onto more lines than one"),
+				CLAY(CLAY_ID("aspect"), Clay_ElementDeclaration{
+					Layout:      Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(40)}},
+					AspectRatio: Clay_AspectRatioElementConfig{AspectRatio: 2},
+				}),
+			),
+			CLAY(CLAY_ID("grid"), Clay_ElementDeclaration{
+				Layout: Clay_LayoutConfig{Grid: Clay_GridLayoutConfig{Columns: []Clay_GridTrack{CLAY_GRID_TRACK_FIXED(50), CLAY_GRID_TRACK_GROW(1)}}},
+			},
+				CLAY_TEXT("cell"),
+				CLAY_TEXT("another cell"),
+			),
+			CLAY(CLAY_ID("list"), Clay_ElementDeclaration{
+				Layout: Clay_LayoutConfig{
+					Sizing:          Clay_Sizing{Width: CLAY_SIZING_GROW(Clay_SizingMinMax{}), Height: CLAY_SIZING_GROW(Clay_SizingMinMax{})},
+					LayoutDirection: CLAY_TOP_TO_BOTTOM,
+				},
+				Clip: Clay_ClipElementConfig{Vertical: true, ChildOffset: Clay_Vector2{Y: -15}},
+			}, items...),
+		)
+	}
+}
+
+var testIncrementalLayoutIds = []string{"root", "header", "aspect", "grid", "list", "tooltip"}
+
+// Runs a layout and returns a copy of its render commands and the bounding boxes of the elements in
+// testIncrementalLayoutIds and of the items.
+func testRunIncrementalLayout(tb testing.TB, layout func()) ([]Clay_RenderCommand, map[uint32]Clay_BoundingBox) {
+	tb.Helper()
+	renderCommands := append([]Clay_RenderCommand(nil), runTestLayout(layout)...)
+	boundingBoxes := map[uint32]Clay_BoundingBox{}
+	for _, id := range testIncrementalLayoutIds {
+		boundingBoxes[CLAY_ID(id).Id] = testBoundingBox(tb, id)
+	}
+	for i := 0; i < 8; i++ {
+		boundingBoxes[CLAY_ID(fmt.Sprintf("item%d", i)).Id] = Clay__GetHashMapItem(CLAY_ID(fmt.Sprintf("item%d", i)).Id).BoundingBox
+	}
+	return renderCommands, boundingBoxes
+}
+
+// Checks that an incremental layout of next after previous has the same results as a full layout of next.
+func testExpectIncrementalLayoutMatches(t *testing.T, previous, next func()) {
+	t.Helper()
+	Clay_SetIncrementalLayoutEnabled(true)
+	runTestLayout(previous)
+	incrementalCommands, incrementalBoxes := testRunIncrementalLayout(t, next)
+	if Clay_GetCurrentContext().IncrementalLayout.ReusedElementCount == 0 {
+		t.Errorf("expected the unchanged elements to be reused")
+	}
+	Clay_SetIncrementalLayoutEnabled(false)
+	fullCommands, fullBoxes := testRunIncrementalLayout(t, next)
+
+	if len(incrementalCommands) != len(fullCommands) {
+		t.Fatalf("expected %d render commands, got %d", len(fullCommands), len(incrementalCommands))
+	}
+	for i := range fullCommands {
+		if !reflect.DeepEqual(incrementalCommands[i], fullCommands[i]) {
+			t.Errorf("render command %d: expected %+v, got %+v", i, fullCommands[i], incrementalCommands[i])
+		}
+	}
+	for id, expected := range fullBoxes {
+		if incrementalBoxes[id] != expected {
+			t.Errorf("element %d: expected bounding box %v, got %v", id, expected, incrementalBoxes[id])
+		}
+	}
+}
+
+func TestIncrementalLayoutMatchesFullLayout(t *testing.T) {
+	newTestContext(t, 400, 600)
+	testExpectIncrementalLayoutMatches(t, testIncrementalLayout(1), testIncrementalLayout(2))
+}
+
+func TestIncrementalLayoutOfUnchangedLayout(t *testing.T) {
+	newTestContext(t, 400, 600)
+	testExpectIncrementalLayoutMatches(t, testIncrementalLayout(1), testIncrementalLayout(1))
+}
+
+func TestIncrementalLayoutWithRTL(t *testing.T) {
+	newTestContext(t, 400, 600)
+	Clay_SetRTLEnabled(true)
+	testExpectIncrementalLayoutMatches(t, testIncrementalLayout(1), testIncrementalLayout(3))
+}
+
+func TestIncrementalLayoutOnlyReusesUnchangedSubtrees(t *testing.T) {
+	newTestContext(t, 400, 600)
+	Clay_SetIncrementalLayoutEnabled(true)
+	runTestLayout(testIncrementalLayout(1))
+	runTestLayout(testIncrementalLayout(2))
+	cache := Clay_GetCurrentContext().IncrementalLayout
+	for _, id := range []string{"header", "item0", "item5"} {
+		item := Clay__GetHashMapItem(CLAY_ID(id).Id)
+		if !testCachedLayoutElementWasReused(cache, item.LayoutCacheIndex) {
+			t.Errorf("expected %s to be reused", id)
+		}
+	}
+	for _, id := range []string{"list", "item1", "item2"} {
+		item := Clay__GetHashMapItem(CLAY_ID(id).Id)
+		if testCachedLayoutElementWasReused(cache, item.LayoutCacheIndex) {
+			t.Errorf("expected %s to be laid out again", id)
+		}
+	}
+}
+
+// Reports whether the element recorded at index in the last layout had the same declaration hash in the layout before
+// it, which is what makes its results reusable.
+func testCachedLayoutElementWasReused(cache *Clay__IncrementalLayoutCache, index int32) bool {
+	// Previous holds the last layout after it ended, Current the one before it
+	last := cache.Previous.Elements[index]
+	for _, before := range cache.Current.Elements {
+		if before.Id == last.Id {
+			return before.DeclarationHash == last.DeclarationHash
+		}
+	}
+	return false
+}
+
+func TestIncrementalLayoutIsDiscardedWhenLayoutDimensionsChange(t *testing.T) {
+	newTestContext(t, 400, 600)
+	Clay_SetIncrementalLayoutEnabled(true)
+	runTestLayout(testIncrementalLayout(1))
+	Clay_SetLayoutDimensions(Clay_Dimensions{Width: 300, Height: 600})
+	runTestLayout(testIncrementalLayout(1))
+	if reused := Clay_GetCurrentContext().IncrementalLayout.ReusedElementCount; reused != 0 {
+		t.Errorf("expected nothing to be reused after resizing the layout, got %d elements", reused)
+	}
+	if box := testBoundingBox(t, "header"); box.Width != 300 {
+		t.Errorf("expected the header to be resized, got %v", box)
+	}
+}
+
+func TestIncrementalLayoutWithUnhashableUserData(t *testing.T) {
+	newTestContext(t, 400, 600)
+	Clay_SetIncrementalLayoutEnabled(true)
+	layout := func() {
+		CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{UserData: struct{ name string }{"root"}},
+			CLAY_TEXT("text"),
+		)
+	}
+	runTestLayout(layout)
+	runTestLayout(layout)
+	// Only the text can be reused, the root has to be laid out again because its user data can't be compared
+	if reused := Clay_GetCurrentContext().IncrementalLayout.ReusedElementCount; reused != 1 {
+		t.Errorf("expected only the text to be reused, got %d reused elements", reused)
+	}
+	if hash := Clay__GetHashMapItem(CLAY_ID("root").Id).LayoutElement.DeclarationHash; hash != 0 {
+		t.Errorf("expected the root to have no declaration hash, got %d", hash)
+	}
+}
+
+func TestIncrementalLayoutIsNotReusedOnHashCollision(t *testing.T) {
+	newTestContext(t, 400, 600)
+	Clay_SetIncrementalLayoutEnabled(true)
+	declare := func(withBox bool) func() {
+		return func() {
+			children := []ClayContainer{CLAY_TEXT("text")}
+			if withBox {
+				children = append(children, CLAY(CLAY_ID("box"), Clay_ElementDeclaration{
+					Layout:          Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(20), Height: CLAY_SIZING_FIXED(20)}},
+					BackgroundColor: Clay_Color{R: 255, A: 255},
+				}))
+			}
+			CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{
+				Layout: Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(100), Height: CLAY_SIZING_FIXED(100)}},
+			}, children...)
+		}
+	}
+	runTestLayout(declare(true))
+	changedHash := Clay__GetHashMapItem(CLAY_ID("root").Id).LayoutElement.DeclarationHash
+	runTestLayout(declare(false))
+	// Make the root of the last layout look like it was declared the same way as the changed root
+	cache := Clay_GetCurrentContext().IncrementalLayout
+	cache.Previous.Elements[Clay__GetHashMapItem(CLAY_ID("root").Id).LayoutCacheIndex].DeclarationHash = changedHash
+
+	renderCommands := runTestLayout(declare(true))
+	if reused := cache.ReusedElementCount; reused != 1 {
+		t.Errorf("expected only the text to be reused, got %d reused elements", reused)
+	}
+	box := testFindRenderCommand(t, renderCommands, "box", CLAY_RENDER_COMMAND_TYPE_RECTANGLE)
+	if expected := (Clay_BoundingBox{X: 40, Y: 0, Width: 20, Height: 20}); box.BoundingBox != expected {
+		t.Errorf("expected the box at %v, got %v", expected, box.BoundingBox)
+	}
+}
+
+func TestIncrementalLayoutKeepsReusedTruncatedText(t *testing.T) {
+	newTestContext(t, 400, 600)
+	Clay_SetIncrementalLayoutEnabled(true)
+	declare := func(first string) func() {
+		return func() {
+			CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{},
+				CLAY(CLAY_ID("first"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(100)}}},
+					CLAY_TEXT(first, TextWithOverflow(CLAY_TEXT_OVERFLOW_ELLIPSIS)),
+				),
+				CLAY(CLAY_ID("second"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(100)}}},
+					CLAY_TEXT("another_long_file_name.txt", TextWithOverflow(CLAY_TEXT_OVERFLOW_ELLIPSIS)),
+				),
+			)
+		}
+	}
+	runTestLayout(declare("short"))
+	// The first text now takes the generated text memory that the reused second text was truncated into, and the
+	// generated text memory is reused by the layouts after it
+	for frame := 0; frame < 3; frame++ {
+		lines := []string{}
+		for _, command := range runTestLayout(declare("a_very_long_file_name.txt")) {
+			if command.CommandType == CLAY_RENDER_COMMAND_TYPE_TEXT {
+				lines = append(lines, string(command.RenderData.Text.StringContents.Chars[:command.RenderData.Text.StringContents.Length]))
+			}
+		}
+		if Clay_GetCurrentContext().IncrementalLayout.ReusedElementCount == 0 {
+			t.Errorf("frame %d: expected the second text to be reused", frame)
+		}
+		testExpectTextLines(t, []string{"a_very_…", "another…"}, lines)
+	}
+}
+
+// Returns the text render commands of a layout along with the lines of the "wrapped" and "rich" text elements.
+func testRunTextLayout(layout func()) ([]testTextCommand, [][]Clay_TextLine) {
+	commands := []testTextCommand{}
+	for _, command := range runTestLayout(layout) {
+		if command.CommandType == CLAY_RENDER_COMMAND_TYPE_TEXT {
+			textData := command.RenderData.Text
+			commands = append(commands, testTextCommand{text: textData.StringContents.String(), box: command.BoundingBox, fontId: textData.FontId})
+		}
+	}
+	return commands, [][]Clay_TextLine{Clay_GetTextLines(CLAY_ID("wrapped")), Clay_GetTextLines(CLAY_ID("rich"))}
+}
+
+func TestIncrementalLayoutRestoresWrappedTextOfMovedSubtrees(t *testing.T) {
+	newTestContext(t, 400, 600)
+	declare := func(frame int) func() {
+		return func() {
+			CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{LayoutDirection: CLAY_TOP_TO_BOTTOM}},
+				// The header changes every frame and is truncated into the generated text memory first
+				CLAY(CLAY_ID("header"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(100), Height: CLAY_SIZING_FIXED(float32(20 + 10*frame))}}},
+					CLAY_TEXT(fmt.Sprintf("header_number_%d.txt", frame), TextWithOverflow(CLAY_TEXT_OVERFLOW_ELLIPSIS)),
+				),
+				CLAY(CLAY_ID("content"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(100)}, LayoutDirection: CLAY_TOP_TO_BOTTOM}},
+					CLAY_TEXT_WITH_ID(CLAY_ID("wrapped"), "a subtitle that wraps onto more lines than one"),
+					CLAY_TEXT("a_very_long_file_name.txt", TextWithOverflow(CLAY_TEXT_OVERFLOW_ELLIPSIS)),
+					CLAY_RICH_TEXT_WITH_ID(CLAY_ID("rich"), []TextSpan{
+						CLAY_TEXT_SPAN("one two "),
+						CLAY_TEXT_SPAN("three four", TextWithColor(Clay_Color{R: 255, A: 255})),
+					}),
+				),
+			)
+		}
+	}
+	expectedCommands := [][]testTextCommand{}
+	expectedLines := [][][]Clay_TextLine{}
+	for frame := 1; frame <= 3; frame++ {
+		commands, lines := testRunTextLayout(declare(frame))
+		expectedCommands = append(expectedCommands, commands)
+		expectedLines = append(expectedLines, lines)
+	}
+
+	Clay_SetIncrementalLayoutEnabled(true)
+	runTestLayout(declare(0))
+	// Moving the content keeps its sizes and wrapped text, while its render commands are generated again
+	for frame := 1; frame <= 3; frame++ {
+		commands, lines := testRunTextLayout(declare(frame))
+		if Clay__GetHashMapItem(CLAY_ID("content").Id).LayoutElement.CachedLayoutIndex == 0 {
+			t.Errorf("frame %d: expected the sizes of the content to be reused", frame)
+		}
+		if !reflect.DeepEqual(commands, expectedCommands[frame-1]) {
+			t.Errorf("frame %d: expected text commands %v, got %v", frame, expectedCommands[frame-1], commands)
+		}
+		if !reflect.DeepEqual(lines, expectedLines[frame-1]) {
+			t.Errorf("frame %d: expected text lines %v, got %v", frame, expectedLines[frame-1], lines)
+		}
+	}
+}
+
+// Declares a screen of 2000 cards of 9 elements each, one of which is highlighted.
+func benchmarkIncrementalLayout(highlighted int) func() {
+	return func() {
+		cards := make([]ClayContainer, 0, 2000)
+		for i := 0; i < 2000; i++ {
+			color := Clay_Color{R: 200, G: 200, B: 200, A: 255}
+			if i == highlighted {
+				color = Clay_Color{R: 255, G: 100, B: 100, A: 255}
+			}
+			cards = append(cards, CLAY(CLAY_ID(fmt.Sprintf("card%d", i)), Clay_ElementDeclaration{
+				Layout:          Clay_LayoutConfig{Padding: CLAY_PADDING_ALL(4), ChildGap: 4, LayoutDirection: CLAY_TOP_TO_BOTTOM},
+				BackgroundColor: color,
+				Border:          Clay_BorderElementConfig{Color: Clay_Color{A: 255}, Width: Clay_BorderWidth{Left: 1, Right: 1, Top: 1, Bottom: 1}},
+			},
+				CLAY_AUTO_ID(Clay_ElementDeclaration{Layout: Clay_LayoutConfig{ChildGap: 2}},
+					CLAY_AUTO_ID(Clay_ElementDeclaration{Layout: Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(16), Height: CLAY_SIZING_FIXED(16)}}, BackgroundColor: Clay_Color{B: 255, A: 255}}),
+					CLAY_TEXT("title"),
+				),
+				CLAY_TEXT("a description"),
+				CLAY_AUTO_ID(Clay_ElementDeclaration{Layout: Clay_LayoutConfig{ChildGap: 2}},
+					CLAY_AUTO_ID(Clay_ElementDeclaration{Layout: Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(20), Height: CLAY_SIZING_FIXED(8)}}, BackgroundColor: Clay_Color{G: 255, A: 255}}),
+					CLAY_AUTO_ID(Clay_ElementDeclaration{Layout: Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(20), Height: CLAY_SIZING_FIXED(8)}}, BackgroundColor: Clay_Color{G: 255, A: 255}}),
+					CLAY_TEXT("tag"),
+				),
+			))
+		}
+		CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{
+			Layout: Clay_LayoutConfig{
+				Sizing: Clay_Sizing{Width: CLAY_SIZING_GROW(Clay_SizingMinMax{})},
+				Wrap:   CLAY_WRAP_WRAP,
+			},
+		}, cards...)
+	}
+}
+
+func benchmarkLayout(b *testing.B, incremental bool) {
+	arena, err := mem.NewArena(make([]byte, 256*1024*1024))
+	if err != nil {
+		b.Fatalf("failed to create arena: %v", err)
+	}
+	Clay_SetCurrentContext(&Clay_Context{MaxElementCount: 32768, MaxMeasureTextCacheWordCount: 32768})
+	Clay_Initialize(*arena, Clay_Dimensions{Width: 1920, Height: 100000}, Clay_ErrorHandler{})
+	Clay_SetMeasureTextFunction(testMeasureText, nil)
+	Clay_SetIncrementalLayoutEnabled(incremental)
+	b.ResetTimer()
+	var layoutDuration time.Duration
+	for i := 0; i < b.N; i++ {
+		// Only the highlighted card changes between frames, like when the pointer moves over the cards
+		Clay_BeginLayout()
+		benchmarkIncrementalLayout(i % 2000)()
+		start := time.Now()
+		Clay_EndLayout()
+		layoutDuration += time.Since(start)
+	}
+	// Incremental layout speeds up Clay_EndLayout, while declaring the elements takes as long and includes hashing them
+	b.ReportMetric(float64(layoutDuration.Nanoseconds())/float64(b.N), "layout-ns/op")
+}
+
+func BenchmarkFullLayout(b *testing.B) {
+	benchmarkLayout(b, false)
+}
+
+func BenchmarkIncrementalLayout(b *testing.B) {
+	benchmarkLayout(b, true)
+}
@@ -2,6 +2,8 @@ package clay
 
 import (
 	"fmt"
+	"unicode/utf8"
+	"unsafe"
 
 	"github.com/zodimo/clay-go/pkg/mem"
 )
@@ -36,6 +38,16 @@ func Clay__Array_Allocate_Arena[T any](capacity int32, arena *Clay_Arena, option
 	return mem.NewMemArray[T](capacity, append(options, mem.MemArrayWithArena[T](arena))...)
 }
 
+// Empties an array of ephemeral memory for the next layout. The array is only allocated again if its capacity changed, as
+// allocating every array for every layout leaves the garbage collector to clear and scan all of them each frame.
+func Clay__Array_Reallocate_Arena[T any](array *Clay__Array[T], capacity int32, arena *Clay_Arena) {
+	if array.Capacity() == capacity {
+		Clay__Array_Reset(array)
+		return
+	}
+	*array = Clay__Array_Allocate_Arena[T](capacity, arena)
+}
+
 type Clay__LayoutElementChildren struct {
 	Elements []int32
 	Length   uint16
@@ -50,6 +62,13 @@ type Clay__LayoutElementTreeNode struct {
 	LayoutElement   *Clay_LayoutElement
 	Position        Clay_Vector2
 	NextChildOffset Clay_Vector2
+	// Space added before the first child and between children along the layout axis by CLAY_ALIGN_X/Y_SPACE_* alignment.
+	DistributedLeadingSpace float32
+	DistributedChildGap     float32
+	// The index of the results of the element in the incremental layout cache, or -1.
+	CacheIndex int32
+	// The index of the element in TransitionElements, or -1 if it has no transition.
+	TransitionIndex int32
 }
 
 func Clay__CloseElement() {
@@ -98,22 +117,26 @@ func Clay__CloseElement() {
 		)
 	}
 
-	if layoutConfig.LayoutDirection == CLAY_LEFT_TO_RIGHT {
+	var largestChildMinSize float32
+	if Clay__LayoutDirectionIsHorizontal(layoutConfig.LayoutDirection) {
 		openLayoutElement.Dimensions.Width = leftRightPadding
 		openLayoutElement.MinDimensions.Width = leftRightPadding
 		if openLayoutElement.ChildrenOrTextContent.Children.Length > 0 && int32(openLayoutElement.ChildrenOrTextContent.Children.Length) <= currentContext.LayoutElementChildrenBuffer.Length() {
 			for i := uint16(0); i < openLayoutElement.ChildrenOrTextContent.Children.Length; i++ {
 				childIndex := Clay__Array_GetValue(&currentContext.LayoutElementChildrenBuffer, currentContext.LayoutElementChildrenBuffer.Length()-int32(openLayoutElement.ChildrenOrTextContent.Children.Length)+int32(i))
 				child := Clay__Array_Get(&currentContext.LayoutElements, childIndex)
-				openLayoutElement.Dimensions.Width += child.Dimensions.Width
-				openLayoutElement.Dimensions.Height = CLAY__MAX(openLayoutElement.Dimensions.Height, child.Dimensions.Height+topBottomPadding)
+				childMarginX := Clay__MarginSizeAlongAxis(child, true)
+				childMarginY := Clay__MarginSizeAlongAxis(child, false)
+				openLayoutElement.Dimensions.Width += child.Dimensions.Width + childMarginX
+				openLayoutElement.Dimensions.Height = CLAY__MAX(openLayoutElement.Dimensions.Height, child.Dimensions.Height+childMarginY+topBottomPadding)
 
 				// Minimum size of child elements doesn't matter to clip containers as they can shrink and hide their contents
 				if !elementHasClipHorizontal {
-					openLayoutElement.MinDimensions.Width += child.MinDimensions.Width
+					openLayoutElement.MinDimensions.Width += child.MinDimensions.Width + childMarginX
 				}
+				largestChildMinSize = CLAY__MAX(largestChildMinSize, child.MinDimensions.Width+childMarginX)
 				if !elementHasClipVertical {
-					openLayoutElement.MinDimensions.Height = CLAY__MAX(openLayoutElement.MinDimensions.Height, child.MinDimensions.Height+topBottomPadding)
+					openLayoutElement.MinDimensions.Height = CLAY__MAX(openLayoutElement.MinDimensions.Height, child.MinDimensions.Height+childMarginY+topBottomPadding)
 				}
 				Clay__Array_Add(&currentContext.LayoutElementChildren, childIndex)
 			}
@@ -125,20 +148,27 @@ func Clay__CloseElement() {
 		if !elementHasClipHorizontal {
 			openLayoutElement.MinDimensions.Width += childGap
 		}
-	} else if layoutConfig.LayoutDirection == CLAY_TOP_TO_BOTTOM {
+		// Wrapping containers can move children onto new lines, so they only need to fit their largest child
+		if layoutConfig.Wrap == CLAY_WRAP_WRAP && !elementHasClipHorizontal {
+			openLayoutElement.MinDimensions.Width = leftRightPadding + largestChildMinSize
+		}
+	} else {
 		openLayoutElement.Dimensions.Height = topBottomPadding
 		openLayoutElement.MinDimensions.Height = topBottomPadding
 		if openLayoutElement.ChildrenOrTextContent.Children.Length > 0 && int32(openLayoutElement.ChildrenOrTextContent.Children.Length) <= currentContext.LayoutElementChildrenBuffer.Length() {
 			for i := uint16(0); i < openLayoutElement.ChildrenOrTextContent.Children.Length; i++ {
 				childIndex := Clay__Array_GetValue(&currentContext.LayoutElementChildrenBuffer, currentContext.LayoutElementChildrenBuffer.Length()-int32(openLayoutElement.ChildrenOrTextContent.Children.Length)+int32(i))
 				child := Clay__Array_Get(&currentContext.LayoutElements, childIndex)
-				openLayoutElement.Dimensions.Height += child.Dimensions.Height
-				openLayoutElement.Dimensions.Width = CLAY__MAX(openLayoutElement.Dimensions.Width, child.Dimensions.Width+leftRightPadding)
+				childMarginX := Clay__MarginSizeAlongAxis(child, true)
+				childMarginY := Clay__MarginSizeAlongAxis(child, false)
+				openLayoutElement.Dimensions.Height += child.Dimensions.Height + childMarginY
+				openLayoutElement.Dimensions.Width = CLAY__MAX(openLayoutElement.Dimensions.Width, child.Dimensions.Width+childMarginX+leftRightPadding)
 				if !elementHasClipVertical {
-					openLayoutElement.MinDimensions.Height += child.MinDimensions.Height
+					openLayoutElement.MinDimensions.Height += child.MinDimensions.Height + childMarginY
 				}
+				largestChildMinSize = CLAY__MAX(largestChildMinSize, child.MinDimensions.Height+childMarginY)
 				if !elementHasClipHorizontal {
-					openLayoutElement.MinDimensions.Width = CLAY__MAX(openLayoutElement.MinDimensions.Width, child.MinDimensions.Width+leftRightPadding)
+					openLayoutElement.MinDimensions.Width = CLAY__MAX(openLayoutElement.MinDimensions.Width, child.MinDimensions.Width+childMarginX+leftRightPadding)
 				}
 				Clay__Array_Add(&currentContext.LayoutElementChildren, childIndex)
 			}
@@ -150,6 +180,9 @@ func Clay__CloseElement() {
 		if !elementHasClipVertical {
 			openLayoutElement.MinDimensions.Height += childGap
 		}
+		if layoutConfig.Wrap == CLAY_WRAP_WRAP && !elementHasClipVertical {
+			openLayoutElement.MinDimensions.Height = topBottomPadding + largestChildMinSize
+		}
 	}
 
 	// Resize the Elements slice to match the Length field after adding children
@@ -165,6 +198,9 @@ func Clay__CloseElement() {
 			openLayoutElement.ChildrenOrTextContent.Children.Length = 0
 		}
 	}
+	if len(layoutConfig.Grid.Columns) > 0 && !Clay__ElementHasConfig(openLayoutElement, CLAY__ELEMENT_CONFIG_TYPE_TEXT) {
+		Clay__CloseGridElement(openLayoutElement, elementHasClipHorizontal, elementHasClipVertical)
+	}
 	// Note: We don't set Elements to an empty slice when Length == 0 because:
 	// 1. Text elements use TextElementData instead of Children, and modifying Children might affect them
 	// 2. Container elements with no children will have Elements set when they get children
@@ -192,8 +228,20 @@ func Clay__CloseElement() {
 		openLayoutElement.Dimensions.Height = 0
 	}
 
+	// Columns of CLAY_TOP_TO_BOTTOM wrapping are estimated from the heights of the children, so that the siblings of the
+	// element are sized around them along the x axis
+	if layoutConfig.Wrap == CLAY_WRAP_WRAP && !Clay__LayoutDirectionIsHorizontal(layoutConfig.LayoutDirection) && layoutConfig.Sizing.Width.Type == CLAY__SIZING_TYPE_FIT &&
+		len(layoutConfig.Grid.Columns) == 0 && openLayoutElement.ChildrenOrTextContent.Children.Length > 0 && !Clay__ElementHasConfig(openLayoutElement, CLAY__ELEMENT_CONFIG_TYPE_TEXT) {
+		_, columnsWidth, _ := Clay__WrappedContentSize(openLayoutElement)
+		openLayoutElement.Dimensions.Width = CLAY__MIN(CLAY__MAX(CLAY__MAX(leftRightPadding+columnsWidth, openLayoutElement.Dimensions.Width), layoutConfig.Sizing.Width.Size.MinMax.Min), layoutConfig.Sizing.Width.Size.MinMax.Max)
+	}
+
 	Clay__UpdateAspectRatioBox(openLayoutElement)
 
+	if currentContext.IncrementalLayout != nil {
+		openLayoutElement.DeclarationHash = Clay__HashElementDeclaration(openLayoutElement)
+	}
+
 	elementIsFloating := Clay__ElementHasConfig(openLayoutElement, CLAY__ELEMENT_CONFIG_TYPE_FLOATING)
 
 	// Close the currently open element
@@ -753,14 +801,27 @@ func Clay__AddRenderCommand(renderCommand Clay_RenderCommand) {
 	} else {
 		if !currentContext.BooleanWarnings.MaxRenderCommandsExceeded {
 			currentContext.BooleanWarnings.MaxRenderCommandsExceeded = true
-			currentContext.ErrorHandler.ErrorHandlerFunction(Clay_ErrorData{
-				ErrorType: CLAY_ERROR_TYPE_ELEMENTS_CAPACITY_EXCEEDED,
-				ErrorText: CLAY_STRING("Clay ran out of capacity while attempting to create render commands. This is usually caused by a large amount of wrapping text elements while close to the max element capacity. Try using Clay_SetMaxElementCount() with a higher value."),
-				UserData:  currentContext.ErrorHandler.UserData,
-			})
+			Clay__ReportError(CLAY_ERROR_TYPE_ELEMENTS_CAPACITY_EXCEEDED, CLAY_STRING("Clay ran out of capacity while attempting to create render commands. This is usually caused by a large amount of wrapping text elements while close to the max element capacity. Try using Clay_SetMaxElementCount() with a higher value."))
 		}
 	}
 }
+
+// Adds render commands that are known not to be of the NONE type at once, like Clay__AddRenderCommand does one by one.
+func Clay__AddRenderCommands(renderCommands []Clay_RenderCommand) {
+	currentContext := Clay_GetCurrentContext()
+	start := currentContext.RenderCommands.Length()
+	count := int32(len(renderCommands))
+	if start+count >= currentContext.RenderCommands.Capacity() {
+		// Adds the commands that fit and reports running out of capacity
+		for _, renderCommand := range renderCommands {
+			Clay__AddRenderCommand(renderCommand)
+		}
+		return
+	}
+	Clay__Array_Grow(&currentContext.RenderCommands, count)
+	copy(mem.MArray_GetSlice(&currentContext.RenderCommands, start, start+count), renderCommands)
+}
+
 func Clay__GenerateIdForAnonymousElement(openLayoutElement *Clay_LayoutElement) Clay_ElementId {
 	currentContext := Clay_GetCurrentContext()
 	parentElement := Clay__Array_Get(&currentContext.LayoutElements, Clay__Array_GetValue(&currentContext.OpenLayoutElementStack, currentContext.OpenLayoutElementStack.Length()-2))
@@ -782,11 +843,7 @@ func Clay__OpenElement() {
 	openLayoutElement := Clay__Array_Add(&currentContext.LayoutElements, layoutElement)
 	Clay__Array_Add(&currentContext.OpenLayoutElementStack, currentContext.LayoutElements.Length()-1)
 	Clay__GenerateIdForAnonymousElement(openLayoutElement)
-	if currentContext.OpenClipElementStack.Length() > 0 {
-		Clay__Array_Set(&currentContext.LayoutElementClipElementIds, currentContext.LayoutElements.Length()-1, Clay__Array_GetValue(&currentContext.OpenClipElementStack, currentContext.OpenClipElementStack.Length()-1))
-	} else {
-		Clay__Array_Set(&currentContext.LayoutElementClipElementIds, currentContext.LayoutElements.Length()-1, 0)
-	}
+	Clay__SetOpenElementClipElementId(currentContext.LayoutElements.Length() - 1)
 }
 func Clay__OpenElementWithId(elementId Clay_ElementId) {
 	currentContext := Clay_GetCurrentContext()
@@ -794,30 +851,33 @@ func Clay__OpenElementWithId(elementId Clay_ElementId) {
 		currentContext.BooleanWarnings.MaxElementsExceeded = true
 		return
 	}
+	existingItem := Clay__GetHashMapItem(elementId.Id)
+	if existingItem != &Clay_LayoutElementHashMapItem_DEFAULT && existingItem.Generation > currentContext.Generation {
+		Clay__ReportDuplicateId(existingItem, elementId)
+		if currentContext.DisambiguateDuplicateIds {
+			elementId = Clay__DisambiguateElementId(elementId)
+		}
+	}
 	layoutElement := Clay_LayoutElement{}
 	layoutElement.Id = elementId.Id
 	openLayoutElement := Clay__Array_Add(&currentContext.LayoutElements, layoutElement)
 	Clay__Array_Add(&currentContext.OpenLayoutElementStack, currentContext.LayoutElements.Length()-1) // add the index of the new layout element to the open layout element stack
 	Clay__AddHashMapItem(elementId, openLayoutElement)
 	Clay__Array_Add(&currentContext.LayoutElementIdStrings, elementId.StringId)
-	if currentContext.OpenClipElementStack.Length() > 0 {
-		if currentContext.LayoutElementClipElementIds.Length() == 0 {
-			Clay__Array_Add(&currentContext.LayoutElementClipElementIds, Clay__Array_GetValue(&currentContext.OpenClipElementStack, currentContext.OpenClipElementStack.Length()-1))
+	Clay__SetOpenElementClipElementId(currentContext.LayoutElements.Length() - 1)
+}
 
-		} else {
-			Clay__Array_Set(&currentContext.LayoutElementClipElementIds, currentContext.LayoutElements.Length()-1, Clay__Array_GetValue(&currentContext.OpenClipElementStack, currentContext.OpenClipElementStack.Length()-1))
-		}
-	} else {
-		if currentContext.LayoutElementClipElementIds.Length() == 0 {
-			Clay__Array_Add(&currentContext.LayoutElementClipElementIds, 0)
-		} else {
-			if currentContext.LayoutElementClipElementIds.Length() <= currentContext.LayoutElements.Length()-1 {
-				Clay__Array_Add(&currentContext.LayoutElementClipElementIds, 0)
-			} else {
-				Clay__Array_Set(&currentContext.LayoutElementClipElementIds, currentContext.LayoutElements.Length()-1, 0)
-			}
-		}
+// Records the id of the innermost open clip element (or 0) as the clip element of the layout element at elementIndex.
+func Clay__SetOpenElementClipElementId(elementIndex int32) {
+	currentContext := Clay_GetCurrentContext()
+	clipElementId := int32(0)
+	if currentContext.OpenClipElementStack.Length() > 0 {
+		clipElementId = Clay__Array_GetValue(&currentContext.OpenClipElementStack, currentContext.OpenClipElementStack.Length()-1)
 	}
+	for currentContext.LayoutElementClipElementIds.Length() <= elementIndex {
+		Clay__Array_Add(&currentContext.LayoutElementClipElementIds, 0)
+	}
+	Clay__Array_Set(&currentContext.LayoutElementClipElementIds, elementIndex, clipElementId)
 }
 
 func Clay__StoreLayoutConfig(config Clay_LayoutConfig) *Clay_LayoutConfig {
@@ -878,6 +938,30 @@ func Clay__StoreCustomElementConfig(config Clay_CustomElementConfig) *Clay_Custo
 	return Clay__Array_Add(&currentContext.CustomElementConfigs, config)
 }
 
+func Clay__StoreTransitionElementConfig(config Clay_TransitionElementConfig) *Clay_TransitionElementConfig {
+	currentContext := Clay_GetCurrentContext()
+	if currentContext.BooleanWarnings.MaxElementsExceeded {
+		return &Clay_TransitionElementConfig{}
+	}
+	return Clay__Array_Add(&currentContext.TransitionElementConfigs, config)
+}
+
+func Clay__StoreShadowElementConfig(config Clay_ShadowElementConfig) *Clay_ShadowElementConfig {
+	currentContext := Clay_GetCurrentContext()
+	if currentContext.BooleanWarnings.MaxElementsExceeded {
+		return &Clay_ShadowElementConfig{}
+	}
+	return Clay__Array_Add(&currentContext.ShadowElementConfigs, config)
+}
+
+func Clay__StoreTransformElementConfig(config Clay_TransformElementConfig) *Clay_TransformElementConfig {
+	currentContext := Clay_GetCurrentContext()
+	if currentContext.BooleanWarnings.MaxElementsExceeded {
+		return &Clay_TransformElementConfig{}
+	}
+	return Clay__Array_Add(&currentContext.TransformElementConfigs, config)
+}
+
 func Clay__StoreClipElementConfig(config Clay_ClipElementConfig) *Clay_ClipElementConfig {
 	currentContext := Clay_GetCurrentContext()
 	if currentContext.BooleanWarnings.MaxElementsExceeded {
@@ -904,11 +988,7 @@ func Clay__ConfigureOpenElementPtr(elementDeclaration *Clay_ElementDeclaration)
 	openLayoutElement.LayoutConfig = Clay__StoreLayoutConfig(elementDeclaration.Layout)
 
 	if elementDeclaration.Layout.Sizing.Width.Type == CLAY__SIZING_TYPE_PERCENT && elementDeclaration.Layout.Sizing.Width.Size.Percent > 1 || elementDeclaration.Layout.Sizing.Height.Type == CLAY__SIZING_TYPE_PERCENT && elementDeclaration.Layout.Sizing.Height.Size.Percent > 1 {
-		currentContext.ErrorHandler.ErrorHandlerFunction(Clay_ErrorData{
-			ErrorType: CLAY_ERROR_TYPE_PERCENTAGE_OVER_1,
-			ErrorText: CLAY_STRING("An element was configured with CLAY_SIZING_PERCENT, but the provided percentage value was over 1.0. Clay expects a value between 0 and 1, i.e. 20% is 0.2."),
-			UserData:  currentContext.ErrorHandler.UserData,
-		})
+		Clay__ReportElementError(CLAY_ERROR_TYPE_PERCENTAGE_OVER_1, CLAY_STRING("An element was configured with CLAY_SIZING_PERCENT, but the provided percentage value was over 1.0. Clay expects a value between 0 and 1, i.e. 20% is 0.2."), Clay__GetElementIdForLayoutElement(Clay__Array_GetValue(&currentContext.OpenLayoutElementStack, currentContext.OpenLayoutElementStack.Length()-1)))
 	}
 
 	// Capture the starting length before attaching configs
@@ -917,16 +997,22 @@ func Clay__ConfigureOpenElementPtr(elementDeclaration *Clay_ElementDeclaration)
 	var sharedConfig *Clay_SharedElementConfig = nil
 
 	if elementDeclaration.BackgroundColor.A > 0 {
-		sharedConfig = new(Clay_SharedElementConfig)
-		sharedConfig.BackgroundColor = elementDeclaration.BackgroundColor
+		sharedConfig = Clay__StoreSharedElementConfig(Clay_SharedElementConfig{BackgroundColor: elementDeclaration.BackgroundColor})
 		Clay__AttachElementConfig(Clay_ElementConfigUnion{SharedElementConfig: sharedConfig}, CLAY__ELEMENT_CONFIG_TYPE_SHARED)
 	}
+	if Clay_GradientIsSet(&elementDeclaration.BackgroundGradient) {
+		if sharedConfig != nil {
+			sharedConfig.BackgroundGradient = elementDeclaration.BackgroundGradient
+		} else {
+			sharedConfig = Clay__StoreSharedElementConfig(Clay_SharedElementConfig{BackgroundGradient: elementDeclaration.BackgroundGradient})
+			Clay__AttachElementConfig(Clay_ElementConfigUnion{SharedElementConfig: sharedConfig}, CLAY__ELEMENT_CONFIG_TYPE_SHARED)
+		}
+	}
 	if !Clay__MemCmpTyped(&elementDeclaration.CornerRadius, &Clay__CornerRadius_DEFAULT) {
 		if sharedConfig != nil {
 			sharedConfig.CornerRadius = elementDeclaration.CornerRadius
 		} else {
-			sharedConfig = new(Clay_SharedElementConfig)
-			sharedConfig.CornerRadius = elementDeclaration.CornerRadius
+			sharedConfig = Clay__StoreSharedElementConfig(Clay_SharedElementConfig{CornerRadius: elementDeclaration.CornerRadius})
 			Clay__AttachElementConfig(Clay_ElementConfigUnion{SharedElementConfig: sharedConfig}, CLAY__ELEMENT_CONFIG_TYPE_SHARED)
 		}
 	}
@@ -952,61 +1038,42 @@ func Clay__ConfigureOpenElementPtr(elementDeclaration *Clay_ElementDeclaration)
 	if elementDeclaration.Floating.AttachTo != CLAY_ATTACH_TO_NONE {
 		floatingConfig := elementDeclaration.Floating
 		// This looks dodgy but because of the auto generated root element the depth of the tree will always be at least 2 here
-
 		hierarchicalParent := Clay__Array_Get[Clay_LayoutElement](&currentContext.LayoutElements, Clay__Array_GetValue[int32](&currentContext.OpenLayoutElementStack, currentContext.OpenLayoutElementStack.Length()-2))
-		if hierarchicalParent != nil {
-			var clipElementId int32 = 0
-			if elementDeclaration.Floating.AttachTo == CLAY_ATTACH_TO_PARENT {
-				// Attach to the element's direct hierarchical parent
-				floatingConfig.ParentId = hierarchicalParent.Id
-				if currentContext.OpenClipElementStack.Length() > 0 {
-					clipElementId = Clay__Array_GetValue(&currentContext.OpenClipElementStack, currentContext.OpenClipElementStack.Length()-1)
-				} else if elementDeclaration.Floating.AttachTo == CLAY_ATTACH_TO_ELEMENT_WITH_ID {
-					parentItem := Clay__GetHashMapItem(floatingConfig.ParentId)
-					// check if parentItem is pointing to the default item
-					defaultItem := &Clay_LayoutElementHashMapItem_DEFAULT
-					if parentItem == defaultItem {
-						currentContext.ErrorHandler.ErrorHandlerFunction(Clay_ErrorData{
-							ErrorType: CLAY_ERROR_TYPE_FLOATING_CONTAINER_PARENT_NOT_FOUND,
-							ErrorText: CLAY_STRING("A floating element was declared with a parentId, but no element with that ID was found."),
-							UserData:  currentContext.ErrorHandler.UserData,
-						})
-					} else {
-						var clipItemIndex int32 = -1
-						for i, elem := range mem.MArray_GetAll(&currentContext.LayoutElements) {
-							if &elem == parentItem.LayoutElement {
-								clipItemIndex = int32(i)
-								break
-							}
-						}
-						if clipItemIndex != -1 {
-							clipElementId = Clay__Array_GetValue[int32](&currentContext.LayoutElementClipElementIds, clipItemIndex)
-						}
-					}
-				} else if elementDeclaration.Floating.AttachTo == CLAY_ATTACH_TO_ROOT {
-					floatingConfig.ParentId = Clay__HashString(CLAY_STRING("Clay__RootContainer"), 0).Id
-				}
-
-				if elementDeclaration.Floating.ClipTo == CLAY_CLIP_TO_NONE {
-					clipElementId = 0
-				}
-				currentElementIndex := Clay__Array_GetValue[int32](&currentContext.OpenLayoutElementStack, currentContext.OpenLayoutElementStack.Length()-1)
-				Clay__Array_Set(&currentContext.LayoutElementClipElementIds, currentElementIndex, clipElementId)
-				Clay__Array_Add(&currentContext.OpenClipElementStack, clipElementId)
-				Clay__Array_Add(&currentContext.LayoutElementTreeRoots, Clay__LayoutElementTreeRoot{
-					LayoutElementIndex: Clay__Array_GetValue[int32](&currentContext.OpenLayoutElementStack, currentContext.OpenLayoutElementStack.Length()-1),
-					ParentId:           floatingConfig.ParentId,
-					ClipElementId:      uint32(clipElementId),
-					ZIndex:             floatingConfig.ZIndex,
-				})
-				Clay__AttachElementConfig(Clay_ElementConfigUnion{FloatingElementConfig: Clay__StoreFloatingElementConfig(floatingConfig)}, CLAY__ELEMENT_CONFIG_TYPE_FLOATING)
+		currentElementIndex := Clay__Array_GetValue[int32](&currentContext.OpenLayoutElementStack, currentContext.OpenLayoutElementStack.Length()-1)
+		var clipElementId int32 = 0
+		switch floatingConfig.AttachTo {
+		case CLAY_ATTACH_TO_PARENT:
+			// Attach to the element's direct hierarchical parent
+			floatingConfig.ParentId = hierarchicalParent.Id
+			if currentContext.OpenClipElementStack.Length() > 0 {
+				clipElementId = Clay__Array_GetValue(&currentContext.OpenClipElementStack, currentContext.OpenClipElementStack.Length()-1)
 			}
+		case CLAY_ATTACH_TO_ELEMENT_WITH_ID:
+			// The parent may be declared later in this layout, in which case the clip element is resolved (and a missing parent
+			// reported) by Clay__ResolveFloatingAttachments once all elements have been declared.
+			if parentIndex := Clay__GetDeclaredLayoutElementIndex(floatingConfig.ParentId); parentIndex != -1 {
+				clipElementId = Clay__Array_GetValue(&currentContext.LayoutElementClipElementIds, parentIndex)
+			}
+		case CLAY_ATTACH_TO_ROOT:
+			floatingConfig.ParentId = Clay__HashString(CLAY_STRING("Clay__RootContainer"), 0).Id
 		}
-		if elementDeclaration.Custom.CustomData != nil {
-			Clay__AttachElementConfig(Clay_ElementConfigUnion{
-				CustomElementConfig: Clay__StoreCustomElementConfig(elementDeclaration.Custom),
-			}, CLAY__ELEMENT_CONFIG_TYPE_CUSTOM)
+		if floatingConfig.ClipTo == CLAY_CLIP_TO_NONE {
+			clipElementId = 0
 		}
+		Clay__Array_Set(&currentContext.LayoutElementClipElementIds, currentElementIndex, clipElementId)
+		Clay__Array_Add(&currentContext.OpenClipElementStack, clipElementId)
+		Clay__Array_Add(&currentContext.LayoutElementTreeRoots, Clay__LayoutElementTreeRoot{
+			LayoutElementIndex: currentElementIndex,
+			ParentId:           floatingConfig.ParentId,
+			ClipElementId:      uint32(clipElementId),
+			ZIndex:             floatingConfig.ZIndex,
+		})
+		Clay__AttachElementConfig(Clay_ElementConfigUnion{FloatingElementConfig: Clay__StoreFloatingElementConfig(floatingConfig)}, CLAY__ELEMENT_CONFIG_TYPE_FLOATING)
+	}
+	if elementDeclaration.Custom.CustomData != nil {
+		Clay__AttachElementConfig(Clay_ElementConfigUnion{
+			CustomElementConfig: Clay__StoreCustomElementConfig(elementDeclaration.Custom),
+		}, CLAY__ELEMENT_CONFIG_TYPE_CUSTOM)
 	}
 
 	if elementDeclaration.Clip.Horizontal || elementDeclaration.Clip.Vertical {
@@ -1023,9 +1090,11 @@ func Clay__ConfigureOpenElementPtr(elementDeclaration *Clay_ElementDeclaration)
 				scrollOffset = mapping
 				scrollOffset.LayoutElement = openLayoutElement
 				scrollOffset.OpenThisFrame = true
+				openLayoutElement.ScrollContainerDataIndex = i + 1
 			}
 		}
 		if scrollOffset == nil {
+			openLayoutElement.ScrollContainerDataIndex = currentContext.ScrollContainerDatas.Length() + 1
 			scrollOffset = Clay__Array_Add(&currentContext.ScrollContainerDatas, Clay__ScrollContainerDataInternal{
 				LayoutElement: openLayoutElement,
 				ScrollOrigin:  Clay_Vector2{-1, -1},
@@ -1041,6 +1110,26 @@ func Clay__ConfigureOpenElementPtr(elementDeclaration *Clay_ElementDeclaration)
 			BorderElementConfig: Clay__StoreBorderElementConfig(elementDeclaration.Border),
 		}, CLAY__ELEMENT_CONFIG_TYPE_BORDER)
 	}
+	if elementDeclaration.Shadow.Color.A > 0 {
+		Clay__AttachElementConfig(Clay_ElementConfigUnion{
+			ShadowElementConfig: Clay__StoreShadowElementConfig(elementDeclaration.Shadow),
+		}, CLAY__ELEMENT_CONFIG_TYPE_SHADOW)
+	}
+	if !Clay__MemCmpTyped(&elementDeclaration.Transform, &Clay__TransformElementConfig_DEFAULT) {
+		Clay__AttachElementConfig(Clay_ElementConfigUnion{
+			TransformElementConfig: Clay__StoreTransformElementConfig(elementDeclaration.Transform),
+		}, CLAY__ELEMENT_CONFIG_TYPE_TRANSFORM)
+	}
+	if elementDeclaration.Transition.Duration > 0 {
+		// A transparent background still needs a rectangle to fade in from or out to
+		if elementDeclaration.Transition.Properties&CLAY_TRANSITION_PROPERTY_BACKGROUND_COLOR != 0 && sharedConfig == nil {
+			sharedConfig = Clay__StoreSharedElementConfig(Clay_SharedElementConfig{})
+			Clay__AttachElementConfig(Clay_ElementConfigUnion{SharedElementConfig: sharedConfig}, CLAY__ELEMENT_CONFIG_TYPE_SHARED)
+		}
+		Clay__AttachElementConfig(Clay_ElementConfigUnion{
+			TransitionElementConfig: Clay__StoreTransitionElementConfig(elementDeclaration.Transition),
+		}, CLAY__ELEMENT_CONFIG_TYPE_TRANSITION)
+	}
 
 	// Set the element configs slice AFTER all configs have been attached
 	elementConfigsEndLength := currentContext.ElementConfigs.Length()
@@ -1051,6 +1140,66 @@ func Clay__ConfigureOpenElementPtr(elementDeclaration *Clay_ElementDeclaration)
 	openLayoutElement.ElementConfigs = elementConfigs
 }
 
+// Returns the index of the provided element in the LayoutElements array, or -1 if it isn't part of the current layout.
+func Clay__GetLayoutElementIndex(layoutElement *Clay_LayoutElement) int32 {
+	currentContext := Clay_GetCurrentContext()
+	layoutElements := mem.MArray_GetAll(&currentContext.LayoutElements)
+	if layoutElement == nil || len(layoutElements) == 0 {
+		return -1
+	}
+	elementSize := unsafe.Sizeof(layoutElements[0])
+	offset := uintptr(unsafe.Pointer(layoutElement)) - uintptr(unsafe.Pointer(&layoutElements[0]))
+	if offset%elementSize != 0 || offset/elementSize >= uintptr(len(layoutElements)) {
+		return -1
+	}
+	return int32(offset / elementSize)
+}
+
+// Returns the index of the element with the provided id in the LayoutElements array, or -1 if no element with that id
+// has been declared so far during the current layout.
+func Clay__GetDeclaredLayoutElementIndex(id uint32) int32 {
+	currentContext := Clay_GetCurrentContext()
+	hashMapItem := Clay__GetHashMapItem(id)
+	if hashMapItem == &Clay_LayoutElementHashMapItem_DEFAULT || hashMapItem.Generation != currentContext.Generation+1 {
+		return -1
+	}
+	return Clay__GetLayoutElementIndex(hashMapItem.LayoutElement)
+}
+
+// Floating elements attached with CLAY_ATTACH_TO_ELEMENT_WITH_ID may reference an element that is declared after them,
+// so their parents are validated and their clip elements resolved once the whole layout has been declared.
+func Clay__ResolveFloatingAttachments() {
+	currentContext := Clay_GetCurrentContext()
+	for i := int32(0); i < currentContext.LayoutElementTreeRoots.Length(); i++ {
+		root := Clay__Array_Get(&currentContext.LayoutElementTreeRoots, i)
+		rootElement := Clay__Array_Get(&currentContext.LayoutElements, root.LayoutElementIndex)
+		if !Clay__ElementHasConfig(rootElement, CLAY__ELEMENT_CONFIG_TYPE_FLOATING) {
+			continue
+		}
+		floatingConfig := Clay__FindElementConfigWithType(rootElement, CLAY__ELEMENT_CONFIG_TYPE_FLOATING).FloatingElementConfig
+		if floatingConfig.AttachTo != CLAY_ATTACH_TO_ELEMENT_WITH_ID {
+			continue
+		}
+		parentIndex := Clay__GetDeclaredLayoutElementIndex(floatingConfig.ParentId)
+		if parentIndex == -1 {
+			rootItem := Clay__GetHashMapItem(rootElement.Id)
+			currentContext.ErrorHandler.ErrorHandlerFunction(Clay_ErrorData{
+				ErrorType: CLAY_ERROR_TYPE_FLOATING_CONTAINER_PARENT_NOT_FOUND,
+				ErrorText: CLAY_STRING("A floating element was declared with a parentId, but no element with that ID was found."),
+				UserData:  currentContext.ErrorHandler.UserData,
+				ElementId: Clay__GetElementIdForLayoutElement(root.LayoutElementIndex),
+				ParentIds: Clay__GetDeclarationParentIds(rootItem.ParentId),
+			})
+			continue
+		}
+		if floatingConfig.ClipTo == CLAY_CLIP_TO_ATTACHED_PARENT {
+			clipElementId := Clay__Array_GetValue(&currentContext.LayoutElementClipElementIds, parentIndex)
+			root.ClipElementId = uint32(clipElementId)
+			Clay__Array_Set(&currentContext.LayoutElementClipElementIds, root.LayoutElementIndex, clipElementId)
+		}
+	}
+}
+
 func Clay__GetOpenLayoutElement() *Clay_LayoutElement {
 	currentContext := Clay_GetCurrentContext()
 	return Clay__Array_Get[Clay_LayoutElement](&currentContext.LayoutElements, Clay__Array_GetValue[int32](&currentContext.OpenLayoutElementStack, currentContext.OpenLayoutElementStack.Length()-1))
@@ -1066,10 +1215,7 @@ func Clay__MeasureTextCached(text *Clay_String, textConfig *Clay_TextElementConf
 	if MeasureTextFunction == nil {
 		if !currentContext.BooleanWarnings.TextMeasurementFunctionNotSet {
 			currentContext.BooleanWarnings.TextMeasurementFunctionNotSet = true
-			currentContext.ErrorHandler.ErrorHandlerFunction(Clay_ErrorData{
-				ErrorType: CLAY_ERROR_TYPE_TEXT_MEASUREMENT_FUNCTION_NOT_PROVIDED,
-				ErrorText: CLAY_STRING("Clay's internal MeasureText function is null. You may have forgotten to call Clay_SetMeasureTextFunction(), or passed a NULL function pointer by mistake."),
-				UserData:  currentContext.ErrorHandler.UserData})
+			Clay__ReportError(CLAY_ERROR_TYPE_TEXT_MEASUREMENT_FUNCTION_NOT_PROVIDED, CLAY_STRING("Clay's internal MeasureText function is null. You may have forgotten to call Clay_SetMeasureTextFunction(), or passed a NULL function pointer by mistake."))
 		}
 		return &Clay__MeasureTextCacheItem_DEFAULT
 	}
@@ -1121,10 +1267,7 @@ func Clay__MeasureTextCached(text *Clay_String, textConfig *Clay_TextElementConf
 	} else {
 		if currentContext.MeasureTextHashMapInternal.Length() == currentContext.MeasureTextHashMapInternal.Capacity()-1 {
 			if !currentContext.BooleanWarnings.MaxTextMeasureCacheExceeded {
-				currentContext.ErrorHandler.ErrorHandlerFunction(Clay_ErrorData{
-					ErrorType: CLAY_ERROR_TYPE_ELEMENTS_CAPACITY_EXCEEDED,
-					ErrorText: CLAY_STRING("Clay ran out of capacity while attempting to measure text elements. Try using Clay_SetMaxElementCount() with a higher value."),
-					UserData:  currentContext.ErrorHandler.UserData})
+				Clay__ReportError(CLAY_ERROR_TYPE_ELEMENTS_CAPACITY_EXCEEDED, CLAY_STRING("Clay ran out of capacity while attempting to measure text elements. Try using Clay_SetMaxElementCount() with a higher value."))
 				currentContext.BooleanWarnings.MaxTextMeasureCacheExceeded = true
 			}
 			return &Clay__MeasureTextCacheItem_DEFAULT
@@ -1133,8 +1276,6 @@ func Clay__MeasureTextCached(text *Clay_String, textConfig *Clay_TextElementConf
 		newItemIndex = currentContext.MeasureTextHashMapInternal.Length() - 1
 	}
 
-	start := int32(0)
-	end := int32(0)
 	lineWidth := float32(0)
 	measuredWidth := float32(0)
 	measuredHeight := float32(0)
@@ -1148,91 +1289,69 @@ func Clay__MeasureTextCached(text *Clay_String, textConfig *Clay_TextElementConf
 	).Width
 	tempWord := Clay__MeasuredWord{Next: -1}
 	previousWord := &tempWord
-	for end < text.Length {
-		if currentContext.MeasuredWords.Length() == currentContext.MeasuredWords.Capacity()-1 {
+	// Each measured word runs up to the next line break opportunity, and includes any trailing spaces
+	for _, segment := range Clay__FindLineBreakSegments(*text) {
+		if currentContext.MeasuredWords.Length() >= currentContext.MeasuredWords.Capacity()-2 {
 			if !currentContext.BooleanWarnings.MaxTextMeasureCacheExceeded {
-				currentContext.ErrorHandler.ErrorHandlerFunction(Clay_ErrorData{
-					ErrorType: CLAY_ERROR_TYPE_TEXT_MEASUREMENT_CAPACITY_EXCEEDED,
-					ErrorText: CLAY_STRING("Clay has run out of space in it's internal text measurement cache. Try using Clay_SetMaxMeasureTextCacheWordCount() (default 16384, with 1 unit storing 1 measured word)."),
-					UserData:  currentContext.ErrorHandler.UserData,
-				})
+				Clay__ReportError(CLAY_ERROR_TYPE_TEXT_MEASUREMENT_CAPACITY_EXCEEDED, CLAY_STRING("Clay has run out of space in it's internal text measurement cache. Try using Clay_SetMaxMeasureTextCacheWordCount() (default 16384, with 1 unit storing 1 measured word)."))
 				currentContext.BooleanWarnings.MaxTextMeasureCacheExceeded = true
 			}
 			return &Clay__MeasureTextCacheItem_DEFAULT
 		}
-		current := text.Chars[end]
-		if current == ' ' || current == '\n' {
-			length := end - start
-			dimensions := Clay_Dimensions{}
-			if length > 0 {
-				dimensions = Clay__MeasureText(Clay_StringSlice{
-					Length:    length,
-					Chars:     text.Chars[start:],
-					BaseChars: text.Chars,
-				},
-					textConfig,
-					currentContext.MeasureTextUserData,
-				)
-			}
-			measured.MinWidth = CLAY__MAX(dimensions.Width, measured.MinWidth)
-			measuredHeight = CLAY__MAX(measuredHeight, dimensions.Height)
-			if current == ' ' {
-				dimensions.Width += spaceWidth
-				previousWord = Clay__AddMeasuredWord(Clay__MeasuredWord{
-					StartOffset: start,
-					Length:      length + 1,
-					Width:       dimensions.Width,
-					Next:        -1,
-				}, previousWord)
-				lineWidth += dimensions.Width
-			}
-			if current == '\n' {
-				if length > 0 {
-					previousWord = Clay__AddMeasuredWord(Clay__MeasuredWord{
-						StartOffset: start,
-						Length:      length,
-						Width:       dimensions.Width,
-						Next:        -1,
-					}, previousWord)
-				}
-				previousWord = Clay__AddMeasuredWord(Clay__MeasuredWord{
-					StartOffset: end + 1,
-					Length:      0,
-					Width:       0,
-					Next:        -1,
-				}, previousWord)
-				lineWidth += dimensions.Width
-				measuredWidth = CLAY__MAX(lineWidth, measuredWidth)
-				measured.ContainsNewlines = true
-				lineWidth = 0
+		wordEnd := segment.End
+		if segment.Mandatory {
+			// Leave out the newline, which is a single character apart from "\r\n"
+			_, newlineLength := utf8.DecodeLastRune(text.Chars[segment.Start:segment.End])
+			wordEnd -= int32(newlineLength)
+			if newlineLength == 1 && wordEnd > segment.Start && text.Chars[wordEnd] == '\n' && text.Chars[wordEnd-1] == '\r' {
+				wordEnd--
 			}
-			start = end + 1
 		}
-		end++
-	}
-
-	if end-start > 0 {
-		dimensions := Clay__MeasureText(Clay_StringSlice{
-			Length:    end - start,
-			Chars:     text.Chars[start:],
-			BaseChars: text.Chars,
-		},
-			textConfig,
-			currentContext.MeasureTextUserData,
-		)
-		Clay__AddMeasuredWord(Clay__MeasuredWord{
-			StartOffset: start,
-			Length:      end - start,
-			Width:       dimensions.Width,
-			Next:        -1,
-		}, previousWord)
-		lineWidth += dimensions.Width
-		measuredHeight = CLAY__MAX(measuredHeight, dimensions.Height)
+		contentEnd := wordEnd
+		for contentEnd > segment.Start && text.Chars[contentEnd-1] == ' ' {
+			contentEnd--
+		}
+		dimensions := Clay_Dimensions{}
+		if contentEnd > segment.Start {
+			metrics := Clay__MeasureTextMetrics(Clay_StringSlice{
+				Length:    contentEnd - segment.Start,
+				Chars:     text.Chars[segment.Start:],
+				BaseChars: text.Chars,
+			},
+				textConfig,
+				currentContext.MeasureTextUserData,
+			)
+			dimensions = metrics.Dimensions
+			measured.Ascent = CLAY__MAX(measured.Ascent, metrics.Ascent)
+		}
 		measured.MinWidth = CLAY__MAX(dimensions.Width, measured.MinWidth)
+		measuredHeight = CLAY__MAX(measuredHeight, dimensions.Height)
+		dimensions.Width += float32(wordEnd-contentEnd) * spaceWidth
+		if wordEnd > segment.Start {
+			previousWord = Clay__AddMeasuredWord(Clay__MeasuredWord{
+				StartOffset: segment.Start,
+				Length:      wordEnd - segment.Start,
+				Width:       dimensions.Width,
+				Next:        -1,
+			}, previousWord)
+			lineWidth += dimensions.Width
+		}
+		if segment.Mandatory {
+			previousWord = Clay__AddMeasuredWord(Clay__MeasuredWord{
+				StartOffset: segment.End,
+				Length:      0,
+				Width:       0,
+				Next:        -1,
+			}, previousWord)
+			measuredWidth = CLAY__MAX(lineWidth, measuredWidth)
+			measured.ContainsNewlines = true
+			lineWidth = 0
+		}
 	}
 	measuredWidth = CLAY__MAX(lineWidth, measuredWidth) - float32(textConfig.LetterSpacing)
 
 	measured.MeasuredWordsStartIndex = tempWord.Next
+	measured.SpaceWidth = spaceWidth
 	measured.UnwrappedDimensions.Width = measuredWidth
 	measured.UnwrappedDimensions.Height = measuredHeight
 
@@ -1260,63 +1379,24 @@ func Clay__AddMeasuredWord(word Clay__MeasuredWord, previousWord *Clay__Measured
 }
 
 func Clay__OpenTextElement(text Clay_String, textConfig *Clay_TextElementConfig) {
-	currentContext := Clay_GetCurrentContext()
-	if currentContext.LayoutElements.Length() == currentContext.LayoutElements.Capacity()-1 || currentContext.BooleanWarnings.MaxElementsExceeded {
-		currentContext.BooleanWarnings.MaxElementsExceeded = true
-		return
-	}
-	parentElement := Clay__GetOpenLayoutElement()
-
-	layoutElement := Clay_LayoutElement{}
-
-	textElement := Clay__Array_Add[Clay_LayoutElement](&currentContext.LayoutElements, layoutElement)
+	Clay__OpenTextElementWithId(Clay_ElementId{}, text, textConfig)
+}
 
-	if currentContext.OpenClipElementStack.Length() > 0 {
+// Adds a text element with the provided id, or an id generated from its position within its parent if the id is zero.
+func Clay__OpenTextElementWithId(elementId Clay_ElementId, text Clay_String, textConfig *Clay_TextElementConfig) {
+	Clay__AddMeasuredTextElement(elementId, textConfig, Clay__TextElementData{Text: text})
+}
 
-		if currentContext.LayoutElementClipElementIds.Length() != currentContext.LayoutElements.Length() {
-			panic("LayoutElementClipElementIds length does not match LayoutElements length")
-		}
-		if currentContext.LayoutElementClipElementIds.Length() == 0 {
-			Clay__Array_Add(
-				&currentContext.LayoutElementClipElementIds,
-				Clay__Array_GetValue[int32](&currentContext.OpenClipElementStack, currentContext.OpenClipElementStack.Length()-1),
-			)
-		} else {
-			Clay__Array_Set(
-				&currentContext.LayoutElementClipElementIds,
-				currentContext.LayoutElements.Length()-1,
-				Clay__Array_GetValue[int32](&currentContext.OpenClipElementStack, currentContext.OpenClipElementStack.Length()-1),
-			)
-		}
-	} else {
-		if currentContext.LayoutElementClipElementIds.Length() != currentContext.LayoutElements.Length()-1 {
-			fmt.Printf("LayoutElementClipElementIds length : %d, LayoutElements length : %d\n", currentContext.LayoutElementClipElementIds.Length(), currentContext.LayoutElements.Length())
-			panic("LayoutElementClipElementIds length does not match LayoutElements length")
-		}
-		if currentContext.LayoutElementClipElementIds.Length() == 0 {
-			Clay__Array_Add(&currentContext.LayoutElementClipElementIds, 0)
-		} else {
-			if currentContext.LayoutElementClipElementIds.Length() == currentContext.LayoutElements.Length()-1 {
-				Clay__Array_Add(&currentContext.LayoutElementClipElementIds, 0)
-			} else if currentContext.LayoutElementClipElementIds.Length() == currentContext.LayoutElements.Length() {
-				Clay__Array_Set(&currentContext.LayoutElementClipElementIds, currentContext.LayoutElements.Length()-1, 0)
-			} else {
-				panic("LayoutElementClipElementIds length does not match LayoutElements length")
-			}
-		}
+// Adds a text element like Clay__AddTextLayoutElement, and sizes it to fit its measured text.
+func Clay__AddMeasuredTextElement(elementId Clay_ElementId, textConfig *Clay_TextElementConfig, textElementData Clay__TextElementData) *Clay_LayoutElement {
+	textElement := Clay__AddTextLayoutElement(elementId, textConfig, textElementData)
+	if textElement == nil {
+		return nil
 	}
 
-	Clay__Array_Add(&currentContext.LayoutElementChildrenBuffer, currentContext.LayoutElements.Length()-1)
-
+	text := textElementData.Text
 	textMeasured := Clay__MeasureTextCached(&text, textConfig)
 
-	elementId := Clay__HashNumber(uint32(parentElement.ChildrenOrTextContent.Children.Length), parentElement.Id)
-
-	textElement.Id = elementId.Id
-
-	Clay__AddHashMapItem(elementId, textElement)
-	Clay__Array_Add(&currentContext.LayoutElementIdStrings, elementId.StringId)
-
 	// Clay_Dimensions textDimensions = { .width = textMeasured->unwrappedDimensions.width, .height = textConfig->lineHeight > 0 ? (float)textConfig->lineHeight : textMeasured->unwrappedDimensions.height };
 
 	textDimensions := Clay_Dimensions{
@@ -1334,12 +1414,52 @@ func Clay__OpenTextElement(text Clay_String, textConfig *Clay_TextElementConfig)
 		Width:  textMeasured.MinWidth,
 		Height: textDimensions.Height,
 	}
+	// Truncated text can shrink until only the ellipsis is left
+	if textConfig.Overflow != CLAY_TEXT_OVERFLOW_CLIP {
+		textElement.MinDimensions.Width = CLAY__MIN(textElement.MinDimensions.Width, Clay__MeasureTextCached(&CLAY__ELLIPSIS, textConfig).UnwrappedDimensions.Width)
+	}
 
-	textElement.ChildrenOrTextContent.TextElementData = Clay__Array_Add(&currentContext.TextElementData, Clay__TextElementData{
-		Text:                text,
-		PreferredDimensions: textMeasured.UnwrappedDimensions,
-		ElementIndex:        currentContext.LayoutElements.Length() - 1,
-	})
+	textElement.ChildrenOrTextContent.TextElementData.PreferredDimensions = textMeasured.UnwrappedDimensions
+	textElement.ChildrenOrTextContent.TextElementData.Ascent = textMeasured.Ascent
+	return textElement
+}
+
+// Adds a text layout element with the provided id, config and text data as a child of the open element. A zero id is
+// replaced by one generated from the position of the element within its parent.
+// The dimensions of the element are left to the caller. Returns nil if the maximum element count has been reached.
+func Clay__AddTextLayoutElement(elementId Clay_ElementId, textConfig *Clay_TextElementConfig, textElementData Clay__TextElementData) *Clay_LayoutElement {
+	currentContext := Clay_GetCurrentContext()
+	if currentContext.LayoutElements.Length() == currentContext.LayoutElements.Capacity()-1 || currentContext.BooleanWarnings.MaxElementsExceeded {
+		currentContext.BooleanWarnings.MaxElementsExceeded = true
+		return nil
+	}
+	parentElement := Clay__GetOpenLayoutElement()
+
+	layoutElement := Clay_LayoutElement{}
+
+	textElement := Clay__Array_Add[Clay_LayoutElement](&currentContext.LayoutElements, layoutElement)
+	Clay__SetOpenElementClipElementId(currentContext.LayoutElements.Length() - 1)
+
+	Clay__Array_Add(&currentContext.LayoutElementChildrenBuffer, currentContext.LayoutElements.Length()-1)
+
+	if elementId.Id == 0 {
+		// Floating children are included in the offset to match Clay__GenerateIdForAnonymousElement, otherwise a text element
+		// following an anonymous floating sibling would be given the same ID.
+		elementId = Clay__HashNumber(uint32(parentElement.ChildrenOrTextContent.Children.Length+parentElement.FloatingChildrenCount), parentElement.Id)
+	} else if existingItem := Clay__GetHashMapItem(elementId.Id); existingItem != &Clay_LayoutElementHashMapItem_DEFAULT && existingItem.Generation > currentContext.Generation {
+		Clay__ReportDuplicateId(existingItem, elementId)
+		if currentContext.DisambiguateDuplicateIds {
+			elementId = Clay__DisambiguateElementId(elementId)
+		}
+	}
+
+	textElement.Id = elementId.Id
+
+	Clay__AddHashMapItem(elementId, textElement)
+	Clay__Array_Add(&currentContext.LayoutElementIdStrings, elementId.StringId)
+
+	textElementData.ElementIndex = currentContext.LayoutElements.Length() - 1
+	textElement.ChildrenOrTextContent.TextElementData = Clay__Array_Add(&currentContext.TextElementData, textElementData)
 
 	// add config to element configs
 
@@ -1354,8 +1474,12 @@ func Clay__OpenTextElement(text Clay_String, textConfig *Clay_TextElementConfig)
 	} else {
 		panic("config is nil")
 	}
-	textElement.LayoutConfig = &Clay_LayoutConfig{}
+	textElement.LayoutConfig = &Clay_LayoutConfig_DEFAULT
+	if currentContext.IncrementalLayout != nil {
+		textElement.DeclarationHash = Clay__HashElementDeclaration(textElement)
+	}
 	parentElement.ChildrenOrTextContent.Children.Length++
+	return textElement
 }
 
 func Clay__InitializePersistentMemory(context *Clay_Context) {
@@ -1365,9 +1489,11 @@ func Clay__InitializePersistentMemory(context *Clay_Context) {
 	arena := &context.InternalArena
 
 	context.ScrollContainerDatas = Clay__Array_Allocate_Arena[Clay__ScrollContainerDataInternal](100, arena)
+	context.TransitionDatas = Clay__Array_Allocate_Arena[Clay__TransitionDataInternal](maxElementCount, arena)
 	context.LayoutElementsHashMapInternal = Clay__Array_Allocate_Arena[Clay_LayoutElementHashMapItem](maxElementCount, arena, mem.MemArrayWithZeroValuePtr[Clay_LayoutElementHashMapItem](&Clay_LayoutElementHashMapItem_DEFAULT))
 	context.LayoutElementsHashMap = Clay__Array_Allocate_Arena[int32](maxElementCount, arena, mem.MemArrayWithIsHashmap[int32](), mem.MemArrayWithZeroValue[int32](-1))
 	context.MeasureTextHashMapInternal = Clay__Array_Allocate_Arena[Clay__MeasureTextCacheItem](maxElementCount, arena, mem.MemArrayWithZeroValuePtr[Clay__MeasureTextCacheItem](&Clay__MeasureTextCacheItem_DEFAULT))
+	Clay__Array_Add(&context.MeasureTextHashMapInternal, Clay__MeasureTextCacheItem{}) // Reserve the 0 index to mean "no next element"
 	context.MeasureTextHashMapInternalFreeList = Clay__Array_Allocate_Arena[int32](maxElementCount, arena)
 	context.MeasuredWordsFreeList = Clay__Array_Allocate_Arena[int32](maxMeasureTextCacheWordCount, arena)
 	context.MeasureTextHashMap = Clay__Array_Allocate_Arena[int32](maxElementCount, arena, mem.MemArrayWithIsHashmap[int32](), mem.MemArrayWithZeroValue[int32](0))
@@ -1383,36 +1509,48 @@ func Clay__InitializeEphemeralMemory(context *Clay_Context) {
 	arena := &context.InternalArena
 	arena.NextAllocation = context.ArenaResetOffset
 
-	context.LayoutElementChildrenBuffer = Clay__Array_Allocate_Arena[int32](maxElementCount, arena)
-	context.LayoutElements = Clay__Array_Allocate_Arena[Clay_LayoutElement](maxElementCount, arena)
-	context.Warnings = Clay__Array_Allocate_Arena[Clay__Warning](100, arena)
-
-	context.LayoutConfigs = Clay__Array_Allocate_Arena[Clay_LayoutConfig](maxElementCount, arena)
-	context.ElementConfigs = Clay__Array_Allocate_Arena[Clay_ElementConfig](maxElementCount, arena)
-	context.TextElementConfigs = Clay__Array_Allocate_Arena[Clay_TextElementConfig](maxElementCount, arena)
-	context.AspectRatioElementConfigs = Clay__Array_Allocate_Arena[Clay_AspectRatioElementConfig](maxElementCount, arena)
-	context.ImageElementConfigs = Clay__Array_Allocate_Arena[Clay_ImageElementConfig](maxElementCount, arena)
-	context.FloatingElementConfigs = Clay__Array_Allocate_Arena[Clay_FloatingElementConfig](maxElementCount, arena)
-	context.ClipElementConfigs = Clay__Array_Allocate_Arena[Clay_ClipElementConfig](maxElementCount, arena)
-	context.CustomElementConfigs = Clay__Array_Allocate_Arena[Clay_CustomElementConfig](maxElementCount, arena)
-	context.BorderElementConfigs = Clay__Array_Allocate_Arena[Clay_BorderElementConfig](maxElementCount, arena)
-	context.SharedElementConfigs = Clay__Array_Allocate_Arena[Clay_SharedElementConfig](maxElementCount, arena)
-
-	context.LayoutElementIdStrings = Clay__Array_Allocate_Arena[Clay_String](maxElementCount, arena)
-	context.WrappedTextLines = Clay__Array_Allocate_Arena[Clay__WrappedTextLine](maxElementCount, arena)
-	context.LayoutElementTreeNodeArray1 = Clay__Array_Allocate_Arena[Clay__LayoutElementTreeNode](maxElementCount, arena)
-	context.LayoutElementTreeRoots = Clay__Array_Allocate_Arena[Clay__LayoutElementTreeRoot](maxElementCount, arena)
-	context.LayoutElementChildren = Clay__Array_Allocate_Arena[int32](maxElementCount, arena)
-	context.OpenLayoutElementStack = Clay__Array_Allocate_Arena[int32](maxElementCount, arena)
-	context.TextElementData = Clay__Array_Allocate_Arena[Clay__TextElementData](maxElementCount, arena)
-	context.AspectRatioElementIndexes = Clay__Array_Allocate_Arena[int32](maxElementCount, arena)
-	context.RenderCommands = Clay__Array_Allocate_Arena[Clay_RenderCommand](maxElementCount, arena)
-	context.TreeNodeVisited = Clay__Array_Allocate_Arena[bool](maxElementCount, arena, mem.MemArrayWithInitialLength[bool](maxElementCount))
+	Clay__Array_Reallocate_Arena(&context.LayoutElementChildrenBuffer, maxElementCount, arena)
+	Clay__Array_Reallocate_Arena(&context.LayoutElements, maxElementCount, arena)
+	Clay__Array_Reallocate_Arena(&context.Warnings, 100, arena)
+
+	Clay__Array_Reallocate_Arena(&context.LayoutConfigs, maxElementCount, arena)
+	Clay__Array_Reallocate_Arena(&context.ElementConfigs, maxElementCount, arena)
+	Clay__Array_Reallocate_Arena(&context.TextElementConfigs, maxElementCount, arena)
+	Clay__Array_Reallocate_Arena(&context.AspectRatioElementConfigs, maxElementCount, arena)
+	Clay__Array_Reallocate_Arena(&context.ImageElementConfigs, maxElementCount, arena)
+	Clay__Array_Reallocate_Arena(&context.FloatingElementConfigs, maxElementCount, arena)
+	Clay__Array_Reallocate_Arena(&context.ClipElementConfigs, maxElementCount, arena)
+	Clay__Array_Reallocate_Arena(&context.CustomElementConfigs, maxElementCount, arena)
+	Clay__Array_Reallocate_Arena(&context.BorderElementConfigs, maxElementCount, arena)
+	Clay__Array_Reallocate_Arena(&context.SharedElementConfigs, maxElementCount, arena)
+	Clay__Array_Reallocate_Arena(&context.TransitionElementConfigs, maxElementCount, arena)
+	Clay__Array_Reallocate_Arena(&context.TransformElementConfigs, maxElementCount, arena)
+	Clay__Array_Reallocate_Arena(&context.ShadowElementConfigs, maxElementCount, arena)
+
+	Clay__Array_Reallocate_Arena(&context.LayoutElementIdStrings, maxElementCount, arena)
+	Clay__Array_Reallocate_Arena(&context.WrappedTextLines, maxElementCount, arena)
+	Clay__Array_Reallocate_Arena(&context.WrappedTextFragments, maxElementCount, arena)
+	Clay__Array_Reallocate_Arena(&context.LayoutElementTreeNodeArray1, maxElementCount, arena)
+	Clay__Array_Reallocate_Arena(&context.LayoutElementTreeRoots, maxElementCount, arena)
+	Clay__Array_Reallocate_Arena(&context.LayoutElementTreeRootsSortBuffer, maxElementCount, arena)
+	Clay__Array_Reallocate_Arena(&context.ElementConfigSortBuffer, maxElementCount, arena)
+	Clay__Array_Reallocate_Arena(&context.LayoutElementChildren, maxElementCount, arena)
+	Clay__Array_Reallocate_Arena(&context.OpenLayoutElementStack, maxElementCount, arena)
+	Clay__Array_Reallocate_Arena(&context.TextElementData, maxElementCount, arena)
+	Clay__Array_Reallocate_Arena(&context.AspectRatioElementIndexes, maxElementCount, arena)
+	Clay__Array_Reallocate_Arena(&context.RenderCommands, maxElementCount, arena)
+	if context.TreeNodeVisited.Capacity() != maxElementCount {
+		context.TreeNodeVisited = Clay__Array_Allocate_Arena[bool](maxElementCount, arena, mem.MemArrayWithInitialLength[bool](maxElementCount))
+	}
 	// context.TreeNodeVisited.Length() = context.TreeNodeVisited.Capacity // This array is accessed directly rather than behaving as a list
-	context.OpenClipElementStack = Clay__Array_Allocate_Arena[int32](maxElementCount, arena)
-	context.ReusableElementIndexBuffer = Clay__Array_Allocate_Arena[int32](maxElementCount, arena)
-	context.LayoutElementClipElementIds = Clay__Array_Allocate_Arena[int32](maxElementCount, arena)
-	context.DynamicStringData = Clay__Array_Allocate_Arena[byte](maxElementCount, arena)
+	Clay__Array_Reallocate_Arena(&context.OpenClipElementStack, maxElementCount, arena)
+	Clay__Array_Reallocate_Arena(&context.ReusableElementIndexBuffer, maxElementCount, arena)
+	Clay__Array_Reallocate_Arena(&context.LayoutElementClipElementIds, maxElementCount, arena)
+	Clay__ResetDynamicStringData(context, arena)
+	Clay__Array_Reallocate_Arena(&context.GridLayoutDatas, maxElementCount, arena)
+	Clay__Array_Reallocate_Arena(&context.GridItemPlacements, maxElementCount, arena)
+	Clay__Array_Reallocate_Arena(&context.GridTrackSizes, maxElementCount, arena)
+	Clay__Array_Reallocate_Arena(&context.TransitionElements, maxElementCount, arena)
 
 }
 
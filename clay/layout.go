@@ -12,6 +12,14 @@ type Clay_Padding struct {
 	Bottom uint16
 }
 
+// Controls the space in pixels outside an element's edges, separating it from its siblings and the parent's padding.
+type Clay_Margin struct {
+	Left   uint16
+	Right  uint16
+	Top    uint16
+	Bottom uint16
+}
+
 type Clay_LayoutDirection uint8
 
 const (
@@ -19,6 +27,12 @@ const (
 	CLAY_LEFT_TO_RIGHT Clay_LayoutDirection = iota
 	// Lays out child elements from top to bottom with increasing y.
 	CLAY_TOP_TO_BOTTOM
+	// Lays out child elements from right to left with decreasing x, so the first child is rightmost.
+	// ChildAlignment and Padding still refer to the physical left and right sides. See Clay_SetRTLEnabled to mirror those too.
+	CLAY_RIGHT_TO_LEFT
+	// Lays out child elements from bottom to top with decreasing y, so the first child is lowest.
+	// ChildAlignment and Padding still refer to the physical top and bottom sides.
+	CLAY_BOTTOM_TO_TOP
 )
 
 // Controls the alignment along the x axis (horizontal) of child elements.
@@ -31,6 +45,15 @@ const (
 	CLAY_ALIGN_X_RIGHT
 	// Aligns child elements horizontally to the center of this element
 	CLAY_ALIGN_X_CENTER
+	// Distributes the remaining horizontal space between child elements, with the first and last child touching the padding.
+	// Only applies along the layout axis (CLAY_LEFT_TO_RIGHT), and aligns to the left otherwise.
+	CLAY_ALIGN_X_SPACE_BETWEEN
+	// Distributes the remaining horizontal space around child elements, with half sized spaces before the first and after the last child.
+	// Only applies along the layout axis (CLAY_LEFT_TO_RIGHT), and aligns to the left otherwise.
+	CLAY_ALIGN_X_SPACE_AROUND
+	// Distributes the remaining horizontal space evenly before, between and after child elements.
+	// Only applies along the layout axis (CLAY_LEFT_TO_RIGHT), and aligns to the left otherwise.
+	CLAY_ALIGN_X_SPACE_EVENLY
 )
 
 // Controls the alignment along the y axis (vertical) of child elements.
@@ -43,6 +66,46 @@ const (
 	CLAY_ALIGN_Y_BOTTOM
 	// Aligns child elements vertically to the center of this element
 	CLAY_ALIGN_Y_CENTER
+	// Distributes the remaining vertical space between child elements, with the first and last child touching the padding.
+	// Only applies along the layout axis (CLAY_TOP_TO_BOTTOM), and aligns to the top otherwise.
+	CLAY_ALIGN_Y_SPACE_BETWEEN
+	// Distributes the remaining vertical space around child elements, with half sized spaces before the first and after the last child.
+	// Only applies along the layout axis (CLAY_TOP_TO_BOTTOM), and aligns to the top otherwise.
+	CLAY_ALIGN_Y_SPACE_AROUND
+	// Distributes the remaining vertical space evenly before, between and after child elements.
+	// Only applies along the layout axis (CLAY_TOP_TO_BOTTOM), and aligns to the top otherwise.
+	CLAY_ALIGN_Y_SPACE_EVENLY
+	// Aligns the first text baseline of child elements with each other. Only applies across the layout axis of
+	// CLAY_LEFT_TO_RIGHT elements that don't wrap, and aligns to the top otherwise. See Clay_SetMeasureTextMetricsFunction.
+	CLAY_ALIGN_Y_BASELINE
+)
+
+// Overrides the alignment of a single element along the non layout axis of its parent.
+type Clay_LayoutAlignSelf uint8
+
+const (
+	// (Default) Uses the ChildAlignment of the parent element.
+	CLAY_ALIGN_SELF_AUTO Clay_LayoutAlignSelf = iota
+	// Aligns the element to the left (CLAY_TOP_TO_BOTTOM parents) or top (CLAY_LEFT_TO_RIGHT parents) of its parent.
+	CLAY_ALIGN_SELF_START
+	// Aligns the element to the center of its parent.
+	CLAY_ALIGN_SELF_CENTER
+	// Aligns the element to the right (CLAY_TOP_TO_BOTTOM parents) or bottom (CLAY_LEFT_TO_RIGHT parents) of its parent.
+	CLAY_ALIGN_SELF_END
+	// Aligns the first text baseline of the element with the other baseline aligned children of a CLAY_LEFT_TO_RIGHT parent.
+	// Behaves like CLAY_ALIGN_SELF_START in CLAY_TOP_TO_BOTTOM parents.
+	CLAY_ALIGN_SELF_BASELINE
+)
+
+// Controls whether child elements that don't fit along the layout axis wrap onto new lines.
+type Clay_LayoutWrap uint8
+
+const (
+	// (Default) Child elements are laid out on a single line, and are compressed or overflow if there isn't enough space.
+	CLAY_WRAP_NONE Clay_LayoutWrap = iota
+	// Child elements that would overflow the layout axis are moved onto a new line, stacked along the non layout axis.
+	// Each line is sized independently, so GROW children expand to fill the remaining space in their own line.
+	CLAY_WRAP_WRAP
 )
 
 // Controls how child elements are aligned on each axis.
@@ -54,11 +117,60 @@ type Clay_ChildAlignment struct {
 // Controls various settings that affect the size and position of an element, as well as the sizes and positions
 // of any child elements.
 type Clay_LayoutConfig struct {
-	Sizing          Clay_Sizing
-	Padding         Clay_Padding
+	Sizing  Clay_Sizing
+	Padding Clay_Padding
+	// Space around this element inside its parent, added to the parent's FIT size and to the offset of the element.
+	// Margins are not part of the element's bounding box, and are ignored for floating elements.
+	Margin Clay_Margin
+	// The gap in pixels between child elements. When ChildAlignment distributes space along the layout axis,
+	// the distributed space is added on top of this gap.
 	ChildGap        uint16
 	ChildAlignment  Clay_ChildAlignment
 	LayoutDirection Clay_LayoutDirection
+	// Controls whether child elements wrap onto multiple lines when they don't fit along the layout axis.
+	// With CLAY_TOP_TO_BOTTOM, FIT containers are widened to their columns once the heights of children are final.
+	Wrap Clay_LayoutWrap
+	// The gap in pixels between wrapped lines, along the non layout axis. Only used with CLAY_WRAP_WRAP.
+	LineGap uint16
+	// Lays child elements out in rows and columns when at least one column is defined.
+	Grid Clay_GridLayoutConfig
+	// Controls which cells this element occupies when its parent is laid out as a grid.
+	GridItem Clay_GridItemConfig
+	// Overrides the ChildAlignment of the parent for this element, along the parent's non layout axis.
+	// Not used for children of grids.
+	AlignSelf Clay_LayoutAlignSelf
+}
+
+// Controls the size of a single column or row of a grid.
+type Clay_GridTrack struct {
+	// FIT tracks wrap to the largest child in the track, GROW tracks share the space remaining in the grid,
+	// PERCENT tracks take a percent of the grid's size minus padding and gaps, and FIXED tracks have an exact size.
+	// Sizing.MinMax clamps the size of FIT and GROW tracks, and Sizing.GrowWeight sets the share of the remaining space
+	// a GROW track receives relative to the other GROW tracks.
+	Sizing Clay_SizingAxis
+}
+
+// Controls the columns and rows of an element laid out as a grid. When Columns is set, the element's LayoutDirection,
+// ChildGap and Wrap are ignored. Grids don't compress their tracks, content larger than the grid overflows it.
+type Clay_GridLayoutConfig struct {
+	// The column tracks of the grid.
+	// Note: the slice is not copied and must stay alive until Clay_EndLayout has been called.
+	Columns []Clay_GridTrack
+	// The row tracks of the grid. Rows needed to place every child that aren't defined here are sized as FIT.
+	Rows []Clay_GridTrack
+	// The gap in pixels between columns.
+	ColumnGap uint16
+	// The gap in pixels between rows.
+	RowGap uint16
+}
+
+// Controls the cells an element occupies inside a grid. Elements without a Column or Row are placed automatically
+// in the next free cells, row by row in declaration order.
+type Clay_GridItemConfig struct {
+	Column     uint16 // The 1-based column the element starts in, or 0 to place it automatically.
+	Row        uint16 // The 1-based row the element starts in, or 0 to place it automatically.
+	ColumnSpan uint16 // The number of columns the element spans. 0 is treated as 1.
+	RowSpan    uint16 // The number of rows the element spans. 0 is treated as 1.
 }
 
 // Controls the sizing of this element along one axis inside its parent container.
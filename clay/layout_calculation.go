@@ -6,6 +6,7 @@ import (
 
 func Clay__CalculateFinalLayout() {
 	currentContext := Clay_GetCurrentContext()
+	Clay__BeginIncrementalLayout()
 	// Calculate sizing along the X axis
 	Clay__SizeContainersAlongAxis(true)
 
@@ -19,15 +20,26 @@ func Clay__CalculateFinalLayout() {
 		wrappedLines := NewClay__Slice[Clay__WrappedTextLine](wrappedLinesData)
 		textElementData.WrappedLines = wrappedLines
 		containerElement := Clay__Array_Get(&currentContext.LayoutElements, textElementData.ElementIndex)
+		// Text in unchanged subtrees is wrapped like it was during the last layout, see Clay_SetIncrementalLayoutEnabled
+		if Clay__RestoreCachedWrappedLines(textElementData, containerElement) {
+			continue
+		}
 		textConfig := Clay__FindElementConfigWithType(containerElement, CLAY__ELEMENT_CONFIG_TYPE_TEXT).TextElementConfig
-		measureTextCacheItem := Clay__MeasureTextCached(&textElementData.Text, textConfig)
-		var lineWidth float32 = 0
 		var lineHeight float32 = 0
 		if textConfig.LineHeight > 0 {
 			lineHeight = float32(textConfig.LineHeight)
 		} else {
 			lineHeight = textElementData.PreferredDimensions.Height
 		}
+		if len(textElementData.Spans) > 0 {
+			Clay__WrapRichText(textElementData.Spans, containerElement.Dimensions.Width, lineHeight, &textElementData.WrappedLines)
+			Clay__HyphenateWrappedTextLines(textElementData, textConfig)
+			Clay__TruncateWrappedTextLines(textElementData, textConfig, containerElement.Dimensions.Width)
+			containerElement.Dimensions.Height = lineHeight * float32(textElementData.WrappedLines.Length())
+			continue
+		}
+		measureTextCacheItem := Clay__MeasureTextCached(&textElementData.Text, textConfig)
+		var lineWidth float32 = 0
 		var lineLengthChars int32 = 0
 		var lineStartOffset int32 = 0
 
@@ -61,6 +73,7 @@ func Clay__CalculateFinalLayout() {
 						Length: measuredWord.Length,
 						Chars:  textElementData.Text.Chars[measuredWord.StartOffset : measuredWord.StartOffset+measuredWord.Length],
 					},
+					StartOffset: measuredWord.StartOffset,
 				},
 				)
 				Clay__Slice_Grow(&textElementData.WrappedLines, 1)
@@ -95,8 +108,9 @@ func Clay__CalculateFinalLayout() {
 				}
 				Clay__Array_Add(&currentContext.WrappedTextLines,
 					Clay__WrappedTextLine{
-						Dimensions: Dimensions,
-						Line:       Line,
+						Dimensions:  Dimensions,
+						Line:        Line,
+						StartOffset: lineStartOffset,
 					},
 				)
 
@@ -123,9 +137,15 @@ func Clay__CalculateFinalLayout() {
 					Length: lineLengthChars,
 					Chars:  textElementData.Text.Chars[lineStartOffset : lineStartOffset+lineLengthChars],
 				},
+				StartOffset: lineStartOffset,
 			})
 			Clay__Slice_Grow(&textElementData.WrappedLines, 1)
 		}
+		if textElementData.Input {
+			Clay__AddTextInputTrailingLine(textElementData, lineHeight)
+		}
+		Clay__HyphenateWrappedTextLines(textElementData, textConfig)
+		Clay__TruncateWrappedTextLines(textElementData, textConfig, containerElement.Dimensions.Width)
 
 		containerElement.Dimensions.Height = lineHeight * float32(textElementData.WrappedLines.Length())
 	}
@@ -161,8 +181,14 @@ func Clay__CalculateFinalLayout() {
 		currentElement := currentElementTreeNode.LayoutElement
 		if !Clay__Array_GetValue(&currentContext.TreeNodeVisited, dfsBuffer.Length()-1) {
 			Clay__Array_Set(&currentContext.TreeNodeVisited, dfsBuffer.Length()-1, true)
+			// Unchanged subtrees get the heights of the last layout back
+			if Clay__RestoreCachedContentHeights(currentElement) {
+				Clay__Array_Shrink(&dfsBuffer, 1)
+				continue
+			}
 			// If the element has no children or is the container for a text element, don't bother inspecting it
 			if Clay__ElementHasConfig(currentElement, CLAY__ELEMENT_CONFIG_TYPE_TEXT) || currentElement.ChildrenOrTextContent.Children.Length == 0 {
+				currentElement.ContentHeight = currentElement.Dimensions.Height
 				Clay__Array_Shrink(&dfsBuffer, 1)
 				continue
 			}
@@ -191,28 +217,50 @@ func Clay__CalculateFinalLayout() {
 
 		// DFS node has been visited, this is on the way back up to the root
 		layoutConfig := currentElement.LayoutConfig
-		if layoutConfig.LayoutDirection == CLAY_LEFT_TO_RIGHT {
+		if gridData := Clay__GetGridLayoutData(currentElement); gridData != nil {
+			// Rows are sized to their content again, as wrapped text may have changed the height of children
+			Clay__SizeGridTracks(currentElement, gridData, false, -1, false)
+			contentHeight := Clay__GridContentSize(currentElement, gridData, false) + float32(layoutConfig.Padding.Top+layoutConfig.Padding.Bottom)
+			currentElement.Dimensions.Height = CLAY__MIN(CLAY__MAX(CLAY__MAX(contentHeight, currentElement.Dimensions.Height), layoutConfig.Sizing.Height.Size.MinMax.Min), layoutConfig.Sizing.Height.Size.MinMax.Max)
+		} else if Clay__ElementWrapsChildren(currentElement) && Clay__LayoutDirectionIsHorizontal(layoutConfig.LayoutDirection) {
+			// Wrapped lines stack vertically, so the height is the sum of all lines rather than the tallest child
+			_, contentHeight, _ := Clay__WrappedContentSize(currentElement)
+			contentHeight += float32(layoutConfig.Padding.Top + layoutConfig.Padding.Bottom)
+			currentElement.Dimensions.Height = CLAY__MIN(CLAY__MAX(CLAY__MAX(contentHeight, currentElement.Dimensions.Height), layoutConfig.Sizing.Height.Size.MinMax.Min), layoutConfig.Sizing.Height.Size.MinMax.Max)
+		} else if Clay__LayoutDirectionIsHorizontal(layoutConfig.LayoutDirection) {
 			// Resize any parent containers that have grown in height along their non layout axis
 			for childIndex := int32(0); childIndex < int32(currentElement.ChildrenOrTextContent.Children.Length); childIndex++ {
 				childElement := Clay__Array_Get(&currentContext.LayoutElements, currentElement.ChildrenOrTextContent.Children.Elements[childIndex])
-				childHeightWithPadding := CLAY__MAX(childElement.Dimensions.Height+float32(layoutConfig.Padding.Top)+float32(layoutConfig.Padding.Bottom), currentElement.Dimensions.Height)
+				childHeightWithPadding := CLAY__MAX(Clay__OuterSizeAlongAxis(childElement, false)+float32(layoutConfig.Padding.Top)+float32(layoutConfig.Padding.Bottom), currentElement.Dimensions.Height)
 				currentElement.Dimensions.Height = CLAY__MIN(CLAY__MAX(childHeightWithPadding, layoutConfig.Sizing.Height.Size.MinMax.Min), layoutConfig.Sizing.Height.Size.MinMax.Max)
 			}
-		} else if layoutConfig.LayoutDirection == CLAY_TOP_TO_BOTTOM {
+			// Children aligned on their baselines can extend further than the tallest child
+			if baseline, descent, found := Clay__GetChildrenBaseline(currentElement); found {
+				baselineHeightWithPadding := CLAY__MAX(baseline+descent+float32(layoutConfig.Padding.Top)+float32(layoutConfig.Padding.Bottom), currentElement.Dimensions.Height)
+				currentElement.Dimensions.Height = CLAY__MIN(CLAY__MAX(baselineHeightWithPadding, layoutConfig.Sizing.Height.Size.MinMax.Min), layoutConfig.Sizing.Height.Size.MinMax.Max)
+			}
+		} else {
 			// Resizing along the layout axis
 			contentHeight := float32(layoutConfig.Padding.Top + layoutConfig.Padding.Bottom)
 			for childIndex := int32(0); childIndex < int32(currentElement.ChildrenOrTextContent.Children.Length); childIndex++ {
 				childElement := Clay__Array_Get(&currentContext.LayoutElements, currentElement.ChildrenOrTextContent.Children.Elements[childIndex])
-				contentHeight += childElement.Dimensions.Height
+				contentHeight += Clay__OuterSizeAlongAxis(childElement, false)
 			}
 			contentHeight += float32(CLAY__MAX(int32(currentElement.ChildrenOrTextContent.Children.Length)-1, 0) * int32(layoutConfig.ChildGap))
 			currentElement.Dimensions.Height = CLAY__MIN(CLAY__MAX(contentHeight, layoutConfig.Sizing.Height.Size.MinMax.Min), layoutConfig.Sizing.Height.Size.MinMax.Max)
 		}
+		currentElement.ContentHeight = currentElement.Dimensions.Height
 	}
 
 	// Calculate sizing along the Y axis
 	Clay__SizeContainersAlongAxis(false)
 
+	// Columns of CLAY_TOP_TO_BOTTOM wrapping are only known now that heights are final
+	for rootIndex := int32(0); rootIndex < currentContext.LayoutElementTreeRoots.Length(); rootIndex++ {
+		root := Clay__Array_Get(&currentContext.LayoutElementTreeRoots, rootIndex)
+		Clay__FitWrappedColumnWidths(Clay__Array_Get(&currentContext.LayoutElements, root.LayoutElementIndex))
+	}
+
 	// Scale horizontal widths according to aspect ratio
 	for aspectRatioElementIndex := int32(0); aspectRatioElementIndex < currentContext.AspectRatioElementIndexes.Length(); aspectRatioElementIndex++ {
 		aspectElement := Clay__Array_Get(&currentContext.LayoutElements, Clay__Array_GetValue(&currentContext.AspectRatioElementIndexes, aspectRatioElementIndex))
@@ -221,18 +269,7 @@ func Clay__CalculateFinalLayout() {
 	}
 
 	// Sort tree roots by z-index
-	sortMax := currentContext.LayoutElementTreeRoots.Length() - 1
-	for sortMax > 0 { // todo dumb bubble sort
-		for i := int32(0); i < sortMax; i++ {
-			current := Clay__Array_GetValue(&currentContext.LayoutElementTreeRoots, i)
-			next := Clay__Array_GetValue(&currentContext.LayoutElementTreeRoots, i+1)
-			if next.ZIndex < current.ZIndex {
-				Clay__Array_Set(&currentContext.LayoutElementTreeRoots, i, next)
-				Clay__Array_Set(&currentContext.LayoutElementTreeRoots, i+1, current)
-			}
-		}
-		sortMax--
-	}
+	Clay__SortLayoutElementTreeRoots()
 
 	// Calculate final positions and generate render commands
 	Clay__Array_Reset(&currentContext.RenderCommands)
@@ -334,6 +371,7 @@ func Clay__CalculateFinalLayout() {
 			targetAttachPosition.Y += config.Offset.Y
 			rootPosition = targetAttachPosition
 		}
+		outerTransformCount := Clay__StartAncestorTransforms(rootElement.Id, root.ClipElementId, false, root.ZIndex)
 		if root.ClipElementId != 0 {
 			clipHashMapItem := Clay__GetHashMapItem(root.ClipElementId)
 			if clipHashMapItem != nil {
@@ -356,6 +394,7 @@ func Clay__CalculateFinalLayout() {
 				})
 			}
 		}
+		innerTransformCount := Clay__StartAncestorTransforms(rootElement.Id, root.ClipElementId, true, root.ZIndex)
 		Clay__Array_Add(&dfsBuffer, Clay__LayoutElementTreeNode{
 			LayoutElement: rootElement,
 			Position:      rootPosition,
@@ -392,6 +431,12 @@ func Clay__CalculateFinalLayout() {
 					currentElementBoundingBox.Height += expand.Height * 2
 				}
 
+				// Unchanged subtrees in the same place add the render commands of the last layout, see Clay_SetIncrementalLayoutEnabled
+				if Clay__ReuseCachedRenderCommands(currentElement, currentElementBoundingBox, root.ZIndex, rootElement) {
+					Clay__Array_Shrink(&dfsBuffer, 1)
+					continue
+				}
+
 				scrollContainerData := new(Clay__ScrollContainerDataInternal)
 				// Apply scroll offsets to container
 				if Clay__ElementHasConfig(currentElement, CLAY__ELEMENT_CONFIG_TYPE_CLIP) {
@@ -416,30 +461,15 @@ func Clay__CalculateFinalLayout() {
 				if hashMapItem != nil {
 					hashMapItem.BoundingBox = currentElementBoundingBox
 				}
+				currentElementTreeNode.CacheIndex = Clay__CacheLayoutElement(currentElement, hashMapItem, currentElementBoundingBox, root.ZIndex, rootElement)
+				currentElementTreeNode.TransitionIndex = Clay__OpenTransitionElement(currentElement)
 
-				sortedConfigIndexes := make([]int32, currentElement.ElementConfigs.Length())
-				for elementConfigIndex := int32(0); elementConfigIndex < currentElement.ElementConfigs.Length(); elementConfigIndex++ {
-					sortedConfigIndexes[elementConfigIndex] = elementConfigIndex
-				}
-				sortMax := currentElement.ElementConfigs.Length() - 1
-				for sortMax > 0 { // todo dumb bubble sort
-					for i := int32(0); i < sortMax; i++ {
-						current := sortedConfigIndexes[i]
-						next := sortedConfigIndexes[i+1]
-						currentType := Clay__Slice_Get(&currentElement.ElementConfigs, current).Type
-						nextType := Clay__Slice_Get(&currentElement.ElementConfigs, next).Type
-						if nextType == CLAY__ELEMENT_CONFIG_TYPE_CLIP || currentType == CLAY__ELEMENT_CONFIG_TYPE_BORDER {
-							sortedConfigIndexes[i] = next
-							sortedConfigIndexes[i+1] = current
-						}
-					}
-					sortMax--
-				}
+				sortedConfigIndexes := Clay__SortElementConfigsForRendering(currentElement)
 
 				emitRectangle := false
 				// Create the render commands for this element
 				sharedConfig := Clay__FindElementConfigWithType(currentElement, CLAY__ELEMENT_CONFIG_TYPE_SHARED).SharedElementConfig
-				if sharedConfig != nil && sharedConfig.BackgroundColor.A > 0 {
+				if sharedConfig != nil && (sharedConfig.BackgroundColor.A > 0 || Clay_GradientIsSet(&sharedConfig.BackgroundGradient) || Clay__TransitionsBackgroundColor(currentElement)) {
 					emitRectangle = true
 				} else if sharedConfig == nil {
 					emitRectangle = false
@@ -451,6 +481,7 @@ func Clay__CalculateFinalLayout() {
 						BoundingBox: currentElementBoundingBox,
 						UserData:    sharedConfig.UserData,
 						Id:          currentElement.Id,
+						ZIndex:      root.ZIndex,
 					}
 
 					offscreen := Clay__ElementIsOffscreen(&currentElementBoundingBox)
@@ -463,8 +494,24 @@ func Clay__CalculateFinalLayout() {
 						fallthrough
 					case CLAY__ELEMENT_CONFIG_TYPE_SHARED:
 						fallthrough
+					case CLAY__ELEMENT_CONFIG_TYPE_TRANSITION:
+						fallthrough
 					case CLAY__ELEMENT_CONFIG_TYPE_BORDER:
 						shouldRender = false
+					case CLAY__ELEMENT_CONFIG_TYPE_SHADOW:
+						// Shadows cast behind the element can be visible while it is offscreen, and inset shadows are drawn on top of its background below
+						shadowConfig := elementConfig.Config.ShadowElementConfig
+						shadowBoundingBox := Clay__ShadowBoundingBox(currentElementBoundingBox, shadowConfig)
+						shouldRender = !shadowConfig.Inset && !Clay__ElementIsOffscreen(&shadowBoundingBox)
+						renderCommand.CommandType = CLAY_RENDER_COMMAND_TYPE_SHADOW
+						renderCommand.RenderData = Clay__ShadowRenderData(shadowConfig, sharedConfig.CornerRadius)
+					case CLAY__ELEMENT_CONFIG_TYPE_TRANSFORM:
+						// Never culled, as the transform may move the element on screen and has to be balanced by its TRANSFORM_END
+						shouldRender = true
+						renderCommand.CommandType = CLAY_RENDER_COMMAND_TYPE_TRANSFORM_START
+						renderCommand.RenderData = Clay_RenderData{
+							Transform: Clay__TransformRenderData(elementConfig.Config.TransformElementConfig),
+						}
 					case CLAY__ELEMENT_CONFIG_TYPE_CLIP:
 						renderCommand.CommandType = CLAY_RENDER_COMMAND_TYPE_SCISSOR_START
 						renderCommand.RenderData = Clay_RenderData{
@@ -505,18 +552,23 @@ func Clay__CalculateFinalLayout() {
 						}
 						lineHeightOffset := (finalLineHeight - naturalLineHeight) / 2
 						yPosition := lineHeightOffset
+						if currentElement.ChildrenOrTextContent.TextElementData.Input {
+							Clay__AddTextInputRenderCommand(currentElement, currentElementBoundingBox, textElementConfig, root.ZIndex)
+						}
 						for lineIndex := int32(0); lineIndex < currentElement.ChildrenOrTextContent.TextElementData.WrappedLines.Length(); lineIndex++ {
 							wrappedLine := Clay__Slice_Get(&currentElement.ChildrenOrTextContent.TextElementData.WrappedLines, lineIndex)
-							if wrappedLine.Line.Length == 0 {
+							offset := Clay__TextLineOffset(textElementConfig, currentElementBoundingBox.Width, wrappedLine.Dimensions.Width)
+							if wrappedLine.Fragments.Length() > 0 {
+								Clay__AddRichTextLineRenderCommands(currentElement, wrappedLine, Clay_Vector2{X: currentElementBoundingBox.X + offset, Y: currentElementBoundingBox.Y + yPosition}, root.ZIndex)
 								yPosition += finalLineHeight
+								if !currentContext.DisableCulling && (currentElementBoundingBox.Y+yPosition > currentContext.LayoutDimensions.Height) {
+									break
+								}
 								continue
 							}
-							offset := (currentElementBoundingBox.Width - wrappedLine.Dimensions.Width)
-							if textElementConfig.TextAlignment == CLAY_TEXT_ALIGN_LEFT {
-								offset = 0
-							}
-							if textElementConfig.TextAlignment == CLAY_TEXT_ALIGN_CENTER {
-								offset /= 2
+							if wrappedLine.Line.Length == 0 {
+								yPosition += finalLineHeight
+								continue
 							}
 							Clay__AddRenderCommand(Clay_RenderCommand{
 								BoundingBox: Clay_BoundingBox{
@@ -575,8 +627,9 @@ func Clay__CalculateFinalLayout() {
 						BoundingBox: currentElementBoundingBox,
 						RenderData: Clay_RenderData{
 							Rectangle: Clay_RectangleRenderData{
-								BackgroundColor: sharedConfig.BackgroundColor,
-								CornerRadius:    sharedConfig.CornerRadius,
+								BackgroundColor:    sharedConfig.BackgroundColor,
+								BackgroundGradient: sharedConfig.BackgroundGradient,
+								CornerRadius:       sharedConfig.CornerRadius,
 							},
 						},
 						UserData:    sharedConfig.UserData,
@@ -585,15 +638,37 @@ func Clay__CalculateFinalLayout() {
 						CommandType: CLAY_RENDER_COMMAND_TYPE_RECTANGLE,
 					})
 				}
+				if shadowConfig := Clay__FindElementConfigWithType(currentElement, CLAY__ELEMENT_CONFIG_TYPE_SHADOW).ShadowElementConfig; shadowConfig != nil && shadowConfig.Inset && !Clay__ElementIsOffscreen(&currentElementBoundingBox) {
+					Clay__AddRenderCommand(Clay_RenderCommand{
+						BoundingBox: currentElementBoundingBox,
+						RenderData:  Clay__ShadowRenderData(shadowConfig, sharedConfig.CornerRadius),
+						UserData:    sharedConfig.UserData,
+						Id:          currentElement.Id,
+						ZIndex:      root.ZIndex,
+						CommandType: CLAY_RENDER_COMMAND_TYPE_SHADOW,
+					})
+				}
+
+				Clay__BeginTransitionChildren(currentElementTreeNode.TransitionIndex)
 
 				// Setup initial on-axis alignment
 				if !Clay__ElementHasConfig(currentElementTreeNode.LayoutElement, CLAY__ELEMENT_CONFIG_TYPE_TEXT) {
 					contentSize := Clay_Dimensions{Width: 0, Height: 0}
-					if layoutConfig.LayoutDirection == CLAY_LEFT_TO_RIGHT {
+					if gridData := Clay__GetGridLayoutData(currentElement); gridData != nil {
+						contentSize = Clay_Dimensions{Width: Clay__GridContentSize(currentElement, gridData, true), Height: Clay__GridContentSize(currentElement, gridData, false)}
+					} else if Clay__ElementWrapsChildren(currentElement) {
+						// Wrapped lines are aligned individually as their children are positioned
+						mainSize, crossSize, _ := Clay__WrappedContentSize(currentElement)
+						if Clay__LayoutDirectionIsHorizontal(layoutConfig.LayoutDirection) {
+							contentSize = Clay_Dimensions{Width: mainSize, Height: crossSize}
+						} else {
+							contentSize = Clay_Dimensions{Width: crossSize, Height: mainSize}
+						}
+					} else if Clay__LayoutDirectionIsHorizontal(layoutConfig.LayoutDirection) {
 						for i := int32(0); i < int32(currentElement.ChildrenOrTextContent.Children.Length); i++ {
 							childElement := Clay__Array_Get(&currentContext.LayoutElements, currentElement.ChildrenOrTextContent.Children.Elements[i])
-							contentSize.Width += childElement.Dimensions.Width
-							contentSize.Height = CLAY__MAX(contentSize.Height, childElement.Dimensions.Height)
+							contentSize.Width += Clay__OuterSizeAlongAxis(childElement, true)
+							contentSize.Height = CLAY__MAX(contentSize.Height, Clay__OuterSizeAlongAxis(childElement, false))
 						}
 						contentSize.Width += float32(CLAY__MAX(currentElement.ChildrenOrTextContent.Children.Length-1, 0) * layoutConfig.ChildGap)
 						extraSpace := currentElement.Dimensions.Width - float32(layoutConfig.Padding.Left+layoutConfig.Padding.Right) - contentSize.Width
@@ -602,23 +677,28 @@ func Clay__CalculateFinalLayout() {
 							extraSpace = 0
 						case CLAY_ALIGN_X_CENTER:
 							extraSpace /= 2
+						case CLAY_ALIGN_X_SPACE_BETWEEN, CLAY_ALIGN_X_SPACE_AROUND, CLAY_ALIGN_X_SPACE_EVENLY:
+							extraSpace, currentElementTreeNode.DistributedChildGap = Clay__DistributeSpace(Clay__SpaceDistributionAlongAxis(layoutConfig.ChildAlignment, true), extraSpace, int32(currentElement.ChildrenOrTextContent.Children.Length))
+							currentElementTreeNode.DistributedLeadingSpace = extraSpace
 						}
 						currentElementTreeNode.NextChildOffset.X += extraSpace
 						extraSpace = CLAY__MAX(0, extraSpace)
 					} else {
 						for i := int32(0); i < int32(currentElement.ChildrenOrTextContent.Children.Length); i++ {
 							childElement := Clay__Array_Get(&currentContext.LayoutElements, currentElement.ChildrenOrTextContent.Children.Elements[i])
-							contentSize.Width = CLAY__MAX(contentSize.Width, childElement.Dimensions.Width)
-							contentSize.Height += childElement.Dimensions.Height
+							contentSize.Width = CLAY__MAX(contentSize.Width, Clay__OuterSizeAlongAxis(childElement, true))
+							contentSize.Height += Clay__OuterSizeAlongAxis(childElement, false)
 						}
 						contentSize.Height += float32(CLAY__MAX(currentElement.ChildrenOrTextContent.Children.Length-1, 0) * layoutConfig.ChildGap)
 						extraSpace := currentElement.Dimensions.Height - float32(layoutConfig.Padding.Top+layoutConfig.Padding.Bottom) - contentSize.Height
 						switch layoutConfig.ChildAlignment.Y {
-						case CLAY_ALIGN_Y_TOP:
+						case CLAY_ALIGN_Y_TOP, CLAY_ALIGN_Y_BASELINE:
 							extraSpace = 0
 						case CLAY_ALIGN_Y_CENTER:
 							extraSpace /= 2
-
+						case CLAY_ALIGN_Y_SPACE_BETWEEN, CLAY_ALIGN_Y_SPACE_AROUND, CLAY_ALIGN_Y_SPACE_EVENLY:
+							extraSpace, currentElementTreeNode.DistributedChildGap = Clay__DistributeSpace(Clay__SpaceDistributionAlongAxis(layoutConfig.ChildAlignment, false), extraSpace, int32(currentElement.ChildrenOrTextContent.Children.Length))
+							currentElementTreeNode.DistributedLeadingSpace = extraSpace
 						}
 						extraSpace = CLAY__MAX(0, extraSpace)
 						currentElementTreeNode.NextChildOffset.Y += extraSpace
@@ -633,6 +713,7 @@ func Clay__CalculateFinalLayout() {
 				}
 			} else {
 				// DFS is returning upwards backwards
+				Clay__EndTransitionChildren(currentElementTreeNode.TransitionIndex)
 				var scrollOffset Clay_Vector2
 				closeClipElement := false
 				clipConfig := Clay__FindElementConfigWithType(currentElement, CLAY__ELEMENT_CONFIG_TYPE_CLIP).ClipElementConfig
@@ -679,15 +760,28 @@ func Clay__CalculateFinalLayout() {
 							CommandType: CLAY_RENDER_COMMAND_TYPE_BORDER,
 						}
 						Clay__AddRenderCommand(renderCommand)
-						if borderConfig.Width.BetweenChildren > 0 && borderConfig.Color.A > 0 {
-							halfGap := float32(layoutConfig.ChildGap / 2)
+						if borderConfig.Width.BetweenChildren > 0 && borderConfig.Color.A > 0 && Clay__ElementWrapsChildren(currentElement) && Clay__GetGridLayoutData(currentElement) == nil {
+							scrolledBoundingBox := currentElementBoundingBox
+							scrolledBoundingBox.X += scrollOffset.X
+							scrolledBoundingBox.Y += scrollOffset.Y
+							Clay__AddWrappedBetweenChildrenBorders(currentElement, scrolledBoundingBox, borderConfig, sharedConfig.UserData)
+						} else if borderConfig.Width.BetweenChildren > 0 && borderConfig.Color.A > 0 && Clay__GetGridLayoutData(currentElement) == nil {
+							// Borders sit in the middle of the gap between children, including any distributed space
+							childGap := float32(layoutConfig.ChildGap) + currentElementTreeNode.DistributedChildGap
+							halfGap := float32(layoutConfig.ChildGap/2) + currentElementTreeNode.DistributedChildGap/2
 							borderOffset := Clay_Vector2{
-								X: float32(layoutConfig.Padding.Left) - halfGap,
-								Y: float32(layoutConfig.Padding.Top) - halfGap,
+								X: float32(layoutConfig.Padding.Left) + currentElementTreeNode.DistributedLeadingSpace - halfGap,
+								Y: float32(layoutConfig.Padding.Top) + currentElementTreeNode.DistributedLeadingSpace - halfGap,
 							}
-							if layoutConfig.LayoutDirection == CLAY_LEFT_TO_RIGHT {
+							// Walk the children in the order they are placed along the layout axis
+							reversed := Clay__LayoutDirectionIsReversed(layoutConfig.LayoutDirection)
+							if Clay__LayoutDirectionIsHorizontal(layoutConfig.LayoutDirection) {
 								for i := int32(0); i < int32(currentElement.ChildrenOrTextContent.Children.Length); i++ {
-									childElement := Clay__Array_Get(&currentContext.LayoutElements, currentElement.ChildrenOrTextContent.Children.Elements[i])
+									childIndex := i
+									if reversed {
+										childIndex = int32(currentElement.ChildrenOrTextContent.Children.Length) - 1 - i
+									}
+									childElement := Clay__Array_Get(&currentContext.LayoutElements, currentElement.ChildrenOrTextContent.Children.Elements[childIndex])
 									if i > 0 {
 										Clay__AddRenderCommand(Clay_RenderCommand{
 											BoundingBox: Clay_BoundingBox{
@@ -706,11 +800,15 @@ func Clay__CalculateFinalLayout() {
 											CommandType: CLAY_RENDER_COMMAND_TYPE_RECTANGLE,
 										})
 									}
-									borderOffset.X += (childElement.Dimensions.Width + float32(layoutConfig.ChildGap))
+									borderOffset.X += (Clay__OuterSizeAlongAxis(childElement, true) + childGap)
 								}
 							} else {
 								for i := int32(0); i < int32(currentElement.ChildrenOrTextContent.Children.Length); i++ {
-									childElement := Clay__Array_Get(&currentContext.LayoutElements, currentElement.ChildrenOrTextContent.Children.Elements[i])
+									childIndex := i
+									if reversed {
+										childIndex = int32(currentElement.ChildrenOrTextContent.Children.Length) - 1 - i
+									}
+									childElement := Clay__Array_Get(&currentContext.LayoutElements, currentElement.ChildrenOrTextContent.Children.Elements[childIndex])
 									if i > 0 {
 										Clay__AddRenderCommand(Clay_RenderCommand{
 											BoundingBox: Clay_BoundingBox{
@@ -729,7 +827,7 @@ func Clay__CalculateFinalLayout() {
 											CommandType: CLAY_RENDER_COMMAND_TYPE_RECTANGLE,
 										})
 									}
-									borderOffset.Y += (childElement.Dimensions.Height + float32(layoutConfig.ChildGap))
+									borderOffset.Y += (Clay__OuterSizeAlongAxis(childElement, false) + childGap)
 								}
 							}
 						}
@@ -742,6 +840,11 @@ func Clay__CalculateFinalLayout() {
 						CommandType: CLAY_RENDER_COMMAND_TYPE_SCISSOR_END,
 					})
 				}
+				if Clay__ElementHasConfig(currentElement, CLAY__ELEMENT_CONFIG_TYPE_TRANSFORM) {
+					Clay__EndTransforms(currentElement.Id, 1, root.ZIndex)
+				}
+				Clay__FinishCachedLayoutElement(currentElement, currentElementTreeNode.CacheIndex)
+				Clay__CloseTransitionElement(currentElementTreeNode.TransitionIndex)
 
 				Clay__Array_Shrink(&dfsBuffer, 1)
 				continue
@@ -750,24 +853,70 @@ func Clay__CalculateFinalLayout() {
 			// Add children to the DFS buffer
 			if !Clay__ElementHasConfig(currentElement, CLAY__ELEMENT_CONFIG_TYPE_TEXT) {
 				Clay__Array_Grow(&dfsBuffer, int32(currentElement.ChildrenOrTextContent.Children.Length))
-				for i := int32(0); i < int32(currentElement.ChildrenOrTextContent.Children.Length); i++ {
+				gridData := Clay__GetGridLayoutData(currentElement)
+				wrapsChildren := gridData == nil && Clay__ElementWrapsChildren(currentElement)
+				var wrapLine Clay__WrapLine
+				var wrapLineCount int32
+				var wrapLineOffset float32
+				if wrapsChildren {
+					wrapLineOffset, wrapLineCount = Clay__WrapLinesStartOffset(currentElement)
+				}
+				var childrenBaseline float32
+				if gridData == nil && !wrapsChildren {
+					childrenBaseline, _, _ = Clay__GetChildrenBaseline(currentElement)
+				}
+				reversed := gridData == nil && Clay__LayoutDirectionIsReversed(layoutConfig.LayoutDirection)
+				childCount := int32(currentElement.ChildrenOrTextContent.Children.Length)
+				for placementIndex := int32(0); placementIndex < childCount; placementIndex++ {
+					// Start a new line, aligned along the layout axis by its own size
+					if wrapsChildren && placementIndex == wrapLine.EndChild {
+						if placementIndex > 0 {
+							wrapLineOffset += Clay__WrapLineCrossSize(currentElement, wrapLine, wrapLineCount) + float32(layoutConfig.LineGap)
+						}
+						wrapLine = Clay__NextWrapLine(currentElement, placementIndex)
+						if Clay__LayoutDirectionIsHorizontal(layoutConfig.LayoutDirection) {
+							currentElementTreeNode.NextChildOffset.X, currentElementTreeNode.DistributedChildGap = Clay__WrapLineStartOffset(currentElement, wrapLine)
+						} else {
+							currentElementTreeNode.NextChildOffset.Y, currentElementTreeNode.DistributedChildGap = Clay__WrapLineStartOffset(currentElement, wrapLine)
+						}
+					}
+					// Reversed layout directions place the children (of each wrapped line) starting from the last one
+					i := placementIndex
+					if reversed && wrapsChildren {
+						i = wrapLine.StartChild + wrapLine.EndChild - 1 - placementIndex
+					} else if reversed {
+						i = childCount - 1 - placementIndex
+					}
 					childElement := Clay__Array_Get(&currentContext.LayoutElements, currentElement.ChildrenOrTextContent.Children.Elements[i])
-					// Alignment along non layout axis
-					if layoutConfig.LayoutDirection == CLAY_LEFT_TO_RIGHT {
+					if gridData != nil {
+						currentElementTreeNode.NextChildOffset = Clay__GridChildOffset(currentElement, gridData, i, childElement)
+					} else if wrapsChildren {
+						// Alignment within the line along the non layout axis
+						lineCrossSize := Clay__WrapLineCrossSize(currentElement, wrapLine, wrapLineCount)
+						childAlignment := Clay__ResolveChildAlignment(layoutConfig, childElement)
+						if Clay__LayoutDirectionIsHorizontal(layoutConfig.LayoutDirection) {
+							currentElementTreeNode.NextChildOffset.Y = wrapLineOffset + (lineCrossSize-Clay__OuterSizeAlongAxis(childElement, false))*Clay__ChildAlignmentFactor(childAlignment, false)
+						} else {
+							currentElementTreeNode.NextChildOffset.X = wrapLineOffset + (lineCrossSize-Clay__OuterSizeAlongAxis(childElement, true))*Clay__ChildAlignmentFactor(childAlignment, true)
+						}
+					} else if Clay__LayoutDirectionIsHorizontal(layoutConfig.LayoutDirection) {
+						// Alignment along non layout axis
 						currentElementTreeNode.NextChildOffset.Y = float32(currentElement.LayoutConfig.Padding.Top)
-						whiteSpaceAroundChild := currentElement.Dimensions.Height - float32(layoutConfig.Padding.Top+layoutConfig.Padding.Bottom) - childElement.Dimensions.Height
-						switch layoutConfig.ChildAlignment.Y {
+						whiteSpaceAroundChild := currentElement.Dimensions.Height - float32(layoutConfig.Padding.Top+layoutConfig.Padding.Bottom) - Clay__OuterSizeAlongAxis(childElement, false)
+						switch Clay__ResolveChildAlignment(layoutConfig, childElement).Y {
 						case CLAY_ALIGN_Y_TOP:
 							//do not fallthrough
 						case CLAY_ALIGN_Y_CENTER:
 							currentElementTreeNode.NextChildOffset.Y += whiteSpaceAroundChild / 2
 						case CLAY_ALIGN_Y_BOTTOM:
 							currentElementTreeNode.NextChildOffset.Y += whiteSpaceAroundChild
+						case CLAY_ALIGN_Y_BASELINE:
+							currentElementTreeNode.NextChildOffset.Y += childrenBaseline - float32(childElement.LayoutConfig.Margin.Top) - Clay__GetElementBaseline(childElement)
 						}
 					} else {
 						currentElementTreeNode.NextChildOffset.X = float32(currentElement.LayoutConfig.Padding.Left)
-						whiteSpaceAroundChild := currentElement.Dimensions.Width - float32(layoutConfig.Padding.Left+layoutConfig.Padding.Right) - childElement.Dimensions.Width
-						switch layoutConfig.ChildAlignment.X {
+						whiteSpaceAroundChild := currentElement.Dimensions.Width - float32(layoutConfig.Padding.Left+layoutConfig.Padding.Right) - Clay__OuterSizeAlongAxis(childElement, true)
+						switch Clay__ResolveChildAlignment(layoutConfig, childElement).X {
 						case CLAY_ALIGN_X_LEFT:
 							//do not fallthrough
 						case CLAY_ALIGN_X_CENTER:
@@ -777,9 +926,10 @@ func Clay__CalculateFinalLayout() {
 						}
 					}
 
+					// The offsets above are those of the child's margin box
 					childPosition := Clay_Vector2{
-						X: currentElementTreeNode.Position.X + currentElementTreeNode.NextChildOffset.X + scrollOffset.X,
-						Y: currentElementTreeNode.Position.Y + currentElementTreeNode.NextChildOffset.Y + scrollOffset.Y,
+						X: currentElementTreeNode.Position.X + currentElementTreeNode.NextChildOffset.X + float32(childElement.LayoutConfig.Margin.Left) + scrollOffset.X,
+						Y: currentElementTreeNode.Position.Y + currentElementTreeNode.NextChildOffset.Y + float32(childElement.LayoutConfig.Margin.Top) + scrollOffset.Y,
 					}
 
 					// DFS buffer elements need to be added in reverse because stack traversal happens backwards
@@ -795,21 +945,23 @@ func Clay__CalculateFinalLayout() {
 					Clay__Array_Set(&currentContext.TreeNodeVisited, newNodeIndex, false)
 
 					// Update parent offsets
-					if layoutConfig.LayoutDirection == CLAY_LEFT_TO_RIGHT {
-						currentElementTreeNode.NextChildOffset.X += childElement.Dimensions.Width + float32(layoutConfig.ChildGap)
+					if Clay__LayoutDirectionIsHorizontal(layoutConfig.LayoutDirection) {
+						currentElementTreeNode.NextChildOffset.X += Clay__OuterSizeAlongAxis(childElement, true) + float32(layoutConfig.ChildGap) + currentElementTreeNode.DistributedChildGap
 					} else {
-						currentElementTreeNode.NextChildOffset.Y += childElement.Dimensions.Height + float32(layoutConfig.ChildGap)
+						currentElementTreeNode.NextChildOffset.Y += Clay__OuterSizeAlongAxis(childElement, false) + float32(layoutConfig.ChildGap) + currentElementTreeNode.DistributedChildGap
 					}
 				}
 			}
 		}
 
+		Clay__EndTransforms(rootElement.Id, innerTransformCount, root.ZIndex)
 		if root.ClipElementId != 0 {
 			Clay__AddRenderCommand(Clay_RenderCommand{
 				Id:          Clay__HashNumber(rootElement.Id, uint32(int32(rootElement.ChildrenOrTextContent.Children.Length)+11)).Id,
 				CommandType: CLAY_RENDER_COMMAND_TYPE_SCISSOR_END,
 			})
 		}
+		Clay__EndTransforms(rootElement.Id, outerTransformCount, root.ZIndex)
 	}
-
+	Clay__EndIncrementalLayout()
 }
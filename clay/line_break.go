@@ -0,0 +1,191 @@
+package clay
+
+import (
+	"bytes"
+	"unicode/utf8"
+
+	"github.com/go-text/typesetting/segmenter"
+	"github.com/zodimo/clay-go/pkg/mem"
+)
+
+// A run of text between two line break opportunities, as byte offsets into the text.
+type Clay__LineBreakSegment struct {
+	Start int32
+	End   int32
+	// The segment ends with a newline, so the line must be broken after it.
+	Mandatory bool
+}
+
+// Scratch buffers reused between calls to Clay__FindLineBreakSegments and Clay__GraphemeBoundary, as segmenting text
+// requires it to be decoded into runes first.
+type Clay__TextSegmenter struct {
+	segmenter   segmenter.Segmenter
+	runes       []rune
+	byteOffsets []int32 // The byte offset of each rune, followed by the length of the text.
+	segments    []Clay__LineBreakSegment
+	boundaries  []int32
+	// The grapheme boundaries of the text being truncated, kept while its candidates are measured.
+	headBoundaries []int32
+	tailBoundaries []int32
+}
+
+// Decodes text into runes for the segmenter, keeping track of where each rune starts. Invalid UTF-8 bytes are decoded
+// as one rune each so that the byte offsets stay in step with the text.
+func (s *Clay__TextSegmenter) init(text []byte) {
+	s.runes = s.runes[:0]
+	s.byteOffsets = s.byteOffsets[:0]
+	for offset := 0; offset < len(text); {
+		r, size := utf8.DecodeRune(text[offset:])
+		s.runes = append(s.runes, r)
+		s.byteOffsets = append(s.byteOffsets, int32(offset))
+		offset += size
+	}
+	s.byteOffsets = append(s.byteOffsets, int32(len(text)))
+	s.segmenter.Init(s.runes)
+}
+
+// Splits text into the segments between its Unicode line break opportunities (UAX #14). Breaks are allowed after
+// spaces, between most CJK characters and after soft hyphens, but never inside a grapheme cluster or after a
+// non-breaking space. The returned slice is reused by the next call.
+func Clay__FindLineBreakSegments(text Clay_String) []Clay__LineBreakSegment {
+	s := &TextSegmenter
+	s.segments = s.segments[:0]
+	if text.Length == 0 {
+		return s.segments
+	}
+	s.init(text.Chars[:text.Length])
+	iterator := s.segmenter.LineIterator()
+	for iterator.Next() {
+		line := iterator.Line()
+		segment := Clay__LineBreakSegment{
+			Start: s.byteOffsets[line.Offset],
+			End:   s.byteOffsets[line.Offset+len(line.Text)],
+		}
+		// The end of the text is also a mandatory break
+		segment.Mandatory = line.IsMandatoryBreak && Clay__IsNewline(line.Text[len(line.Text)-1])
+		s.segments = append(s.segments, segment)
+	}
+	return s.segments
+}
+
+// Returns the grapheme cluster boundary of text closest to offset, searching backwards or forwards from it.
+// Text is only ever cut at grapheme boundaries, so that e.g. emoji ZWJ sequences and combining marks stay whole.
+// To look up several offsets in the same text, segment it once with Clay__AppendGraphemeBoundaries instead.
+func Clay__GraphemeBoundary(text []byte, offset int32, forwards bool) int32 {
+	if offset <= 0 || offset >= int32(len(text)) {
+		return offset
+	}
+	return Clay__NearestGraphemeBoundary(Clay__GraphemeBoundaries(text), offset, forwards)
+}
+
+// Returns the byte offsets of all grapheme cluster boundaries of text, including 0 and the length of the text.
+// The returned slice is reused by the next call.
+func Clay__GraphemeBoundaries(text []byte) []int32 {
+	s := &TextSegmenter
+	s.boundaries = Clay__AppendGraphemeBoundaries(s.boundaries[:0], text)
+	return s.boundaries
+}
+
+// Appends the byte offsets of all grapheme cluster boundaries of text, including 0 and the length of the text, to
+// boundaries and returns the extended slice.
+func Clay__AppendGraphemeBoundaries(boundaries []int32, text []byte) []int32 {
+	boundaries = append(boundaries, 0)
+	if len(text) == 0 {
+		return boundaries
+	}
+	s := &TextSegmenter
+	s.init(text)
+	iterator := s.segmenter.GraphemeIterator()
+	for iterator.Next() {
+		grapheme := iterator.Grapheme()
+		boundaries = append(boundaries, s.byteOffsets[grapheme.Offset+len(grapheme.Text)])
+	}
+	return boundaries
+}
+
+// Returns the boundary closest to offset, searching backwards or forwards from it, given the grapheme boundaries of a
+// text as returned by Clay__AppendGraphemeBoundaries. Offsets outside of the text are returned unchanged.
+func Clay__NearestGraphemeBoundary(boundaries []int32, offset int32, forwards bool) int32 {
+	if offset <= 0 || offset >= boundaries[len(boundaries)-1] {
+		return offset
+	}
+	// Binary search for the first boundary at or past offset
+	low, high := 0, len(boundaries)-1
+	for low < high {
+		middle := (low + high) / 2
+		if boundaries[middle] < offset {
+			low = middle + 1
+		} else {
+			high = middle
+		}
+	}
+	if boundaries[low] == offset || forwards {
+		return boundaries[low]
+	}
+	return boundaries[low-1]
+}
+
+// Returns true if a line can be broken between two runs of text that are laid out next to each other, such as the
+// spans of a rich text element. Only the last rune of before and the first rune of after are considered.
+func Clay__IsLineBreakBetween(before []byte, after []byte) bool {
+	if len(before) == 0 || len(after) == 0 {
+		return false
+	}
+	lastRune, _ := utf8.DecodeLastRune(before)
+	firstRune, _ := utf8.DecodeRune(after)
+	s := &TextSegmenter
+	s.runes = append(s.runes[:0], lastRune, firstRune)
+	s.segmenter.Init(s.runes)
+	iterator := s.segmenter.LineIterator()
+	return iterator.Next() && len(iterator.Line().Text) == 1
+}
+
+// Returns true for the characters that end a line (UAX #14 classes BK, CR, LF and NL).
+func Clay__IsNewline(r rune) bool {
+	switch r {
+	case '\n', '\r', '\v', '\f', '\u0085', '\u2028', '\u2029':
+		return true
+	}
+	return false
+}
+
+// Shows a hyphen at the end of every wrapped line that was broken at a soft hyphen. The line is copied into
+// currentContext.DynamicStringData with the soft hyphen replaced by "-", and measured with Clay__MeasureTextCached.
+func Clay__HyphenateWrappedTextLines(textElementData *Clay__TextElementData, textConfig *Clay_TextElementConfig) {
+	// The final line wasn't broken
+	for lineIndex := int32(0); lineIndex < textElementData.WrappedLines.Length()-1; lineIndex++ {
+		wrappedLine := Clay__Slice_Get(&textElementData.WrappedLines, lineIndex)
+		if wrappedLine.Fragments.Length() == 0 {
+			Clay__HyphenateTextLine(&wrappedLine.Line, &wrappedLine.Dimensions, textConfig)
+			continue
+		}
+		fragment := Clay__Slice_Get(&wrappedLine.Fragments, wrappedLine.Fragments.Length()-1)
+		if Clay__HyphenateTextLine(&fragment.Line, &fragment.Dimensions, textElementData.Spans[fragment.SpanIndex].Config) {
+			wrappedLine.Dimensions.Width = fragment.Offset.X + fragment.Dimensions.Width
+		}
+	}
+}
+
+// Replaces a soft hyphen at the end of line with "-", returning true if it did.
+func Clay__HyphenateTextLine(line *Clay_String, dimensions *Clay_Dimensions, textConfig *Clay_TextElementConfig) bool {
+	currentContext := Clay_GetCurrentContext()
+	if !bytes.HasSuffix(line.Chars[:line.Length], CLAY__SOFT_HYPHEN.Chars) {
+		return false
+	}
+	hyphenatedLength := line.Length - CLAY__SOFT_HYPHEN.Length + 1
+	stringStart := currentContext.DynamicStringData.Length()
+	if stringStart+hyphenatedLength > currentContext.DynamicStringData.Capacity() {
+		if !currentContext.BooleanWarnings.MaxDynamicStringDataExceeded {
+			Clay__ReportError(CLAY_ERROR_TYPE_DYNAMIC_STRING_CAPACITY_EXCEEDED, CLAY_STRING("Clay ran out of capacity while hyphenating text. Try setting Clay_Context.MaxDynamicStringDataBytes to a higher value."))
+			currentContext.BooleanWarnings.MaxDynamicStringDataExceeded = true
+		}
+		return false
+	}
+	Clay__Array_Grow(&currentContext.DynamicStringData, hyphenatedLength)
+	chars := mem.MArray_GetSlice(&currentContext.DynamicStringData, stringStart, stringStart+hyphenatedLength)
+	copy(chars, line.Chars[:hyphenatedLength-1])
+	chars[hyphenatedLength-1] = '-'
+	*line = Clay_String{Length: hyphenatedLength, Chars: chars}
+	dimensions.Width = Clay__MeasureTextCached(line, textConfig).UnwrappedDimensions.Width
+	return true
+}
@@ -0,0 +1,99 @@
+package clay
+
+import (
+	"testing"
+)
+
+const testEmojiFamily = "\U0001F468\u200d\U0001F469\u200d\U0001F467" // Man, woman and girl joined by zero width joiners, 18 bytes
+
+func TestLineBreaking(t *testing.T) {
+	tests := []struct {
+		name     string
+		width    float32
+		text     string
+		expected []string
+	}{
+		{"chinese without spaces", 65, "你好世界", []string{"你好", "世界"}},
+		{"mixed scripts", 60, "Hi 你好 there", []string{"Hi 你", "好", "there"}},
+		{"emoji zwj sequences stay whole", 200, testEmojiFamily + testEmojiFamily, []string{testEmojiFamily, testEmojiFamily}},
+		{"soft hyphen", 70, "co\u00adoperate", []string{"co-", "operate"}},
+		{"unused soft hyphen is kept", 200, "co\u00adoperate", []string{"co\u00adoperate"}},
+		{"non-breaking space", 50, "a\u00a0b c", []string{"a\u00a0b", "c"}},
+		{"crlf", 100, "one\r\ntwo", []string{"one", "two"}},
+		{"invalid utf-8", 40, "a\xffb c", []string{"a\xffb", "c"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lines := testTruncatedTextLines(t, test.width, test.text)
+			testExpectTextLines(t, test.expected, lines)
+		})
+	}
+}
+
+func TestLineBreakingMeasuresHyphen(t *testing.T) {
+	newTestContext(t, 800, 600)
+	commands := runTestLayout(func() {
+		CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{},
+			CLAY(CLAY_ID("parent"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(70)}}},
+				CLAY_TEXT("co\u00adoperate", TextWithTextAlignment(CLAY_TEXT_ALIGN_RIGHT)),
+			),
+		)
+	})
+	for _, command := range commands {
+		if command.CommandType == CLAY_RENDER_COMMAND_TYPE_TEXT && command.RenderData.Text.StringContents.String() == "co-" {
+			if command.BoundingBox.X != 40 || command.BoundingBox.Width != 30 {
+				t.Errorf("expected the hyphenated line to be measured as 30 wide, got %v", command.BoundingBox)
+			}
+			return
+		}
+	}
+	t.Errorf("expected a hyphenated line")
+}
+
+func TestTruncationKeepsGraphemesWhole(t *testing.T) {
+	// Cutting at a rune boundary would leave part of the ZWJ sequence before the ellipsis
+	lines := testTruncatedTextLines(t, 150, testEmojiFamily, TextWithOverflow(CLAY_TEXT_OVERFLOW_ELLIPSIS))
+	testExpectTextLines(t, []string{"…"}, lines)
+
+	lines = testTruncatedTextLines(t, 60, "ééé", TextWithOverflow(CLAY_TEXT_OVERFLOW_ELLIPSIS))
+	testExpectTextLines(t, []string{"é…"}, lines)
+}
+
+func TestNearestGraphemeBoundary(t *testing.T) {
+	text := []byte("a" + testEmojiFamily + "é")
+	boundaries := Clay__AppendGraphemeBoundaries(nil, text)
+	tests := []struct {
+		offset   int32
+		forwards bool
+		expected int32
+	}{
+		{0, false, 0},
+		{1, false, 1},
+		{5, false, 1},
+		{5, true, 19},
+		{19, true, 19},
+		{20, false, 19},
+		{20, true, 21},
+		{21, false, 21},
+	}
+	for _, test := range tests {
+		if got := Clay__NearestGraphemeBoundary(boundaries, test.offset, test.forwards); got != test.expected {
+			t.Errorf("offset %d forwards %v: expected %d, got %d", test.offset, test.forwards, test.expected, got)
+		}
+		if got := Clay__GraphemeBoundary(text, test.offset, test.forwards); got != test.expected {
+			t.Errorf("Clay__GraphemeBoundary at offset %d forwards %v: expected %d, got %d", test.offset, test.forwards, test.expected, got)
+		}
+	}
+}
+
+func TestRichTextLineBreaking(t *testing.T) {
+	newTestContext(t, 800, 600)
+	commands := testRichTextCommands(t, 65, []TextSpan{
+		CLAY_TEXT_SPAN("你好"),
+		CLAY_TEXT_SPAN("世界", TextWithFontId(1)),
+	})
+	testExpectTextCommands(t, []testTextCommand{
+		{text: "你好", box: Clay_BoundingBox{X: 0, Y: 0, Width: 60, Height: 20}},
+		{text: "世界", box: Clay_BoundingBox{X: 0, Y: 20, Width: 60, Height: 20}, fontId: 1},
+	}, commands)
+}
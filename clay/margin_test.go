@@ -0,0 +1,133 @@
+package clay
+
+import "testing"
+
+func testMarginBox(id string, width, height float32, margin Clay_Margin) ClayContainer {
+	return CLAY(CLAY_ID(id), Clay_ElementDeclaration{
+		Layout:          Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(width), Height: CLAY_SIZING_FIXED(height)}, Margin: margin},
+		BackgroundColor: Clay_Color{R: 255, A: 255},
+	})
+}
+
+func TestMarginsAddToFitSizeAndOffsets(t *testing.T) {
+	newTestContext(t, 800, 600)
+	commands := runTestLayout(func() {
+		CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{},
+			CLAY(CLAY_ID("parent"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{Padding: CLAY_PADDING_ALL(10), ChildGap: 5}},
+				testMarginBox("a", 50, 20, Clay_Margin{Left: 5, Right: 10, Top: 3, Bottom: 7}),
+				testMarginBox("b", 30, 30, Clay_Margin{}),
+			),
+		)
+	})
+
+	testExpectBoundingBox(t, "parent", Clay_BoundingBox{X: 0, Y: 0, Width: 120, Height: 50})
+	testExpectBoundingBox(t, "a", Clay_BoundingBox{X: 15, Y: 13, Width: 50, Height: 20})
+	testExpectBoundingBox(t, "b", Clay_BoundingBox{X: 80, Y: 10, Width: 30, Height: 30})
+
+	// Render commands use the border box, without the margins
+	for _, command := range commands {
+		if command.Id == CLAY_ID("a").Id && command.CommandType == CLAY_RENDER_COMMAND_TYPE_RECTANGLE {
+			if expected := (Clay_BoundingBox{X: 15, Y: 13, Width: 50, Height: 20}); command.BoundingBox != expected {
+				t.Errorf("expected rectangle bounding box %v, got %v", expected, command.BoundingBox)
+			}
+			return
+		}
+	}
+	t.Errorf("no rectangle render command was emitted for a")
+}
+
+func TestMarginsAcrossLayoutAxis(t *testing.T) {
+	newTestContext(t, 800, 600)
+	runTestLayout(func() {
+		CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{},
+			CLAY(CLAY_ID("parent"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{
+				Sizing:          Clay_Sizing{Width: CLAY_SIZING_FIXED(200)},
+				LayoutDirection: CLAY_TOP_TO_BOTTOM,
+				ChildAlignment:  Clay_ChildAlignment{X: CLAY_ALIGN_X_CENTER},
+			}},
+				CLAY(CLAY_ID("fill"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{
+					Sizing: Clay_Sizing{Width: CLAY_SIZING_GROW(Clay_SizingMinMax{}), Height: CLAY_SIZING_FIXED(20)},
+					Margin: Clay_Margin{Left: 10, Right: 30},
+				}}),
+				testMarginBox("centered", 40, 20, Clay_Margin{Left: 20}),
+			),
+		)
+	})
+
+	// GROW children fill the parent minus their margins, and alignment centers the margin box
+	testExpectBoundingBox(t, "fill", Clay_BoundingBox{X: 10, Y: 0, Width: 160, Height: 20})
+	testExpectBoundingBox(t, "centered", Clay_BoundingBox{X: 90, Y: 20, Width: 40, Height: 20})
+	testExpectBoundingBox(t, "parent", Clay_BoundingBox{X: 0, Y: 0, Width: 200, Height: 40})
+}
+
+func TestMarginsAlongLayoutAxis(t *testing.T) {
+	t.Run("grow", func(t *testing.T) {
+		newTestContext(t, 800, 600)
+		runTestLayout(func() {
+			CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{},
+				CLAY(CLAY_ID("parent"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(300), Height: CLAY_SIZING_FIXED(20)}}},
+					CLAY(CLAY_ID("a"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_GROW(Clay_SizingMinMax{})}, Margin: Clay_Margin{Right: 20}}}),
+					CLAY(CLAY_ID("b"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_GROW(Clay_SizingMinMax{})}}}),
+				),
+			)
+		})
+		testExpectBoundingBox(t, "a", Clay_BoundingBox{X: 0, Y: 0, Width: 140, Height: 0})
+		testExpectBoundingBox(t, "b", Clay_BoundingBox{X: 160, Y: 0, Width: 140, Height: 0})
+	})
+
+	t.Run("percent", func(t *testing.T) {
+		newTestContext(t, 800, 600)
+		runTestLayout(func() {
+			CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{},
+				CLAY(CLAY_ID("parent"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(220), Height: CLAY_SIZING_FIXED(20)}}},
+					CLAY(CLAY_ID("a"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_PERCENT(0.5)}, Margin: Clay_Margin{Left: 10, Right: 10}}}),
+					CLAY(CLAY_ID("b"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_PERCENT(0.5)}}}),
+				),
+			)
+		})
+		// Percentages are taken of the space left after margins, like child gaps
+		testExpectBoundingBox(t, "a", Clay_BoundingBox{X: 10, Y: 0, Width: 100, Height: 0})
+		testExpectBoundingBox(t, "b", Clay_BoundingBox{X: 120, Y: 0, Width: 100, Height: 0})
+	})
+}
+
+func TestMarginsInWrappedLines(t *testing.T) {
+	newTestContext(t, 800, 600)
+	runTestLayout(func() {
+		CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{},
+			CLAY(CLAY_ID("parent"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{
+				Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(100)},
+				Wrap:   CLAY_WRAP_WRAP,
+			}},
+				testMarginBox("a", 40, 20, CLAY_MARGIN_ALL(5)),
+				testMarginBox("b", 40, 20, CLAY_MARGIN_ALL(5)),
+				testMarginBox("c", 40, 20, CLAY_MARGIN_ALL(5)),
+			),
+		)
+	})
+
+	testExpectBoundingBox(t, "a", Clay_BoundingBox{X: 5, Y: 5, Width: 40, Height: 20})
+	testExpectBoundingBox(t, "b", Clay_BoundingBox{X: 55, Y: 5, Width: 40, Height: 20})
+	testExpectBoundingBox(t, "c", Clay_BoundingBox{X: 5, Y: 35, Width: 40, Height: 20})
+	testExpectBoundingBox(t, "parent", Clay_BoundingBox{X: 0, Y: 0, Width: 100, Height: 60})
+}
+
+func TestMarginsInGridCells(t *testing.T) {
+	newTestContext(t, 800, 600)
+	runTestLayout(func() {
+		CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{},
+			CLAY(CLAY_ID("grid"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{
+				Grid: Clay_GridLayoutConfig{Columns: []Clay_GridTrack{CLAY_GRID_TRACK_FIXED(100), CLAY_GRID_TRACK_FIXED(100)}},
+			}},
+				testMarginBox("a", 100, 20, Clay_Margin{}),
+				CLAY(CLAY_ID("b"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{
+					Sizing: Clay_Sizing{Width: CLAY_SIZING_GROW(Clay_SizingMinMax{}), Height: CLAY_SIZING_FIXED(20)},
+					Margin: CLAY_MARGIN_ALL(10),
+				}}),
+			),
+		)
+	})
+
+	testExpectBoundingBox(t, "b", Clay_BoundingBox{X: 110, Y: 10, Width: 80, Height: 20})
+	testExpectBoundingBox(t, "grid", Clay_BoundingBox{X: 0, Y: 0, Width: 200, Height: 40})
+}
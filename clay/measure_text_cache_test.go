@@ -0,0 +1,111 @@
+package clay
+
+import (
+	"fmt"
+	"testing"
+)
+
+// Sets a measure text function that counts how often each text was measured, word by word.
+func testCountMeasuredText() map[string]int {
+	measured := map[string]int{}
+	Clay_SetMeasureTextFunction(func(text Clay_StringSlice, config *Clay_TextElementConfig, userData interface{}) Clay_Dimensions {
+		measured[string(text.Chars[:text.Length])]++
+		return testMeasureText(text, config, userData)
+	}, nil)
+	return measured
+}
+
+func TestMeasureTextCacheHitsForTextBuiltEveryFrame(t *testing.T) {
+	newTestContext(t, 800, 600)
+	measured := testCountMeasuredText()
+	layout := func() {
+		// Every frame formats new strings, whose bytes are at a different address than the frame before
+		labels := make([]ClayContainer, 10)
+		for i := range labels {
+			labels[i] = CLAY_TEXT(fmt.Sprintf("label%d", i))
+		}
+		CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{LayoutDirection: CLAY_TOP_TO_BOTTOM}}, labels...)
+	}
+	for frame := 0; frame < 10; frame++ {
+		runTestLayout(layout)
+	}
+
+	for i := 0; i < 10; i++ {
+		if count := measured[fmt.Sprintf("label%d", i)]; count != 1 {
+			t.Errorf("expected label%d to be measured once over 10 frames, got %d", i, count)
+		}
+	}
+}
+
+func TestMeasureTextCacheHitsForStaticText(t *testing.T) {
+	newTestContext(t, 800, 600)
+	measured := testCountMeasuredText()
+	textConfig := DefaultTextElementConfig()
+	for frame := 0; frame < 10; frame++ {
+		runTestLayout(func() {
+			Clay_StaticText("title", textConfig)
+			Clay_StaticText("subtitle", textConfig)
+		})
+	}
+
+	for _, text := range []string{"title", "subtitle"} {
+		if count := measured[text]; count != 1 {
+			t.Errorf("expected %q to be measured once over 10 frames, got %d", text, count)
+		}
+	}
+}
+
+func TestMeasureTextCacheSeparatesStaticStringsByLength(t *testing.T) {
+	newTestContext(t, 800, 600)
+	const text = "heading"
+	runTestLayout(func() {
+		// Both strings start at the same address, but only the first is the whole text
+		Clay_StaticText(text, DefaultTextElementConfig())
+		Clay_StaticText(text[:4], DefaultTextElementConfig())
+	})
+
+	textElements := Clay_GetCurrentContext().TextElementData
+	if textElements.Length() != 2 {
+		t.Fatalf("expected 2 text elements, got %d", textElements.Length())
+	}
+	for i, expected := range []float32{70, 40} {
+		if width := Clay__Array_Get(&textElements, int32(i)).PreferredDimensions.Width; width != expected {
+			t.Errorf("text element %d: expected width %v, got %v", i, expected, width)
+		}
+	}
+}
+
+func TestMeasureTextCacheKeysIncludeLineHeightAndWrapMode(t *testing.T) {
+	newTestContext(t, 800, 600)
+	measured := testCountMeasuredText()
+	runTestLayout(func() {
+		CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{},
+			CLAY_TEXT("text"),
+			CLAY_TEXT("text"),
+			CLAY_TEXT("text", TextWithLineHeight(30)),
+			CLAY_TEXT("text", TextWithWrapMode(CLAY_TEXT_WRAP_NONE)),
+		)
+	})
+
+	if count := measured["text"]; count != 3 {
+		t.Errorf("expected text to be measured once for each distinct line height and wrap mode, got %d", count)
+	}
+}
+
+func BenchmarkMeasureTextCacheWithTextBuiltEveryFrame(b *testing.B) {
+	newTestContext(b, 800, 600)
+	labels := make([]string, 1000)
+	for i := range labels {
+		labels[i] = fmt.Sprintf("label number %d", i)
+	}
+	textConfig := DefaultTextElementConfig()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		runTestLayout(func() {
+			for _, label := range labels {
+				// Copies the label to new memory, like formatting it again would
+				Clay_Text(string([]byte(label)), textConfig)
+			}
+		})
+	}
+}
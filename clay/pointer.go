@@ -1,5 +1,7 @@
 package clay
 
+import "github.com/zodimo/clay-go/pkg/mem"
+
 type Clay_PointerDataInteractionState uint8
 
 const (
@@ -27,7 +29,157 @@ type Clay_PointerCaptureMode uint8
 const (
 	// (default) "Capture" the pointer event and don't allow events like hover and click to pass through to elements underneath.
 	CLAY_POINTER_CAPTURE_MODE_CAPTURE Clay_PointerCaptureMode = iota
-	// CLAY_POINTER_CAPTURE_MODE_PARENT, TODO pass pointer through to attached parent
 	// Transparently pass through pointer events like hover and click to elements underneath the floating element.
 	CLAY_POINTER_CAPTURE_MODE_PASSTHROUGH
+	// Capture the pointer event, and treat the floating element as part of the element it is attached to, so that the
+	// attached parent and its ancestors are also considered hovered while the pointer is over the floating element.
+	CLAY_POINTER_CAPTURE_MODE_PARENT
 )
+
+func Clay__PointIsInsideRect(point Clay_Vector2, rect Clay_BoundingBox) bool {
+	return point.X >= rect.X && point.X <= rect.X+rect.Width && point.Y >= rect.Y && point.Y <= rect.Y+rect.Height
+}
+
+// Sets the state of the pointer for the layout computed by the most recent call to Clay_EndLayout, updating the list of
+// elements the pointer is over and calling any hover functions registered with Clay_OnHover.
+func Clay_SetPointerState(position Clay_Vector2, isPointerDown bool) {
+	currentContext := Clay_GetCurrentContext()
+	if currentContext.BooleanWarnings.MaxElementsExceeded {
+		return
+	}
+	currentContext.PointerInfo.Position = position
+	Clay__Array_Reset(&currentContext.PointerOverIds)
+	dfsBuffer := currentContext.LayoutElementChildrenBuffer
+	for rootIndex := currentContext.LayoutElementTreeRoots.Length() - 1; rootIndex >= 0; rootIndex-- {
+		Clay__Array_Reset(&dfsBuffer)
+		root := Clay__Array_Get(&currentContext.LayoutElementTreeRoots, rootIndex)
+		Clay__Array_Add(&dfsBuffer, root.LayoutElementIndex)
+		Clay__Array_Set(&currentContext.TreeNodeVisited, 0, false)
+		found := false
+		for dfsBuffer.Length() > 0 {
+			if Clay__Array_GetValue(&currentContext.TreeNodeVisited, dfsBuffer.Length()-1) {
+				Clay__Array_Pop(&dfsBuffer)
+				continue
+			}
+			Clay__Array_Set(&currentContext.TreeNodeVisited, dfsBuffer.Length()-1, true)
+			currentElementIndex := Clay__Array_GetValue(&dfsBuffer, dfsBuffer.Length()-1)
+			currentElement := Clay__Array_Get(&currentContext.LayoutElements, currentElementIndex)
+			mapItem := Clay__GetHashMapItem(currentElement.Id) // TODO think of a way around this, maybe the fact that it's essentially a binary tree limits the cost, but the worst case is not great
+			if mapItem == &Clay_LayoutElementHashMapItem_DEFAULT {
+				Clay__Array_Pop(&dfsBuffer)
+				continue
+			}
+			clipElementId := Clay__Array_GetValue(&currentContext.LayoutElementClipElementIds, currentElementIndex)
+			clipItem := Clay__GetHashMapItem(uint32(clipElementId))
+			elementBox := mapItem.BoundingBox
+			elementBox.X -= root.PointerOffset.X
+			elementBox.Y -= root.PointerOffset.Y
+			pointerInside := Clay__PointIsInsideRect(position, elementBox) && (clipElementId == 0 || Clay__PointIsInsideRect(position, clipItem.BoundingBox) || currentContext.ExternalScrollHandlingEnabled)
+			if pointerInside {
+				Clay__AddPointerOverElement(mapItem)
+				found = true
+			}
+			if Clay__ElementHasConfig(currentElement, CLAY__ELEMENT_CONFIG_TYPE_TEXT) {
+				if pointerInside && len(currentElement.ChildrenOrTextContent.TextElementData.Spans) > 0 {
+					Clay__AddPointerOverTextSpans(currentElement, elementBox, position)
+				}
+				Clay__Array_Pop(&dfsBuffer)
+				continue
+			}
+			for i := int32(currentElement.ChildrenOrTextContent.Children.Length) - 1; i >= 0; i-- {
+				Clay__Array_Add(&dfsBuffer, currentElement.ChildrenOrTextContent.Children.Elements[i])
+				Clay__Array_Set(&currentContext.TreeNodeVisited, dfsBuffer.Length()-1, false)
+			}
+		}
+
+		rootElement := Clay__Array_Get(&currentContext.LayoutElements, root.LayoutElementIndex)
+		if found && Clay__ElementHasConfig(rootElement, CLAY__ELEMENT_CONFIG_TYPE_FLOATING) {
+			pointerCaptureMode := Clay__FindElementConfigWithType(rootElement, CLAY__ELEMENT_CONFIG_TYPE_FLOATING).FloatingElementConfig.PointerCaptureMode
+			if pointerCaptureMode == CLAY_POINTER_CAPTURE_MODE_PARENT {
+				Clay__AddPointerOverAttachedParents(root.ParentId)
+			}
+			if pointerCaptureMode != CLAY_POINTER_CAPTURE_MODE_PASSTHROUGH {
+				break
+			}
+		}
+	}
+
+	if isPointerDown {
+		if currentContext.PointerInfo.State == CLAY_POINTER_DATA_PRESSED_THIS_FRAME {
+			currentContext.PointerInfo.State = CLAY_POINTER_DATA_PRESSED
+		} else if currentContext.PointerInfo.State != CLAY_POINTER_DATA_PRESSED {
+			currentContext.PointerInfo.State = CLAY_POINTER_DATA_PRESSED_THIS_FRAME
+		}
+	} else {
+		if currentContext.PointerInfo.State == CLAY_POINTER_DATA_RELEASED_THIS_FRAME {
+			currentContext.PointerInfo.State = CLAY_POINTER_DATA_RELEASED
+		} else if currentContext.PointerInfo.State != CLAY_POINTER_DATA_RELEASED {
+			currentContext.PointerInfo.State = CLAY_POINTER_DATA_RELEASED_THIS_FRAME
+		}
+	}
+}
+
+// Marks the element as being under the pointer and calls its hover function, if it isn't marked already.
+func Clay__AddPointerOverElement(mapItem *Clay_LayoutElementHashMapItem) {
+	currentContext := Clay_GetCurrentContext()
+	if Clay_PointerOver(mapItem.ElementId) {
+		return
+	}
+	if mapItem.OnHoverFunction != nil {
+		mapItem.OnHoverFunction(mapItem.ElementId, currentContext.PointerInfo, mapItem.HoverFunctionUserData)
+	}
+	Clay__Array_Add(&currentContext.PointerOverIds, mapItem.ElementId)
+}
+
+// Marks the element a CLAY_POINTER_CAPTURE_MODE_PARENT floating element is attached to, and all of its ancestors, as
+// being under the pointer, outermost first.
+func Clay__AddPointerOverAttachedParents(parentId uint32) {
+	parentItem := Clay__GetHashMapItem(parentId)
+	if parentItem == &Clay_LayoutElementHashMapItem_DEFAULT {
+		return
+	}
+	for _, ancestorId := range Clay__GetDeclarationParentIds(parentItem.ParentId) {
+		Clay__AddPointerOverElement(Clay__GetHashMapItem(ancestorId.Id))
+	}
+	Clay__AddPointerOverElement(parentItem)
+}
+
+// Returns true if the pointer was over the element with the provided id during the last call to Clay_SetPointerState.
+func Clay_PointerOver(elementId Clay_ElementId) bool { // TODO return priority for separating multiple results
+	currentContext := Clay_GetCurrentContext()
+	for i := int32(0); i < currentContext.PointerOverIds.Length(); i++ {
+		if Clay__Array_Get(&currentContext.PointerOverIds, i).Id == elementId.Id {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the ids of all elements the pointer was over during the last call to Clay_SetPointerState.
+func Clay_GetPointerOverIds() []Clay_ElementId {
+	currentContext := Clay_GetCurrentContext()
+	return mem.MArray_GetAll(&currentContext.PointerOverIds)
+}
+
+// Returns true if the pointer is over the currently open element. Must be called during element declaration.
+func Clay_Hovered() bool {
+	currentContext := Clay_GetCurrentContext()
+	if currentContext.BooleanWarnings.MaxElementsExceeded {
+		return false
+	}
+	openLayoutElement := Clay__GetOpenLayoutElement()
+	return Clay_PointerOver(Clay_ElementId{Id: openLayoutElement.Id})
+}
+
+// Registers a function that is called from Clay_SetPointerState while the pointer is over the currently open element.
+// Must be called during element declaration.
+func Clay_OnHover(onHoverFunction func(elementId Clay_ElementId, pointerInfo Clay_PointerData, userData any), userData any) {
+	currentContext := Clay_GetCurrentContext()
+	if currentContext.BooleanWarnings.MaxElementsExceeded {
+		return
+	}
+	openLayoutElement := Clay__GetOpenLayoutElement()
+	hashMapItem := Clay__GetHashMapItem(openLayoutElement.Id)
+	hashMapItem.OnHoverFunction = onHoverFunction
+	hashMapItem.HoverFunctionUserData = userData
+}
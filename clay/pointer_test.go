@@ -0,0 +1,77 @@
+package clay
+
+import (
+	"testing"
+)
+
+func TestPointerCaptureModes(t *testing.T) {
+	declareLayout := func(captureMode Clay_PointerCaptureMode, onTriggerHover func()) {
+		CLAY_ROOT(CLAY_ID("outer"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{LayoutDirection: CLAY_TOP_TO_BOTTOM}},
+			CLAY(CLAY_ID("trigger"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(100), Height: CLAY_SIZING_FIXED(30)}}},
+				&claContainer{wrapper: func() {
+					Clay_OnHover(func(elementId Clay_ElementId, pointerInfo Clay_PointerData, userData any) {
+						onTriggerHover()
+					}, nil)
+				}},
+				CLAY(CLAY_ID("popover"), Clay_ElementDeclaration{
+					Layout: Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(100), Height: CLAY_SIZING_FIXED(100)}},
+					Floating: Clay_FloatingElementConfig{
+						AttachTo:           CLAY_ATTACH_TO_PARENT,
+						AttachPoints:       Clay_FloatingAttachPoints{Element: CLAY_ATTACH_POINT_LEFT_TOP, Parent: CLAY_ATTACH_POINT_LEFT_BOTTOM},
+						PointerCaptureMode: captureMode,
+					},
+				}),
+			),
+			CLAY(CLAY_ID("content"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(800), Height: CLAY_SIZING_FIXED(500)}}}),
+		)
+	}
+
+	tests := []struct {
+		name                string
+		captureMode         Clay_PointerCaptureMode
+		hovered, notHovered []string
+		triggerHoverCalls   int
+	}{
+		{"capture", CLAY_POINTER_CAPTURE_MODE_CAPTURE, []string{"popover"}, []string{"trigger", "content", "outer"}, 0},
+		{"passthrough", CLAY_POINTER_CAPTURE_MODE_PASSTHROUGH, []string{"popover", "content", "outer"}, []string{"trigger"}, 0},
+		{"parent", CLAY_POINTER_CAPTURE_MODE_PARENT, []string{"popover", "trigger", "outer"}, []string{"content"}, 1},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			newTestContext(t, 800, 600)
+			triggerHoverCalls := 0
+			runTestLayout(func() { declareLayout(test.captureMode, func() { triggerHoverCalls++ }) })
+
+			if got := testBoundingBox(t, "popover"); got != (Clay_BoundingBox{X: 0, Y: 30, Width: 100, Height: 100}) {
+				t.Fatalf("unexpected popover bounding box %v", got)
+			}
+			Clay_SetPointerState(Clay_Vector2{X: 50, Y: 60}, false)
+			for _, id := range test.hovered {
+				if !Clay_PointerOver(CLAY_ID(id)) {
+					t.Errorf("expected %s to be hovered", id)
+				}
+			}
+			for _, id := range test.notHovered {
+				if Clay_PointerOver(CLAY_ID(id)) {
+					t.Errorf("expected %s not to be hovered", id)
+				}
+			}
+			if triggerHoverCalls != test.triggerHoverCalls {
+				t.Errorf("expected the trigger hover function to be called %d times, got %d", test.triggerHoverCalls, triggerHoverCalls)
+			}
+		})
+	}
+
+	t.Run("pointer over the trigger itself", func(t *testing.T) {
+		newTestContext(t, 800, 600)
+		runTestLayout(func() { declareLayout(CLAY_POINTER_CAPTURE_MODE_PARENT, func() {}) })
+		Clay_SetPointerState(Clay_Vector2{X: 50, Y: 10}, false)
+		hoveredIds := Clay_GetPointerOverIds()
+		if len(hoveredIds) != 3 {
+			t.Fatalf("expected the root container, outer and trigger to be hovered, got %d ids", len(hoveredIds))
+		}
+		if hoveredIds[2].Id != CLAY_ID("trigger").Id {
+			t.Errorf("expected the innermost hovered element to be the trigger")
+		}
+	})
+}
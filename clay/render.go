@@ -17,6 +17,10 @@ const (
 	CLAY_RENDER_COMMAND_TYPE_SCISSOR_START
 	CLAY_RENDER_COMMAND_TYPE_SCISSOR_END
 	CLAY_RENDER_COMMAND_TYPE_CUSTOM
+	CLAY_RENDER_COMMAND_TYPE_TEXT_INPUT
+	CLAY_RENDER_COMMAND_TYPE_TRANSFORM_START
+	CLAY_RENDER_COMMAND_TYPE_TRANSFORM_END
+	CLAY_RENDER_COMMAND_TYPE_SHADOW
 )
 
 func (t Clay_RenderCommandType) String() string {
@@ -29,6 +33,10 @@ func (t Clay_RenderCommandType) String() string {
 		"SCISSOR_START",
 		"SCISSOR_END",
 		"CUSTOM",
+		"TEXT_INPUT",
+		"TRANSFORM_START",
+		"TRANSFORM_END",
+		"SHADOW",
 	}[t]
 }
 
@@ -53,6 +61,10 @@ type Clay_RenderCommand struct {
 	// CLAY_RENDER_COMMAND_TYPE_SCISSOR_START - The renderer should begin clipping all future draw commands, only rendering content that falls within the provided boundingBox.
 	// CLAY_RENDER_COMMAND_TYPE_SCISSOR_END - The renderer should finish any previously active clipping, and begin rendering elements in full again.
 	// CLAY_RENDER_COMMAND_TYPE_CUSTOM - The renderer should provide a custom implementation for handling this render command based on its .customData
+	// CLAY_RENDER_COMMAND_TYPE_TEXT_INPUT - The renderer should draw the selection and caret of a text input, and handle its input events. The text itself follows as CLAY_RENDER_COMMAND_TYPE_TEXT commands.
+	// CLAY_RENDER_COMMAND_TYPE_TRANSFORM_START - The renderer should begin transforming and fading all future draw commands as described by .transform, on top of any transforms that are already active.
+	// CLAY_RENDER_COMMAND_TYPE_TRANSFORM_END - The renderer should finish the most recently started transform.
+	// CLAY_RENDER_COMMAND_TYPE_SHADOW - The renderer should draw a blurred shadow of the rounded bounding box, behind the element or inside of it when .shadow.inset is set.
 	CommandType Clay_RenderCommandType
 }
 
@@ -74,7 +86,7 @@ func (b *Clay_BoundingBox) String() string {
 type Clay_RenderData struct {
 	// Render command data when commandType == CLAY_RENDER_COMMAND_TYPE_RECTANGLE
 	Rectangle Clay_RectangleRenderData
-	// Render command data when commandType == CLAY_RENDER_COMMAND_TYPE_TEXT
+	// Render command data when commandType == CLAY_RENDER_COMMAND_TYPE_TEXT or CLAY_RENDER_COMMAND_TYPE_TEXT_INPUT
 	Text Clay_TextRenderData
 	// Render command data when commandType == CLAY_RENDER_COMMAND_TYPE_IMAGE
 	Image Clay_ImageRenderData
@@ -84,6 +96,10 @@ type Clay_RenderData struct {
 	Border Clay_BorderRenderData
 	// Render command data when commandType == CLAY_RENDER_COMMAND_TYPE_SCISSOR_START|END
 	Clip Clay_ClipRenderData
+	// Render command data when commandType == CLAY_RENDER_COMMAND_TYPE_TRANSFORM_START
+	Transform Clay_TransformRenderData
+	// Render command data when commandType == CLAY_RENDER_COMMAND_TYPE_SHADOW
+	Shadow Clay_ShadowRenderData
 }
 
 func (d *Clay_RenderData) String(commandType Clay_RenderCommandType) string {
@@ -92,7 +108,7 @@ func (d *Clay_RenderData) String(commandType Clay_RenderCommandType) string {
 		return "NONE"
 	case CLAY_RENDER_COMMAND_TYPE_RECTANGLE:
 		return d.Rectangle.String()
-	case CLAY_RENDER_COMMAND_TYPE_TEXT:
+	case CLAY_RENDER_COMMAND_TYPE_TEXT, CLAY_RENDER_COMMAND_TYPE_TEXT_INPUT:
 		return d.Text.String()
 	case CLAY_RENDER_COMMAND_TYPE_IMAGE:
 		return d.Image.String()
@@ -104,6 +120,12 @@ func (d *Clay_RenderData) String(commandType Clay_RenderCommandType) string {
 		return d.Clip.String()
 	case CLAY_RENDER_COMMAND_TYPE_SCISSOR_END:
 		return d.Clip.String()
+	case CLAY_RENDER_COMMAND_TYPE_TRANSFORM_START:
+		return d.Transform.String()
+	case CLAY_RENDER_COMMAND_TYPE_TRANSFORM_END:
+		return "TRANSFORM_END"
+	case CLAY_RENDER_COMMAND_TYPE_SHADOW:
+		return d.Shadow.String()
 	default:
 		return fmt.Sprintf("Unknown command type: %d", commandType)
 	}
@@ -113,6 +135,8 @@ func (d *Clay_RenderData) String(commandType Clay_RenderCommandType) string {
 type Clay_RectangleRenderData struct {
 	// The solid background color to fill this rectangle with. Conventionally represented as 0-255 for each channel, but interpretation is up to the renderer.
 	BackgroundColor Clay_Color
+	// Fills this rectangle with a gradient instead of the background color, when its type isn't CLAY_GRADIENT_TYPE_NONE.
+	BackgroundGradient Clay_Gradient
 	// Controls the "radius", or corner rounding of elements, including rectangles, borders and images.
 	// The rounding is determined by drawing a circle inset into the element corner by (radius, radius) pixels.
 	CornerRadius Clay_CornerRadius
@@ -224,3 +248,49 @@ func (d *Clay_ClipRenderData) String() string {
 	}
 	return string(json)
 }
+
+// Render command data when commandType == CLAY_RENDER_COMMAND_TYPE_TRANSFORM_START
+type Clay_TransformRenderData struct {
+	// Moves everything drawn until the matching TRANSFORM_END, after it has been scaled and rotated.
+	Translation Clay_Vector2
+	// Scales everything drawn until the matching TRANSFORM_END around Origin.
+	Scale Clay_Vector2
+	// Rotates everything drawn until the matching TRANSFORM_END clockwise around Origin, in radians.
+	Rotation float32
+	// The point that is scaled and rotated around, as a fraction of the size of the bounding box of this command.
+	Origin Clay_Vector2
+	// Multiplies the opacity of everything drawn until the matching TRANSFORM_END, between 0 and 1.
+	Opacity float32
+}
+
+func (d *Clay_TransformRenderData) String() string {
+	json, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("Error marshalling transform render data: %v", err)
+	}
+	return string(json)
+}
+
+// Render command data when commandType == CLAY_RENDER_COMMAND_TYPE_SHADOW
+type Clay_ShadowRenderData struct {
+	// The color of the shadow where it isn't blurred.
+	Color Clay_Color
+	// Moves the shadow relative to the bounding box of this command.
+	Offset Clay_Vector2
+	// The distance over which the edges of the shadow fade out.
+	BlurRadius float32
+	// Grows the shadow on every side before it is blurred, or shrinks it when negative.
+	Spread float32
+	// Draws the shadow inside the bounding box, around a hole that is moved by Offset and shrunk by Spread.
+	Inset bool
+	// Controls the rounding of the corners of the element that casts the shadow.
+	CornerRadius Clay_CornerRadius
+}
+
+func (d *Clay_ShadowRenderData) String() string {
+	json, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return fmt.Sprintf("Error marshalling shadow render data: %v", err)
+	}
+	return string(json)
+}
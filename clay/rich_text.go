@@ -0,0 +1,309 @@
+package clay
+
+import "github.com/zodimo/clay-go/pkg/mem"
+
+// The position of a measured word within the spans of a rich text element.
+type Clay__RichTextCursor struct {
+	SpanIndex int32
+	WordIndex int32 // Index in currentContext.MeasuredWords, or -1 past the last word of the last span.
+}
+
+// Adds a rich text element with the provided id, or an id generated from its position within its parent if the id is
+// zero.
+func Clay__OpenRichTextElement(elementId Clay_ElementId, spans []Clay_TextSpan, textConfig *Clay_TextElementConfig) {
+	textElement := Clay__AddTextLayoutElement(elementId, textConfig, Clay__TextElementData{Spans: spans})
+	if textElement == nil {
+		return
+	}
+
+	preferredDimensions := Clay_Dimensions{Width: Clay__WrapRichText(spans, CLAY__MAXFLOAT, 0, nil)}
+	for i := range spans {
+		preferredDimensions.Height = CLAY__MAX(preferredDimensions.Height, Clay__MeasureTextCached(&spans[i].Text, spans[i].Config).UnwrappedDimensions.Height)
+	}
+	textElement.Dimensions = preferredDimensions
+	if textConfig.LineHeight > 0 {
+		textElement.Dimensions.Height = float32(textConfig.LineHeight)
+	}
+	// Wrapping every word onto its own line measures the widest word
+	textElement.MinDimensions = Clay_Dimensions{
+		Width:  Clay__WrapRichText(spans, 0, 0, nil),
+		Height: textElement.Dimensions.Height,
+	}
+	if textConfig.Overflow != CLAY_TEXT_OVERFLOW_CLIP {
+		textElement.MinDimensions.Width = CLAY__MIN(textElement.MinDimensions.Width, Clay__MeasureTextCached(&CLAY__ELLIPSIS, textConfig).UnwrappedDimensions.Width)
+	}
+
+	textElement.ChildrenOrTextContent.TextElementData.PreferredDimensions = preferredDimensions
+}
+
+// Returns the first measured word of the span at spanIndex, or of the first span after it that has any words.
+func Clay__RichTextSeek(spans []Clay_TextSpan, spanIndex int32) Clay__RichTextCursor {
+	for ; spanIndex < int32(len(spans)); spanIndex++ {
+		measured := Clay__MeasureTextCached(&spans[spanIndex].Text, spans[spanIndex].Config)
+		if measured != &Clay__MeasureTextCacheItem_DEFAULT && measured.MeasuredWordsStartIndex != -1 {
+			return Clay__RichTextCursor{SpanIndex: spanIndex, WordIndex: measured.MeasuredWordsStartIndex}
+		}
+	}
+	return Clay__RichTextCursor{SpanIndex: int32(len(spans)), WordIndex: -1}
+}
+
+func Clay__RichTextNextWord(spans []Clay_TextSpan, cursor Clay__RichTextCursor) Clay__RichTextCursor {
+	word := Clay__Array_Get(&Clay_GetCurrentContext().MeasuredWords, cursor.WordIndex)
+	if word.Next != -1 {
+		return Clay__RichTextCursor{SpanIndex: cursor.SpanIndex, WordIndex: word.Next}
+	}
+	return Clay__RichTextSeek(spans, cursor.SpanIndex+1)
+}
+
+func Clay__RichTextWordChars(spans []Clay_TextSpan, cursor Clay__RichTextCursor) []byte {
+	word := Clay__Array_Get(&Clay_GetCurrentContext().MeasuredWords, cursor.WordIndex)
+	return spans[cursor.SpanIndex].Text.Chars[word.StartOffset : word.StartOffset+word.Length]
+}
+
+func Clay__RichTextWordEndsWithSpace(spans []Clay_TextSpan, cursor Clay__RichTextCursor) bool {
+	word := Clay__Array_Get(&Clay_GetCurrentContext().MeasuredWords, cursor.WordIndex)
+	return word.Length > 0 && spans[cursor.SpanIndex].Text.Chars[word.StartOffset+word.Length-1] == ' '
+}
+
+// Returns the byte offset of the word at cursor within the text of all spans joined together.
+func Clay__RichTextOffset(spans []Clay_TextSpan, cursor Clay__RichTextCursor) int32 {
+	offset := Clay__TextSpanOffset(spans, cursor.SpanIndex)
+	if cursor.WordIndex != -1 {
+		offset += Clay__Array_Get(&Clay_GetCurrentContext().MeasuredWords, cursor.WordIndex).StartOffset
+	}
+	return offset
+}
+
+// Returns the byte offset of the start of the span at spanIndex within the text of all spans joined together.
+func Clay__TextSpanOffset(spans []Clay_TextSpan, spanIndex int32) int32 {
+	offset := int32(0)
+	for i := int32(0); i < spanIndex && i < int32(len(spans)); i++ {
+		offset += spans[i].Text.Length
+	}
+	return offset
+}
+
+// Returns true if the measured word is a newline, which is measured as a word with no characters.
+func Clay__RichTextWordIsNewline(cursor Clay__RichTextCursor) bool {
+	return cursor.WordIndex != -1 && Clay__Array_Get(&Clay_GetCurrentContext().MeasuredWords, cursor.WordIndex).Length == 0
+}
+
+// Returns the end and the last word of the run of words starting at cursor that can't be broken onto separate lines,
+// along with its width. Words within a span end at line break opportunities, while the last word of a span only ends
+// at one if the line can be broken between it and the first word of the next span. A word that continues into the
+// next span, e.g. the "bold" span of "**bold**ness", is kept together with the rest of the word.
+func Clay__RichTextScanUnbreakable(spans []Clay_TextSpan, cursor Clay__RichTextCursor) (Clay__RichTextCursor, Clay__RichTextCursor, float32) {
+	lastWord := cursor
+	width := float32(0)
+	for cursor.WordIndex != -1 {
+		word := Clay__Array_Get(&Clay_GetCurrentContext().MeasuredWords, cursor.WordIndex)
+		width += word.Width
+		lastWord = cursor
+		cursor = Clay__RichTextNextWord(spans, cursor)
+		if word.Next != -1 || cursor.WordIndex == -1 || Clay__RichTextWordIsNewline(cursor) {
+			break
+		}
+		if Clay__IsLineBreakBetween(Clay__RichTextWordChars(spans, lastWord), Clay__RichTextWordChars(spans, cursor)) {
+			break
+		}
+	}
+	return cursor, lastWord, width
+}
+
+// Breaks the spans of a rich text element into lines that are no wider than maxWidth where possible, and returns the
+// width of the widest line. When wrappedLines is not nil the lines and their fragments are added to it with the
+// provided line height, otherwise the lines are only measured.
+func Clay__WrapRichText(spans []Clay_TextSpan, maxWidth float32, lineHeight float32, wrappedLines *Clay__Slice[Clay__WrappedTextLine]) float32 {
+	currentContext := Clay_GetCurrentContext()
+	widestLine := float32(0)
+	cursor := Clay__RichTextSeek(spans, 0)
+	lineStartOffset := Clay__RichTextOffset(spans, cursor)
+	for cursor.WordIndex != -1 {
+		lineStart := cursor
+		startOffset := lineStartOffset
+		lastWord := cursor
+		lineWidth := float32(0)
+		for cursor.WordIndex != -1 && !Clay__RichTextWordIsNewline(cursor) {
+			end, endWord, width := Clay__RichTextScanUnbreakable(spans, cursor)
+			// Only word on the line is too large, just render it anyway
+			if cursor != lineStart && lineWidth+width > maxWidth {
+				break
+			}
+			lineWidth += width
+			lastWord = endWord
+			cursor = end
+		}
+		lineEnd := cursor
+		// The next line starts at the next word, or after the newline, which is where a measured newline word starts
+		lineStartOffset = Clay__RichTextOffset(spans, cursor)
+		if Clay__RichTextWordIsNewline(cursor) {
+			cursor = Clay__RichTextNextWord(spans, cursor)
+		}
+		if lineEnd != lineStart && Clay__RichTextWordEndsWithSpace(spans, lastWord) {
+			lineWidth -= Clay__RichTextSpaceWidth(&spans[lastWord.SpanIndex])
+		}
+		widestLine = CLAY__MAX(widestLine, lineWidth)
+
+		if wrappedLines == nil {
+			continue
+		}
+		if currentContext.WrappedTextLines.Length() > currentContext.WrappedTextLines.Capacity()-1 {
+			break
+		}
+		Clay__Array_Add(&currentContext.WrappedTextLines, Clay__WrappedTextLine{
+			Dimensions:  Clay_Dimensions{Width: lineWidth, Height: lineHeight},
+			Fragments:   Clay__AddRichTextFragments(spans, lineStart, lineEnd),
+			StartOffset: startOffset,
+		})
+		Clay__Slice_Grow(wrappedLines, 1)
+	}
+	return widestLine
+}
+
+// Adds a fragment to currentContext.WrappedTextFragments for each span with words from lineStart up to lineEnd, and
+// returns them as a slice. Trailing whitespace is left out of the final fragment.
+func Clay__AddRichTextFragments(spans []Clay_TextSpan, lineStart Clay__RichTextCursor, lineEnd Clay__RichTextCursor) Clay__Slice[Clay__WrappedTextFragment] {
+	currentContext := Clay_GetCurrentContext()
+	fragmentsStart := currentContext.WrappedTextFragments.Length()
+	fragments := NewClay__Slice(mem.MArray_GetSlice(&currentContext.WrappedTextFragments, fragmentsStart, fragmentsStart))
+	var fragment *Clay__WrappedTextFragment
+	var fragmentStartOffset int32
+	offsetX := float32(0)
+	for cursor := lineStart; cursor != lineEnd; cursor = Clay__RichTextNextWord(spans, cursor) {
+		word := Clay__Array_Get(&currentContext.MeasuredWords, cursor.WordIndex)
+		if fragment == nil || fragment.SpanIndex != cursor.SpanIndex {
+			if currentContext.WrappedTextFragments.Length() > currentContext.WrappedTextFragments.Capacity()-1 {
+				break
+			}
+			fragment = Clay__Array_Add(&currentContext.WrappedTextFragments, Clay__WrappedTextFragment{
+				SpanIndex:   cursor.SpanIndex,
+				StartOffset: word.StartOffset,
+				Offset:      Clay_Vector2{X: offsetX},
+			})
+			Clay__Slice_Grow(&fragments, 1)
+			fragmentStartOffset = word.StartOffset
+		}
+		fragment.Line.Length = word.StartOffset + word.Length - fragmentStartOffset
+		fragment.Line.Chars = spans[cursor.SpanIndex].Text.Chars[fragmentStartOffset : fragmentStartOffset+fragment.Line.Length]
+		fragment.Dimensions.Width += word.Width
+		offsetX += word.Width
+	}
+	if fragment != nil && fragment.Line.Length > 0 && fragment.Line.Chars[fragment.Line.Length-1] == ' ' {
+		fragment.Line.Length--
+		fragment.Line.Chars = fragment.Line.Chars[:fragment.Line.Length]
+		fragment.Dimensions.Width -= Clay__RichTextSpaceWidth(&spans[fragment.SpanIndex])
+	}
+
+	// Line up the baselines of the fragments
+	baseline := float32(0)
+	for i := int32(0); i < fragments.Length(); i++ {
+		fragment := Clay__Slice_Get(&fragments, i)
+		span := &spans[fragment.SpanIndex]
+		fragment.Dimensions.Height = Clay__MeasureTextCached(&span.Text, span.Config).UnwrappedDimensions.Height
+		baseline = CLAY__MAX(baseline, Clay__RichTextFragmentAscent(span, fragment))
+	}
+	for i := int32(0); i < fragments.Length(); i++ {
+		fragment := Clay__Slice_Get(&fragments, i)
+		fragment.Offset.Y = baseline - Clay__RichTextFragmentAscent(&spans[fragment.SpanIndex], fragment)
+	}
+	return fragments
+}
+
+// Returns the distance from the top of the fragment to its baseline, which is the largest ascent of the words of its
+// span, or the bottom of the fragment without a Clay__MeasureTextMetricsFunction.
+func Clay__RichTextFragmentAscent(span *Clay_TextSpan, fragment *Clay__WrappedTextFragment) float32 {
+	if MeasureTextMetricsFunction == nil || fragment.Line.Length == 0 {
+		return fragment.Dimensions.Height
+	}
+	return Clay__MeasureTextCached(&span.Text, span.Config).Ascent
+}
+
+// Returns the distance from the top of the wrapped line to the baseline shared by its fragments.
+func Clay__RichTextLineBaseline(textElementData *Clay__TextElementData, wrappedLine *Clay__WrappedTextLine) float32 {
+	baseline := float32(0)
+	for i := int32(0); i < wrappedLine.Fragments.Length(); i++ {
+		fragment := Clay__Slice_Get(&wrappedLine.Fragments, i)
+		baseline = CLAY__MAX(baseline, fragment.Offset.Y+Clay__RichTextFragmentAscent(&textElementData.Spans[fragment.SpanIndex], fragment))
+	}
+	return baseline
+}
+
+func Clay__RichTextSpaceWidth(span *Clay_TextSpan) float32 {
+	return Clay__MeasureTextCached(&span.Text, span.Config).SpaceWidth
+}
+
+// Returns the horizontal offset of a wrapped line of text from the left edge of its text element.
+func Clay__TextLineOffset(textConfig *Clay_TextElementConfig, elementWidth float32, lineWidth float32) float32 {
+	switch textConfig.TextAlignment {
+	case CLAY_TEXT_ALIGN_CENTER:
+		return (elementWidth - lineWidth) / 2
+	case CLAY_TEXT_ALIGN_RIGHT:
+		return elementWidth - lineWidth
+	}
+	return 0
+}
+
+// Adds a text render command for each fragment of a wrapped line of rich text, positioned relative to the provided
+// position of the line.
+func Clay__AddRichTextLineRenderCommands(element *Clay_LayoutElement, wrappedLine *Clay__WrappedTextLine, linePosition Clay_Vector2, zIndex int16) {
+	textElementData := element.ChildrenOrTextContent.TextElementData
+	for i := int32(0); i < wrappedLine.Fragments.Length(); i++ {
+		fragment := Clay__Slice_Get(&wrappedLine.Fragments, i)
+		if fragment.Line.Length == 0 {
+			continue
+		}
+		span := &textElementData.Spans[fragment.SpanIndex]
+		Clay__AddRenderCommand(Clay_RenderCommand{
+			BoundingBox: Clay_BoundingBox{
+				X:      linePosition.X + fragment.Offset.X,
+				Y:      linePosition.Y + fragment.Offset.Y,
+				Width:  fragment.Dimensions.Width,
+				Height: fragment.Dimensions.Height,
+			},
+			RenderData: Clay_RenderData{
+				Text: Clay_TextRenderData{
+					StringContents: Clay_StringSlice{
+						Length:    fragment.Line.Length,
+						Chars:     fragment.Line.Chars,
+						BaseChars: span.Text.Chars,
+					},
+					TextColor:     span.Config.TextColor,
+					FontId:        span.Config.FontId,
+					FontSize:      span.Config.FontSize,
+					LetterSpacing: span.Config.LetterSpacing,
+					LineHeight:    span.Config.LineHeight,
+				}},
+			UserData:    span.Config.UserData,
+			Id:          Clay__HashNumber(uint32(i), Clay__HashNumber(uint32(fragment.SpanIndex), element.Id).Id).Id,
+			ZIndex:      zIndex,
+			CommandType: CLAY_RENDER_COMMAND_TYPE_TEXT,
+		})
+	}
+}
+
+// Marks the spans with an id of a rich text element as being under the pointer when the position is inside one of
+// their fragments. elementBox is the bounding box of the element in pointer coordinates.
+func Clay__AddPointerOverTextSpans(element *Clay_LayoutElement, elementBox Clay_BoundingBox, position Clay_Vector2) {
+	currentContext := Clay_GetCurrentContext()
+	textConfig := Clay__FindElementConfigWithType(element, CLAY__ELEMENT_CONFIG_TYPE_TEXT).TextElementConfig
+	textElementData := element.ChildrenOrTextContent.TextElementData
+	lineHeight := Clay__TextElementLineHeight(element)
+	for lineIndex := int32(0); lineIndex < textElementData.WrappedLines.Length(); lineIndex++ {
+		wrappedLine := Clay__Slice_Get(&textElementData.WrappedLines, lineIndex)
+		lineX := elementBox.X + Clay__TextLineOffset(textConfig, elementBox.Width, wrappedLine.Dimensions.Width)
+		lineY := elementBox.Y + float32(lineIndex)*lineHeight
+		for i := int32(0); i < wrappedLine.Fragments.Length(); i++ {
+			fragment := Clay__Slice_Get(&wrappedLine.Fragments, i)
+			span := &textElementData.Spans[fragment.SpanIndex]
+			fragmentBox := Clay_BoundingBox{X: lineX + fragment.Offset.X, Y: lineY, Width: fragment.Dimensions.Width, Height: lineHeight}
+			// The render commands of the fragments were mirrored along with the element
+			if currentContext.RTLEnabled {
+				fragmentBox.X = 2*elementBox.X + elementBox.Width - fragmentBox.X - fragmentBox.Width
+			}
+			if span.Id.Id == 0 || !Clay__PointIsInsideRect(position, fragmentBox) || Clay_PointerOver(span.Id) {
+				continue
+			}
+			Clay__Array_Add(&currentContext.PointerOverIds, span.Id)
+		}
+	}
+}
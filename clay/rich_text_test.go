@@ -0,0 +1,177 @@
+package clay
+
+import (
+	"testing"
+)
+
+type testTextCommand struct {
+	text   string
+	box    Clay_BoundingBox
+	fontId uint16
+}
+
+// Lays out rich text inside a parent of the provided width and returns the emitted text commands.
+func testRichTextCommands(t *testing.T, width float32, spans []TextSpan, options ...TextOption) []testTextCommand {
+	t.Helper()
+	commands := runTestLayout(func() {
+		CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{},
+			CLAY(CLAY_ID("parent"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(width)}}},
+				CLAY_RICH_TEXT(spans, options...),
+			),
+		)
+	})
+	textCommands := []testTextCommand{}
+	for _, command := range commands {
+		if command.CommandType != CLAY_RENDER_COMMAND_TYPE_TEXT {
+			continue
+		}
+		textData := command.RenderData.Text
+		textCommands = append(textCommands, testTextCommand{
+			text:   textData.StringContents.String(),
+			box:    command.BoundingBox,
+			fontId: textData.FontId,
+		})
+	}
+	return textCommands
+}
+
+func testExpectTextCommands(t *testing.T, expected []testTextCommand, commands []testTextCommand) {
+	t.Helper()
+	if len(expected) != len(commands) {
+		t.Fatalf("expected %d text commands, got %d: %v", len(expected), len(commands), commands)
+	}
+	for i := range expected {
+		if expected[i] != commands[i] {
+			t.Errorf("text command %d: expected %v, got %v", i, expected[i], commands[i])
+		}
+	}
+}
+
+func TestRichTextWrapsSpansTogether(t *testing.T) {
+	newTestContext(t, 800, 600)
+	commands := testRichTextCommands(t, 100, []TextSpan{
+		CLAY_TEXT_SPAN("Hello "),
+		CLAY_TEXT_SPAN("bold", TextWithFontId(1)),
+		CLAY_TEXT_SPAN(" world"),
+	})
+	testExpectTextCommands(t, []testTextCommand{
+		{text: "Hello", box: Clay_BoundingBox{X: 0, Y: 0, Width: 50, Height: 20}},
+		{text: "bold", box: Clay_BoundingBox{X: 0, Y: 20, Width: 40, Height: 20}, fontId: 1},
+		{text: " world", box: Clay_BoundingBox{X: 40, Y: 20, Width: 60, Height: 20}},
+	}, commands)
+	testExpectBoundingBox(t, "parent", Clay_BoundingBox{X: 0, Y: 0, Width: 100, Height: 40})
+}
+
+func TestRichTextKeepsWordsSplitAcrossSpansTogether(t *testing.T) {
+	newTestContext(t, 800, 600)
+	commands := testRichTextCommands(t, 80, []TextSpan{
+		CLAY_TEXT_SPAN("un"),
+		CLAY_TEXT_SPAN("break", TextWithFontId(1)),
+		CLAY_TEXT_SPAN("able words"),
+	})
+	testExpectTextCommands(t, []testTextCommand{
+		{text: "un", box: Clay_BoundingBox{X: 0, Y: 0, Width: 20, Height: 20}},
+		{text: "break", box: Clay_BoundingBox{X: 20, Y: 0, Width: 50, Height: 20}, fontId: 1},
+		{text: "able", box: Clay_BoundingBox{X: 70, Y: 0, Width: 40, Height: 20}},
+		{text: "words", box: Clay_BoundingBox{X: 0, Y: 20, Width: 50, Height: 20}},
+	}, commands)
+}
+
+func TestRichTextSizing(t *testing.T) {
+	newTestContext(t, 800, 600)
+	runTestLayout(func() {
+		CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{},
+			CLAY(CLAY_ID("parent"), Clay_ElementDeclaration{},
+				CLAY_RICH_TEXT([]TextSpan{
+					CLAY_TEXT_SPAN("one\n"),
+					CLAY_TEXT_SPAN("two three", TextWithColor(Clay_Color{R: 255, A: 255})),
+				}),
+			),
+		)
+	})
+	testExpectBoundingBox(t, "parent", Clay_BoundingBox{X: 0, Y: 0, Width: 90, Height: 40})
+}
+
+func TestRichTextAlignment(t *testing.T) {
+	newTestContext(t, 800, 600)
+	commands := testRichTextCommands(t, 60, []TextSpan{
+		CLAY_TEXT_SPAN("ab "),
+		CLAY_TEXT_SPAN("cd "),
+		CLAY_TEXT_SPAN("efgh", TextWithFontId(1)),
+	}, TextWithTextAlignment(CLAY_TEXT_ALIGN_RIGHT))
+	testExpectTextCommands(t, []testTextCommand{
+		{text: "ab ", box: Clay_BoundingBox{X: 10, Y: 0, Width: 30, Height: 20}},
+		{text: "cd", box: Clay_BoundingBox{X: 40, Y: 0, Width: 20, Height: 20}},
+		{text: "efgh", box: Clay_BoundingBox{X: 20, Y: 20, Width: 40, Height: 20}, fontId: 1},
+	}, commands)
+}
+
+func TestRichTextMaxLinesEllipsis(t *testing.T) {
+	newTestContext(t, 800, 600)
+	commands := testRichTextCommands(t, 100, []TextSpan{
+		CLAY_TEXT_SPAN("one two "),
+		CLAY_TEXT_SPAN("three four", TextWithFontId(1)),
+	}, TextWithMaxLines(1), TextWithOverflow(CLAY_TEXT_OVERFLOW_ELLIPSIS))
+	testExpectTextCommands(t, []testTextCommand{
+		{text: "one two…", box: Clay_BoundingBox{X: 0, Y: 0, Width: 100, Height: 20}},
+	}, commands)
+}
+
+func TestRichTextSpanHitTesting(t *testing.T) {
+	newTestContext(t, 800, 600)
+	layout := func() {
+		CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{},
+			CLAY(CLAY_ID("parent"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(100)}}},
+				CLAY_RICH_TEXT([]TextSpan{
+					CLAY_TEXT_SPAN("Read the "),
+					CLAY_TEXT_SPAN_WITH_ID(CLAY_ID("docs-link"), "docs page", TextWithColor(Clay_Color{B: 255, A: 255})),
+				}),
+			),
+		)
+	}
+	// "Read the" fits on the first line, "docs page" wraps onto the second
+	runTestLayout(layout)
+
+	tests := []struct {
+		name     string
+		position Clay_Vector2
+		expected bool
+	}{
+		{"plain span", Clay_Vector2{X: 10, Y: 10}, false},
+		{"link span", Clay_Vector2{X: 30, Y: 30}, true},
+		{"past the end of the link", Clay_Vector2{X: 95, Y: 30}, false},
+	}
+	for _, test := range tests {
+		Clay_SetPointerState(test.position, false)
+		if Clay_PointerOver(CLAY_ID("docs-link")) != test.expected {
+			t.Errorf("%s: expected Clay_PointerOver(docs-link) to be %v", test.name, test.expected)
+		}
+		if !Clay_PointerOver(CLAY_ID("parent")) {
+			t.Errorf("%s: expected the pointer to be over the parent", test.name)
+		}
+	}
+}
+
+func TestRichTextUsesMeasureTextCache(t *testing.T) {
+	newTestContext(t, 800, 600)
+	measureCount := 0
+	Clay_SetMeasureTextMetricsFunction(func(text Clay_StringSlice, config *Clay_TextElementConfig, userData interface{}) Clay_TextMetrics {
+		measureCount++
+		return testMeasureTextMetrics(text, config, userData)
+	}, nil)
+	spans := []TextSpan{
+		CLAY_TEXT_SPAN("Large title ", TextWithFontSize(40)),
+		CLAY_TEXT_SPAN("and small print", TextWithFontSize(20)),
+	}
+	frames := [][]testTextCommand{}
+	for frame := 0; frame < 2; frame++ {
+		measureCount = 0
+		frames = append(frames, testRichTextCommands(t, 300, spans))
+	}
+
+	// The spans were measured in the first frame, so the second frame only reads the cache
+	if measureCount != 0 {
+		t.Errorf("expected no measurements in the second frame, got %d", measureCount)
+	}
+	testExpectTextCommands(t, frames[0], frames[1])
+}
@@ -0,0 +1,69 @@
+package clay
+
+// Swaps the left and right corners of a corner radius.
+func Clay__MirrorCornerRadius(cornerRadius Clay_CornerRadius) Clay_CornerRadius {
+	return Clay_CornerRadius{
+		TopLeft:     cornerRadius.TopRight,
+		TopRight:    cornerRadius.TopLeft,
+		BottomLeft:  cornerRadius.BottomRight,
+		BottomRight: cornerRadius.BottomLeft,
+	}
+}
+
+// Mirrors a bounding box horizontally across the center of the layout.
+func Clay__MirrorBoundingBox(boundingBox Clay_BoundingBox, layoutWidth float32) Clay_BoundingBox {
+	boundingBox.X = layoutWidth - boundingBox.X - boundingBox.Width
+	return boundingBox
+}
+
+// Mirrors the final layout horizontally so that it reads from right to left. Mirroring the whole layout swaps the left
+// and right side of everything that was positioned: child alignment, padding, margins, the order of CLAY_LEFT_TO_RIGHT
+// children, floating attach points and offsets, text alignment within wrapped text, corner radii, border widths, gradients, shadow offsets and transforms.
+// The element bounding boxes used for pointer hit testing are mirrored as well.
+func Clay__MirrorLayoutHorizontally() {
+	currentContext := Clay_GetCurrentContext()
+	layoutWidth := currentContext.LayoutDimensions.Width
+
+	for i := int32(0); i < currentContext.RenderCommands.Length(); i++ {
+		renderCommand := Clay__Array_Get(&currentContext.RenderCommands, i)
+		renderData := &renderCommand.RenderData
+		switch renderCommand.CommandType {
+		case CLAY_RENDER_COMMAND_TYPE_NONE, CLAY_RENDER_COMMAND_TYPE_SCISSOR_END, CLAY_RENDER_COMMAND_TYPE_TRANSFORM_END:
+			continue
+		case CLAY_RENDER_COMMAND_TYPE_RECTANGLE:
+			renderData.Rectangle.CornerRadius = Clay__MirrorCornerRadius(renderData.Rectangle.CornerRadius)
+			renderData.Rectangle.BackgroundGradient = Clay__MirrorGradient(renderData.Rectangle.BackgroundGradient)
+		case CLAY_RENDER_COMMAND_TYPE_IMAGE:
+			renderData.Image.CornerRadius = Clay__MirrorCornerRadius(renderData.Image.CornerRadius)
+		case CLAY_RENDER_COMMAND_TYPE_CUSTOM:
+			renderData.Custom.CornerRadius = Clay__MirrorCornerRadius(renderData.Custom.CornerRadius)
+		case CLAY_RENDER_COMMAND_TYPE_BORDER:
+			renderData.Border.CornerRadius = Clay__MirrorCornerRadius(renderData.Border.CornerRadius)
+			renderData.Border.Width.Left, renderData.Border.Width.Right = renderData.Border.Width.Right, renderData.Border.Width.Left
+		case CLAY_RENDER_COMMAND_TYPE_SHADOW:
+			renderData.Shadow.CornerRadius = Clay__MirrorCornerRadius(renderData.Shadow.CornerRadius)
+			renderData.Shadow.Offset.X = -renderData.Shadow.Offset.X
+		case CLAY_RENDER_COMMAND_TYPE_TRANSFORM_START:
+			renderData.Transform.Translation.X = -renderData.Transform.Translation.X
+			renderData.Transform.Rotation = -renderData.Transform.Rotation
+			renderData.Transform.Origin.X = 1 - renderData.Transform.Origin.X
+		}
+		renderCommand.BoundingBox = Clay__MirrorBoundingBox(renderCommand.BoundingBox, layoutWidth)
+	}
+
+	for i := int32(0); i < currentContext.LayoutElementsHashMapInternal.Length(); i++ {
+		hashMapItem := Clay__Array_Get(&currentContext.LayoutElementsHashMapInternal, i)
+		// Only elements declared during this layout have been positioned
+		if hashMapItem.Generation == currentContext.Generation+1 {
+			hashMapItem.BoundingBox = Clay__MirrorBoundingBox(hashMapItem.BoundingBox, layoutWidth)
+		}
+	}
+
+	// Scroll containers share the bounding box of their element
+	for i := int32(0); i < currentContext.ScrollContainerDatas.Length(); i++ {
+		scrollContainerData := Clay__Array_Get(&currentContext.ScrollContainerDatas, i)
+		if hashMapItem := Clay__GetHashMapItem(scrollContainerData.ElementId); hashMapItem.Generation == currentContext.Generation+1 {
+			scrollContainerData.BoundingBox = hashMapItem.BoundingBox
+		}
+	}
+}
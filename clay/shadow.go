@@ -0,0 +1,29 @@
+package clay
+
+func Clay__ShadowRenderData(config *Clay_ShadowElementConfig, cornerRadius Clay_CornerRadius) Clay_RenderData {
+	return Clay_RenderData{
+		Shadow: Clay_ShadowRenderData{
+			Color:        config.Color,
+			Offset:       config.Offset,
+			BlurRadius:   config.BlurRadius,
+			Spread:       config.Spread,
+			Inset:        config.Inset,
+			CornerRadius: cornerRadius,
+		},
+	}
+}
+
+// Returns the area that the shadow of an element at boundingBox can cover, which is larger than the element itself
+// for shadows that are cast behind it.
+func Clay__ShadowBoundingBox(boundingBox Clay_BoundingBox, config *Clay_ShadowElementConfig) Clay_BoundingBox {
+	if config.Inset {
+		return boundingBox
+	}
+	extent := config.Spread + config.BlurRadius
+	return Clay_BoundingBox{
+		X:      boundingBox.X + config.Offset.X - extent,
+		Y:      boundingBox.Y + config.Offset.Y - extent,
+		Width:  boundingBox.Width + extent*2,
+		Height: boundingBox.Height + extent*2,
+	}
+}
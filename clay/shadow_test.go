@@ -0,0 +1,89 @@
+package clay
+
+import (
+	"reflect"
+	"testing"
+)
+
+func testShadowCard(shadow Clay_ShadowElementConfig) func() {
+	return func() {
+		CLAY_ROOT(CLAY_ID("card"), Clay_ElementDeclaration{
+			Layout:          Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(100), Height: CLAY_SIZING_FIXED(50)}},
+			BackgroundColor: Clay_Color{R: 1, G: 1, B: 1, A: 1},
+			CornerRadius:    Clay_CornerRadius{TopLeft: 8},
+			Clip:            Clay_ClipElementConfig{Vertical: true},
+			Shadow:          shadow,
+		})
+	}
+}
+
+func TestShadowIsCastBehindElement(t *testing.T) {
+	newTestContext(t, 800, 600)
+	shadowConfig := Clay_ShadowElementConfig{Color: Clay_Color{A: 0.5}, Offset: Clay_Vector2{X: 2, Y: 4}, BlurRadius: 6, Spread: 1}
+	renderCommands := runTestLayout(testShadowCard(shadowConfig))
+
+	// The shadow extends outside of the element, so it is cast before its clipping rectangle starts
+	expectedTypes := []string{"SHADOW", "SCISSOR_START", "RECTANGLE", "SCISSOR_END"}
+	if types := testRenderCommandTypes(renderCommands); !reflect.DeepEqual(types, expectedTypes) {
+		t.Fatalf("expected render commands %v, got %v", expectedTypes, types)
+	}
+	shadow := renderCommands[0]
+	expectedShadow := Clay_ShadowRenderData{
+		Color:        shadowConfig.Color,
+		Offset:       shadowConfig.Offset,
+		BlurRadius:   6,
+		Spread:       1,
+		CornerRadius: Clay_CornerRadius{TopLeft: 8},
+	}
+	if shadow.RenderData.Shadow != expectedShadow {
+		t.Errorf("expected shadow %+v, got %+v", expectedShadow, shadow.RenderData.Shadow)
+	}
+	if expectedBox := (Clay_BoundingBox{Width: 100, Height: 50}); shadow.BoundingBox != expectedBox {
+		t.Errorf("expected the shadow to have the bounding box of the element %+v, got %+v", expectedBox, shadow.BoundingBox)
+	}
+}
+
+func TestShadowInsetIsDrawnOnTopOfBackground(t *testing.T) {
+	newTestContext(t, 800, 600)
+	renderCommands := runTestLayout(testShadowCard(Clay_ShadowElementConfig{Color: Clay_Color{A: 0.5}, BlurRadius: 4, Inset: true}))
+
+	expectedTypes := []string{"SCISSOR_START", "RECTANGLE", "SHADOW", "SCISSOR_END"}
+	if types := testRenderCommandTypes(renderCommands); !reflect.DeepEqual(types, expectedTypes) {
+		t.Errorf("expected render commands %v, got %v", expectedTypes, types)
+	}
+}
+
+func TestShadowIsCulledByItsOwnBounds(t *testing.T) {
+	newTestContext(t, 800, 600)
+	layout := func(offsetX float32) func() {
+		return func() {
+			CLAY_ROOT(CLAY_ID("outer"), Clay_ElementDeclaration{
+				Layout: Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(100), Height: CLAY_SIZING_FIXED(50)}, Padding: Clay_Padding{Left: 900}},
+			}, CLAY(CLAY_ID("card"), Clay_ElementDeclaration{
+				Layout: Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(100), Height: CLAY_SIZING_FIXED(50)}},
+				Shadow: Clay_ShadowElementConfig{Color: Clay_Color{A: 0.5}, Offset: Clay_Vector2{X: offsetX}, BlurRadius: 10},
+			}))
+		}
+	}
+
+	if renderCommands := runTestLayout(layout(-150)); len(renderCommands) != 1 || renderCommands[0].CommandType != CLAY_RENDER_COMMAND_TYPE_SHADOW {
+		t.Errorf("expected the shadow of an offscreen element to be rendered while it is on screen, got %v", testRenderCommandTypes(renderCommands))
+	}
+	if renderCommands := runTestLayout(layout(0)); len(renderCommands) != 0 {
+		t.Errorf("expected offscreen shadows to be culled, got %v", testRenderCommandTypes(renderCommands))
+	}
+}
+
+func TestShadowIsMirroredInRTL(t *testing.T) {
+	newTestContext(t, 800, 600)
+	Clay_SetRTLEnabled(true)
+	renderCommands := runTestLayout(testShadowCard(Clay_ShadowElementConfig{Color: Clay_Color{A: 0.5}, Offset: Clay_Vector2{X: 2, Y: 4}}))
+
+	shadow := testFindRenderCommand(t, renderCommands, "card", CLAY_RENDER_COMMAND_TYPE_SHADOW)
+	if shadow.RenderData.Shadow.Offset != (Clay_Vector2{X: -2, Y: 4}) {
+		t.Errorf("expected the offset to be mirrored, got %+v", shadow.RenderData.Shadow.Offset)
+	}
+	if shadow.RenderData.Shadow.CornerRadius != (Clay_CornerRadius{TopRight: 8}) {
+		t.Errorf("expected the corner radius to be mirrored, got %+v", shadow.RenderData.Shadow.CornerRadius)
+	}
+}
@@ -30,4 +30,12 @@ type Clay_SizingAxisSize struct {
 type Clay_SizingAxis struct {
 	Type Clay__SizingType
 	Size Clay_SizingAxisSize
+	// The share of the free space a GROW element receives relative to its GROW siblings, like CSS flex-grow. 0 is treated as 1.
+	GrowWeight float32
+	// How quickly this element is compressed relative to its siblings when they overflow their parent, like CSS flex-shrink.
+	// 0 is treated as 1.
+	ShrinkWeight float32
+	// Siblings with a lower ShrinkPriority are compressed down to their minimum size before any sibling with a higher
+	// ShrinkPriority is compressed. Give the main content a higher priority than side panels to keep it intact.
+	ShrinkPriority uint8
 }
@@ -0,0 +1,117 @@
+package clay
+
+import "testing"
+
+func TestGrowWeightSplitsFreeSpace(t *testing.T) {
+	newTestContext(t, 800, 600)
+	runTestLayout(func() {
+		CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{},
+			CLAY(CLAY_ID("parent"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{
+				Sizing:   Clay_Sizing{Width: CLAY_SIZING_FIXED(310), Height: CLAY_SIZING_FIXED(50)},
+				ChildGap: 10,
+			}},
+				CLAY(CLAY_ID("sidebar"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{
+					Sizing: Clay_Sizing{Width: CLAY_SIZING_GROW(Clay_SizingMinMax{}), Height: CLAY_SIZING_GROW(Clay_SizingMinMax{})},
+				}}),
+				CLAY(CLAY_ID("main"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{
+					Sizing: Clay_Sizing{Width: CLAY_SIZING_GROW_WEIGHTED(2, Clay_SizingMinMax{}), Height: CLAY_SIZING_GROW(Clay_SizingMinMax{})},
+				}}),
+			),
+		)
+	})
+
+	testExpectBoundingBox(t, "sidebar", Clay_BoundingBox{X: 0, Y: 0, Width: 100, Height: 50})
+	testExpectBoundingBox(t, "main", Clay_BoundingBox{X: 110, Y: 0, Width: 200, Height: 50})
+}
+
+func TestGrowWeightRespectsMaxSize(t *testing.T) {
+	newTestContext(t, 800, 600)
+	runTestLayout(func() {
+		CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{},
+			CLAY(CLAY_ID("parent"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{
+				Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(300), Height: CLAY_SIZING_FIXED(50)},
+			}},
+				CLAY(CLAY_ID("a"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{
+					Sizing: Clay_Sizing{Width: CLAY_SIZING_GROW_WEIGHTED(3, Clay_SizingMinMax{Max: 120})},
+				}}),
+				CLAY(CLAY_ID("b"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{
+					Sizing: Clay_Sizing{Width: CLAY_SIZING_GROW(Clay_SizingMinMax{})},
+				}}),
+			),
+		)
+	})
+
+	// a stops at its maximum, the remainder goes to b
+	if got := testBoundingBox(t, "a").Width; got != 120 {
+		t.Errorf("expected a to be clamped to 120, got %v", got)
+	}
+	if got := testBoundingBox(t, "b").Width; got != 180 {
+		t.Errorf("expected b to take the remaining 180, got %v", got)
+	}
+}
+
+// Lays out two panels, each wrapping 240px of text with 40px words, inside a parent of the given width.
+func testShrinkPanels(t *testing.T, parentWidth float32, sidebarSizing, mainSizing Clay_SizingAxis) (sidebarWidth, mainWidth float32) {
+	t.Helper()
+	newTestContext(t, 800, 600)
+	runTestLayout(func() {
+		CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{},
+			CLAY(CLAY_ID("parent"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{
+				Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(parentWidth)},
+			}},
+				CLAY(CLAY_ID("sidebar"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{Sizing: Clay_Sizing{Width: sidebarSizing}}},
+					CLAY_TEXT("aaaa bbbb cccc dddd eeee"),
+				),
+				CLAY(CLAY_ID("main"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{Sizing: Clay_Sizing{Width: mainSizing}}},
+					CLAY_TEXT("aaaa bbbb cccc dddd eeee"),
+				),
+			),
+		)
+	})
+	return testBoundingBox(t, "sidebar").Width, testBoundingBox(t, "main").Width
+}
+
+func TestShrinkDefaultsCompressLargestFirst(t *testing.T) {
+	sidebar, main := testShrinkPanels(t, 400, CLAY_SIZING_FIT(Clay_SizingMinMax{}), CLAY_SIZING_FIT(Clay_SizingMinMax{}))
+	if sidebar != 200 || main != 200 {
+		t.Errorf("expected both panels to compress evenly to 200, got %v and %v", sidebar, main)
+	}
+}
+
+func TestShrinkPriority(t *testing.T) {
+	mainSizing := CLAY_SIZING_FIT(Clay_SizingMinMax{})
+	mainSizing.ShrinkPriority = 1
+
+	t.Run("lower priority compresses first", func(t *testing.T) {
+		sidebar, main := testShrinkPanels(t, 400, CLAY_SIZING_FIT(Clay_SizingMinMax{}), mainSizing)
+		if sidebar != 160 || main != 240 {
+			t.Errorf("expected sidebar 160 and main 240, got %v and %v", sidebar, main)
+		}
+	})
+
+	t.Run("higher priority compresses once lower priority is at its minimum", func(t *testing.T) {
+		sidebar, main := testShrinkPanels(t, 250, CLAY_SIZING_FIT(Clay_SizingMinMax{}), mainSizing)
+		if sidebar != 40 || main != 210 {
+			t.Errorf("expected sidebar 40 and main 210, got %v and %v", sidebar, main)
+		}
+	})
+}
+
+func TestShrinkWeight(t *testing.T) {
+	sidebarSizing := CLAY_SIZING_FIT(Clay_SizingMinMax{})
+	sidebarSizing.ShrinkWeight = 3
+
+	t.Run("heavier child compresses first", func(t *testing.T) {
+		sidebar, main := testShrinkPanels(t, 400, sidebarSizing, CLAY_SIZING_FIT(Clay_SizingMinMax{}))
+		if sidebar != 160 || main != 240 {
+			t.Errorf("expected sidebar 160 and main 240, got %v and %v", sidebar, main)
+		}
+	})
+
+	t.Run("siblings settle inversely proportional to their weights", func(t *testing.T) {
+		sidebar, main := testShrinkPanels(t, 200, sidebarSizing, CLAY_SIZING_FIT(Clay_SizingMinMax{}))
+		if !testFloatsEqual(sidebar, 50) || !testFloatsEqual(main, 150) {
+			t.Errorf("expected sidebar 50 and main 150, got %v and %v", sidebar, main)
+		}
+	})
+}
@@ -0,0 +1,82 @@
+package clay
+
+import "github.com/zodimo/clay-go/pkg/mem"
+
+// Sorts items in ascending order with a bottom up merge sort, keeping items that compare equal in their original order.
+// scratch must be at least as long as items, and its contents are overwritten.
+func Clay__SortStable[T any](items []T, scratch []T, less func(a, b *T) bool) {
+	length := len(items)
+	source, destination := items, scratch[:length]
+	for width := 1; width < length; width *= 2 {
+		for start := 0; start < length; start += 2 * width {
+			middle := CLAY__MIN(start+width, length)
+			end := CLAY__MIN(start+2*width, length)
+			left, right, out := start, middle, start
+			for left < middle && right < end {
+				// Taking from the left run unless the right item is strictly smaller is what keeps the sort stable
+				if less(&source[right], &source[left]) {
+					destination[out] = source[right]
+					right++
+				} else {
+					destination[out] = source[left]
+					left++
+				}
+				out++
+			}
+			out += copy(destination[out:], source[left:middle])
+			copy(destination[out:], source[right:end])
+		}
+		source, destination = destination, source
+	}
+	// After an odd number of passes the sorted items are in the scratch buffer
+	if length > 0 && &source[0] != &items[0] {
+		copy(items, source)
+	}
+}
+
+// Sorts the layout tree roots in ascending z index, keeping roots with the same z index in declaration order.
+func Clay__SortLayoutElementTreeRoots() {
+	currentContext := Clay_GetCurrentContext()
+	rootCount := currentContext.LayoutElementTreeRoots.Length()
+	Clay__SortStable(
+		mem.MArray_GetSlice(&currentContext.LayoutElementTreeRoots, 0, rootCount),
+		mem.MArray_GetSlice(&currentContext.LayoutElementTreeRootsSortBuffer, 0, rootCount),
+		func(a, b *Clay__LayoutElementTreeRoot) bool { return a.ZIndex < b.ZIndex },
+	)
+}
+
+// Returns the position of an element config type in the order its render commands are emitted. Transforms start
+// before anything else is drawn for the element so that they also apply to its clipping rectangle, shadows are cast
+// behind the element and outside of its clipping rectangle, clipping starts before the element is drawn, and borders
+// are drawn on top of everything else.
+func Clay__ElementConfigRenderOrder(configType Clay__ElementConfigType) int {
+	switch configType {
+	case CLAY__ELEMENT_CONFIG_TYPE_TRANSFORM:
+		return 0
+	case CLAY__ELEMENT_CONFIG_TYPE_SHADOW:
+		return 1
+	case CLAY__ELEMENT_CONFIG_TYPE_CLIP:
+		return 2
+	case CLAY__ELEMENT_CONFIG_TYPE_BORDER:
+		return 4
+	}
+	return 3
+}
+
+// Returns the indexes of the configs of element in the order their render commands are emitted, keeping configs of
+// the same order in declaration order. The returned slice is only valid until the next call.
+func Clay__SortElementConfigsForRendering(element *Clay_LayoutElement) []int32 {
+	currentContext := Clay_GetCurrentContext()
+	configCount := element.ElementConfigs.Length()
+	sortedConfigIndexes := mem.MArray_GetSlice(&currentContext.ElementConfigSortBuffer, 0, configCount)
+	sortedConfigCount := 0
+	for order := 0; order <= 4; order++ {
+		for elementConfigIndex := int32(0); elementConfigIndex < configCount; elementConfigIndex++ {
+			if Clay__ElementConfigRenderOrder(Clay__Slice_Get(&element.ElementConfigs, elementConfigIndex).Type) == order {
+				sortedConfigIndexes[sortedConfigCount] = elementConfigIndex
+				sortedConfigCount++
+			}
+		}
+	}
+	return sortedConfigIndexes
+}
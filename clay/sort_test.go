@@ -0,0 +1,120 @@
+package clay
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+type testSortItem struct {
+	key   int
+	index int
+}
+
+func TestSortStable(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	for _, length := range []int{0, 1, 2, 3, 7, 16, 33, 100, 1000} {
+		items := make([]testSortItem, length)
+		for i := range items {
+			items[i] = testSortItem{key: random.Intn(5), index: i}
+		}
+		expected := slices.Clone(items)
+		slices.SortStableFunc(expected, func(a, b testSortItem) int { return a.key - b.key })
+
+		Clay__SortStable(items, make([]testSortItem, length), func(a, b *testSortItem) bool { return a.key < b.key })
+		if !slices.Equal(items, expected) {
+			t.Errorf("length %d: expected %v, got %v", length, expected, items)
+		}
+	}
+}
+
+// Declares a root with a floating element for each of the z indexes, in order.
+func testFloatingRoots(zIndexes []int16) func() {
+	return func() {
+		floating := make([]ClayContainer, len(zIndexes))
+		for i, zIndex := range zIndexes {
+			floating[i] = CLAY(CLAY_ID(fmt.Sprintf("floating%d", i)), Clay_ElementDeclaration{
+				Layout:          Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(20), Height: CLAY_SIZING_FIXED(20)}},
+				BackgroundColor: Clay_Color{A: 255},
+				Floating: Clay_FloatingElementConfig{
+					AttachTo: CLAY_ATTACH_TO_PARENT,
+					Offset:   Clay_Vector2{X: float32(i%40) * 20, Y: float32(i/40) * 20},
+					ZIndex:   zIndex,
+				},
+			})
+		}
+		CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{
+			Layout: Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_GROW(Clay_SizingMinMax{}), Height: CLAY_SIZING_GROW(Clay_SizingMinMax{})}},
+		}, floating...)
+	}
+}
+
+func TestFloatingRootsAreSortedByZIndex(t *testing.T) {
+	newTestContext(t, 800, 600)
+	zIndexes := []int16{3, 1, 3, -2, 1, 0, 3, -2}
+	renderCommands := runTestLayout(testFloatingRoots(zIndexes))
+
+	// Floating elements with the same z index are rendered in the order they were declared in
+	expected := []int{3, 7, 5, 1, 4, 0, 2, 6}
+	if len(renderCommands) != len(expected) {
+		t.Fatalf("expected %d render commands, got %d", len(expected), len(renderCommands))
+	}
+	for i, floatingIndex := range expected {
+		if id := CLAY_ID(fmt.Sprintf("floating%d", floatingIndex)).Id; renderCommands[i].Id != id {
+			t.Errorf("render command %d: expected floating%d, got id %d", i, floatingIndex, renderCommands[i].Id)
+		}
+		if renderCommands[i].ZIndex != zIndexes[floatingIndex] {
+			t.Errorf("render command %d: expected z index %d, got %d", i, zIndexes[floatingIndex], renderCommands[i].ZIndex)
+		}
+	}
+}
+
+func TestElementConfigsAreRenderedClipFirstAndBorderLast(t *testing.T) {
+	newTestContext(t, 800, 600)
+	renderCommands := runTestLayout(func() {
+		CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{
+			Layout:          Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(100), Height: CLAY_SIZING_FIXED(100)}},
+			BackgroundColor: Clay_Color{A: 255},
+			Border:          Clay_BorderElementConfig{Color: Clay_Color{A: 255}, Width: Clay_BorderWidth{Left: 1, Right: 1, Top: 1, Bottom: 1}},
+			Clip:            Clay_ClipElementConfig{Vertical: true},
+		})
+	})
+
+	expected := []Clay_RenderCommandType{
+		CLAY_RENDER_COMMAND_TYPE_SCISSOR_START,
+		CLAY_RENDER_COMMAND_TYPE_RECTANGLE,
+		CLAY_RENDER_COMMAND_TYPE_BORDER,
+		CLAY_RENDER_COMMAND_TYPE_SCISSOR_END,
+	}
+	if len(renderCommands) != len(expected) {
+		t.Fatalf("expected %d render commands, got %d", len(expected), len(renderCommands))
+	}
+	for i, commandType := range expected {
+		if renderCommands[i].CommandType != commandType {
+			t.Errorf("render command %d: expected type %d, got %d", i, commandType, renderCommands[i].CommandType)
+		}
+	}
+}
+
+func benchmarkFloatingRoots(b *testing.B, floatingCount int) {
+	newTestContext(b, 800, 600)
+	// Tooltips and badges mostly share a handful of z indexes
+	zIndexes := make([]int16, floatingCount)
+	for i := range zIndexes {
+		zIndexes[i] = int16((i * 7) % 5)
+	}
+	layout := testFloatingRoots(zIndexes)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		runTestLayout(layout)
+	}
+}
+
+func BenchmarkFloatingRoots100(b *testing.B) {
+	benchmarkFloatingRoots(b, 100)
+}
+
+func BenchmarkFloatingRoots1000(b *testing.B) {
+	benchmarkFloatingRoots(b, 1000)
+}
@@ -18,6 +18,45 @@ type Clay_BorderElementConfig struct {
 
 }
 
+// Controls settings related to element shadows. A SHADOW render command is generated while Color.A > 0.
+type Clay_ShadowElementConfig struct {
+	Color      Clay_Color   // The color of the shadow where it isn't blurred.
+	Offset     Clay_Vector2 // Moves the shadow relative to the element.
+	BlurRadius float32      // The distance over which the edges of the shadow fade out.
+	Spread     float32      // Grows the shadow on every side before it is blurred, or shrinks it when negative.
+	Inset      bool         // Casts the shadow inside the element, on top of its background, instead of behind it.
+}
+
+type Clay_GradientType uint8
+
+const (
+	// (default) The element has no gradient, and is filled with its BackgroundColor.
+	CLAY_GRADIENT_TYPE_NONE Clay_GradientType = iota
+	// The colors blend along the line from .start to .end.
+	CLAY_GRADIENT_TYPE_LINEAR
+	// The colors blend outwards from .start, reaching the last stop at the distance from .start to .end.
+	CLAY_GRADIENT_TYPE_RADIAL
+)
+
+// The color of a gradient at a position along it.
+type Clay_GradientStop struct {
+	Color    Clay_Color
+	Position float32 // The position of the stop along the gradient, between 0 and 1.
+}
+
+// Fills the background of an element with colors that blend into each other, instead of its BackgroundColor.
+// Only rectangles are filled with gradients, image and custom elements still use the BackgroundColor.
+type Clay_Gradient struct {
+	Type Clay_GradientType
+	// The points the gradient runs between, as fractions of the size of the element. e.g. {0, 0} and {0, 1} run from
+	// top to bottom, and {0.5, 0.5} and {1, 0.5} form a radial gradient from the center to the left and right edges.
+	Start Clay_Vector2
+	End   Clay_Vector2
+	// The colors of the gradient in ascending order of position. The stops are referenced rather than copied, and
+	// shouldn't be changed until the render commands have been drawn.
+	Stops []Clay_GradientStop
+}
+
 type Clay_Color struct {
 	R float32 // range between 0 and 1
 	G float32 // range between 0 and 1
@@ -24,6 +24,19 @@ const (
 	CLAY_TEXT_ALIGN_RIGHT
 )
 
+// Controls what happens to text that doesn't fit within its bounding box, either because it is wider than the
+// bounding box or because it has more lines than Clay_TextElementConfig.MaxLines.
+type Clay_TextOverflowMode uint8
+
+const (
+	// (default) Lines past MaxLines are dropped and lines that are too wide are left for the renderer to clip.
+	CLAY_TEXT_OVERFLOW_CLIP Clay_TextOverflowMode = iota
+	// Truncated lines end with an ellipsis, e.g. "a_very_long_fi…".
+	CLAY_TEXT_OVERFLOW_ELLIPSIS
+	// Truncated lines keep their start and end and replace the middle with an ellipsis, e.g. "a_very…name.txt".
+	CLAY_TEXT_OVERFLOW_ELLIPSIS_MIDDLE
+)
+
 // Controls various functionality related to text elements.
 type Clay_TextElementConfig struct {
 	// A pointer that will be transparently passed through to the resulting render command.
@@ -49,6 +62,13 @@ type Clay_TextElementConfig struct {
 	// CLAY_TEXT_ALIGN_CENTER - Horizontally aligns wrapped lines of text to the center of their bounding box.
 	// CLAY_TEXT_ALIGN_RIGHT - Horizontally aligns wrapped lines of text to the right hand side of their bounding box.
 	TextAlignment Clay_TextAlignment
+	// The maximum number of wrapped lines to display. 0 (default) means no limit.
+	MaxLines uint16
+	// Controls what happens to text that doesn't fit within its bounding box.
+	// CLAY_TEXT_OVERFLOW_CLIP (default) - Drops lines past MaxLines and leaves lines that are too wide to the renderer.
+	// CLAY_TEXT_OVERFLOW_ELLIPSIS - Ends truncated lines with an ellipsis.
+	// CLAY_TEXT_OVERFLOW_ELLIPSIS_MIDDLE - Replaces the middle of truncated lines with an ellipsis.
+	Overflow Clay_TextOverflowMode
 }
 
 type Clay__MeasureTextCacheItem struct {
@@ -56,6 +76,10 @@ type Clay__MeasureTextCacheItem struct {
 	MeasuredWordsStartIndex int32
 	MinWidth                float32
 	ContainsNewlines        bool
+	// The largest ascent of the measured words, see Clay__MeasureTextMetrics.
+	Ascent float32
+	// The width of a space in the font of the measured text.
+	SpaceWidth float32
 	// Hash map data
 	Id         uint32
 	NextIndex  int32
@@ -65,8 +89,9 @@ type Clay__MeasureTextCacheItem struct {
 // Note: Clay_String is not guaranteed to be null terminated. It may be if created from a literal C string,
 // but it is also used to represent slices.
 type Clay_String struct {
-	// Set this boolean to true if the char* data underlying this string will live for the entire lifetime of the program.
-	// This will automatically be set for strings created with CLAY_STRING, as the macro requires a string literal.
+	// Set this boolean to true if the bytes underlying this string will live for the entire lifetime of the program and
+	// never change, like those of a string constant. Measurements of static strings are cached by the address of their
+	// bytes rather than their contents. This is set for strings created with CLAY_STATIC_STRING.
 	IsStaticallyAllocated bool
 	Length                int32
 	// The underlying character memory. Note: this will not be copied and will not extend the lifetime of the underlying memory.
@@ -77,15 +102,57 @@ func (s Clay_String) String() string {
 	return string(s.Chars[:s.Length])
 }
 
+// The result of measuring text with a Clay__MeasureTextMetricsFunction.
+type Clay_TextMetrics struct {
+	// The size of the measured text, as returned by a Clay__MeasureTextFunction.
+	Dimensions Clay_Dimensions
+	// The distance from the top of the measured text to its baseline.
+	Ascent float32
+}
+
+// A run of text within a rich text element, see CLAY_RICH_TEXT.
+type Clay_TextSpan struct {
+	Text Clay_String
+	// An optional id that Clay_PointerOver reports while the pointer is over the span, e.g. to make the span a link.
+	Id Clay_ElementId
+	// The styling of the span. WrapMode, LineHeight, TextAlignment and MaxLines are taken from the config of the
+	// rich text element instead, as they apply to the whole paragraph.
+	Config *Clay_TextElementConfig
+}
+
 type Clay__WrappedTextLine struct {
 	Line       Clay_String
 	Dimensions Clay_Dimensions
+	// The byte offset of the start of the line within the text of its element. The text of a rich text element is
+	// the text of all of its spans joined together.
+	StartOffset int32
+	// The parts of each span on this line, for rich text elements only.
+	Fragments Clay__Slice[Clay__WrappedTextFragment]
+}
+
+// The part of a rich text span that was wrapped onto a single line.
+type Clay__WrappedTextFragment struct {
+	Line       Clay_String
+	Dimensions Clay_Dimensions
+	SpanIndex  int32
+	// The byte offset of the start of the fragment within the text of its span.
+	StartOffset int32
+	// The position of the fragment relative to the start of its line. Fragments are vertically offset so that
+	// the baselines of spans with different fonts line up.
+	Offset Clay_Vector2
 }
+
 type Clay__TextElementData struct {
 	Text                Clay_String
 	PreferredDimensions Clay_Dimensions
-	ElementIndex        int32
-	WrappedLines        Clay__Slice[Clay__WrappedTextLine]
+	// The distance from the top of the unwrapped text to its baseline, see Clay__MeasureTextCacheItem.Ascent.
+	Ascent       float32
+	ElementIndex int32
+	WrappedLines Clay__Slice[Clay__WrappedTextLine]
+	// The spans of a rich text element, in which case Text is empty.
+	Spans []Clay_TextSpan
+	// Set for text input elements, see CLAY_TEXT_INPUT.
+	Input bool
 }
 
 type Clay__MeasuredWord struct {
@@ -149,6 +216,18 @@ func TextWithTextAlignment(textAlignment Clay_TextAlignment) TextOption {
 	}
 }
 
+func TextWithMaxLines(maxLines uint16) TextOption {
+	return func(config *Clay_TextElementConfig) {
+		config.MaxLines = maxLines
+	}
+}
+
+func TextWithOverflow(overflow Clay_TextOverflowMode) TextOption {
+	return func(config *Clay_TextElementConfig) {
+		config.Overflow = overflow
+	}
+}
+
 func TextWithUserData(userData interface{}) TextOption {
 	return func(config *Clay_TextElementConfig) {
 		config.UserData = userData
@@ -0,0 +1,72 @@
+package clay
+
+import "unicode/utf8"
+
+// Adds a text input element with the provided id, see CLAY_TEXT_INPUT. Text inputs without any measurable text, such
+// as empty ones, are as tall as a space so that the caret has room.
+func Clay__OpenTextInputElement(elementId Clay_ElementId, text Clay_String, textConfig *Clay_TextElementConfig) {
+	textElement := Clay__AddMeasuredTextElement(elementId, textConfig, Clay__TextElementData{Text: text, Input: true})
+	if textElement == nil {
+		return
+	}
+	textElementData := textElement.ChildrenOrTextContent.TextElementData
+	if textElementData.PreferredDimensions.Height > 0 {
+		return
+	}
+	textElementData.PreferredDimensions.Height = Clay__MeasureText(
+		Clay_StringSlice{
+			Length:    1,
+			Chars:     CLAY__SPACECHAR.Chars,
+			BaseChars: CLAY__SPACECHAR.Chars,
+		},
+		textConfig,
+		Clay_GetCurrentContext().MeasureTextUserData).Height
+	if textConfig.LineHeight == 0 {
+		textElement.Dimensions.Height = textElementData.PreferredDimensions.Height
+		textElement.MinDimensions.Height = textElementData.PreferredDimensions.Height
+	}
+}
+
+// Adds an empty line after the wrapped lines of a text input that ends with a newline, so that the caret can be placed
+// after the newline.
+func Clay__AddTextInputTrailingLine(textElementData *Clay__TextElementData, lineHeight float32) {
+	currentContext := Clay_GetCurrentContext()
+	text := textElementData.Text
+	if text.Length == 0 || currentContext.WrappedTextLines.Length() > currentContext.WrappedTextLines.Capacity()-1 {
+		return
+	}
+	if lastRune, _ := utf8.DecodeLastRune(text.Chars[:text.Length]); !Clay__IsNewline(lastRune) {
+		return
+	}
+	Clay__Array_Add(&currentContext.WrappedTextLines, Clay__WrappedTextLine{
+		Dimensions:  Clay_Dimensions{Height: lineHeight},
+		Line:        Clay_String{Chars: text.Chars[text.Length:text.Length]},
+		StartOffset: text.Length,
+	})
+	Clay__Slice_Grow(&textElementData.WrappedLines, 1)
+}
+
+// Adds the CLAY_RENDER_COMMAND_TYPE_TEXT_INPUT command of a text input element, which covers the whole element.
+func Clay__AddTextInputRenderCommand(element *Clay_LayoutElement, boundingBox Clay_BoundingBox, textConfig *Clay_TextElementConfig, zIndex int16) {
+	text := element.ChildrenOrTextContent.TextElementData.Text
+	Clay__AddRenderCommand(Clay_RenderCommand{
+		BoundingBox: boundingBox,
+		RenderData: Clay_RenderData{
+			Text: Clay_TextRenderData{
+				StringContents: Clay_StringSlice{
+					Length:    text.Length,
+					Chars:     text.Chars,
+					BaseChars: text.Chars,
+				},
+				TextColor:     textConfig.TextColor,
+				FontId:        textConfig.FontId,
+				FontSize:      textConfig.FontSize,
+				LetterSpacing: textConfig.LetterSpacing,
+				LineHeight:    textConfig.LineHeight,
+			}},
+		UserData:    textConfig.UserData,
+		Id:          element.Id,
+		ZIndex:      zIndex,
+		CommandType: CLAY_RENDER_COMMAND_TYPE_TEXT_INPUT,
+	})
+}
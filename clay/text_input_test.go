@@ -0,0 +1,68 @@
+package clay
+
+import (
+	"testing"
+)
+
+func TestTextInputRenderCommands(t *testing.T) {
+	newTestContext(t, 800, 600)
+	commands := runTestLayout(func() {
+		CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{},
+			CLAY(CLAY_ID("parent"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(80)}}},
+				CLAY_TEXT_INPUT(CLAY_ID("input"), "one two three"),
+			),
+		)
+	})
+	types := []Clay_RenderCommandType{}
+	for _, command := range commands {
+		types = append(types, command.CommandType)
+		if command.CommandType != CLAY_RENDER_COMMAND_TYPE_TEXT_INPUT {
+			continue
+		}
+		if command.Id != CLAY_ID("input").Id || command.BoundingBox != (Clay_BoundingBox{X: 0, Y: 0, Width: 80, Height: 40}) {
+			t.Errorf("expected the input command to cover the input element, got %v", command.BoundingBox)
+		}
+		if command.RenderData.Text.StringContents.String() != "one two three" {
+			t.Errorf("expected the input command to hold the full text, got %q", command.RenderData.Text.StringContents.String())
+		}
+	}
+	expected := []Clay_RenderCommandType{CLAY_RENDER_COMMAND_TYPE_TEXT_INPUT, CLAY_RENDER_COMMAND_TYPE_TEXT, CLAY_RENDER_COMMAND_TYPE_TEXT}
+	if len(types) != len(expected) {
+		t.Fatalf("expected commands %v, got %v", expected, types)
+	}
+	for i := range expected {
+		if types[i] != expected[i] {
+			t.Errorf("command %d: expected %v, got %v", i, expected[i], types[i])
+		}
+	}
+}
+
+func TestEmptyTextInputHasRoomForTheCaret(t *testing.T) {
+	newTestContext(t, 800, 600)
+	runTestLayout(func() {
+		CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{},
+			CLAY(CLAY_ID("parent"), Clay_ElementDeclaration{},
+				CLAY_TEXT_INPUT(CLAY_ID("input"), ""),
+			),
+		)
+	})
+	testExpectBoundingBox(t, "parent", Clay_BoundingBox{X: 0, Y: 0, Width: 0, Height: 20})
+	if rect, found := Clay_GetCaretRect(CLAY_ID("input"), 0); !found || rect != (Clay_BoundingBox{X: 0, Y: 0, Height: 20}) {
+		t.Errorf("expected the caret at the start of the input, got %v (found %v)", rect, found)
+	}
+}
+
+func TestTextInputTrailingNewline(t *testing.T) {
+	newTestContext(t, 800, 600)
+	runTestLayout(func() {
+		CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{},
+			CLAY(CLAY_ID("parent"), Clay_ElementDeclaration{},
+				CLAY_TEXT_INPUT(CLAY_ID("input"), "abc\n"),
+			),
+		)
+	})
+	testExpectBoundingBox(t, "parent", Clay_BoundingBox{X: 0, Y: 0, Width: 30, Height: 40})
+	if rect, _ := Clay_GetCaretRect(CLAY_ID("input"), 4); rect != (Clay_BoundingBox{X: 0, Y: 20, Height: 20}) {
+		t.Errorf("expected the caret after the newline on the second line, got %v", rect)
+	}
+}
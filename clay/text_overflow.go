@@ -0,0 +1,148 @@
+package clay
+
+import "github.com/zodimo/clay-go/pkg/mem"
+
+// Applies MaxLines and the overflow mode of textConfig to the wrapped lines of textElementData.
+// The wrapped lines must be the last entries in currentContext.WrappedTextLines, as they are while the text is being wrapped.
+func Clay__TruncateWrappedTextLines(textElementData *Clay__TextElementData, textConfig *Clay_TextElementConfig, maxWidth float32) {
+	currentContext := Clay_GetCurrentContext()
+	lineCount := textElementData.WrappedLines.Length()
+	if lineCount == 0 {
+		return
+	}
+	finalLine := *Clay__Slice_Get(&textElementData.WrappedLines, lineCount-1)
+	linesDropped := false
+	if textConfig.MaxLines > 0 && lineCount > int32(textConfig.MaxLines) {
+		droppedLineCount := lineCount - int32(textConfig.MaxLines)
+		Clay__Slice_Shrink(&textElementData.WrappedLines, droppedLineCount)
+		Clay__Array_Shrink(&currentContext.WrappedTextLines, droppedLineCount)
+		lineCount = int32(textConfig.MaxLines)
+		linesDropped = true
+	}
+	if textConfig.Overflow == CLAY_TEXT_OVERFLOW_CLIP {
+		return
+	}
+	for lineIndex := int32(0); lineIndex < lineCount; lineIndex++ {
+		wrappedLine := Clay__Slice_Get(&textElementData.WrappedLines, lineIndex)
+		if len(textElementData.Spans) > 0 {
+			if (linesDropped && lineIndex == lineCount-1) || wrappedLine.Dimensions.Width > maxWidth {
+				Clay__EllipsizeRichTextLine(textElementData, wrappedLine, maxWidth, linesDropped && lineIndex == lineCount-1)
+			}
+			continue
+		}
+		// The last visible line always gets an ellipsis when lines were dropped after it, and takes the end of the
+		// text from the final line so that CLAY_TEXT_OVERFLOW_ELLIPSIS_MIDDLE still shows how the text ends.
+		if linesDropped && lineIndex == lineCount-1 {
+			Clay__EllipsizeWrappedTextLine(wrappedLine, finalLine.Line, textConfig, maxWidth, true)
+		} else if wrappedLine.Dimensions.Width > maxWidth {
+			Clay__EllipsizeWrappedTextLine(wrappedLine, wrappedLine.Line, textConfig, maxWidth, false)
+		}
+	}
+}
+
+// Replaces wrappedLine with the longest string made from the start of the line, an ellipsis, and (for
+// CLAY_TEXT_OVERFLOW_ELLIPSIS_MIDDLE) the end of tail that fits within maxWidth.
+// If tail is the line itself, at least one character of the line is always removed. Otherwise the whole line may be kept.
+// The resulting string is stored in currentContext.DynamicStringData and measured with Clay__MeasureTextCached.
+func Clay__EllipsizeWrappedTextLine(wrappedLine *Clay__WrappedTextLine, tail Clay_String, textConfig *Clay_TextElementConfig, maxWidth float32, tailIsSeparate bool) {
+	currentContext := Clay_GetCurrentContext()
+	head := wrappedLine.Line
+	middle := textConfig.Overflow == CLAY_TEXT_OVERFLOW_ELLIPSIS_MIDDLE
+	var maxLength int32
+	if !tailIsSeparate {
+		maxLength = CLAY__MAX(head.Length-1, 0)
+	} else if middle {
+		maxLength = head.Length + tail.Length
+	} else {
+		maxLength = head.Length
+	}
+	stringStart := currentContext.DynamicStringData.Length()
+	if stringStart+maxLength+CLAY__ELLIPSIS.Length > currentContext.DynamicStringData.Capacity() {
+		if !currentContext.BooleanWarnings.MaxDynamicStringDataExceeded {
+			Clay__ReportError(CLAY_ERROR_TYPE_DYNAMIC_STRING_CAPACITY_EXCEEDED, CLAY_STRING("Clay ran out of capacity while truncating text. Try setting Clay_Context.MaxDynamicStringDataBytes to a higher value."))
+			currentContext.BooleanWarnings.MaxDynamicStringDataExceeded = true
+		}
+		return
+	}
+
+	// Segment the text once, rather than for every candidate
+	TextSegmenter.headBoundaries = Clay__AppendGraphemeBoundaries(TextSegmenter.headBoundaries[:0], head.Chars[:head.Length])
+	TextSegmenter.tailBoundaries = Clay__AppendGraphemeBoundaries(TextSegmenter.tailBoundaries[:0], tail.Chars[:tail.Length])
+	headBoundaries, tailBoundaries := TextSegmenter.headBoundaries, TextSegmenter.tailBoundaries
+
+	// Binary search for the largest number of characters from the original text that still fits
+	lowLength := int32(0)
+	highLength := maxLength
+	for lowLength < highLength {
+		candidateLength := (lowLength + highLength + 1) / 2
+		candidate := Clay__BuildEllipsizedString(head, tail, headBoundaries, tailBoundaries, candidateLength, middle)
+		width := Clay__MeasureTextCached(&candidate, textConfig).UnwrappedDimensions.Width
+		Clay__Array_Shrink(&currentContext.DynamicStringData, candidate.Length)
+		if width <= maxWidth {
+			lowLength = candidateLength
+		} else {
+			highLength = candidateLength - 1
+		}
+	}
+
+	// Leave the final string in place
+	wrappedLine.Line = Clay__BuildEllipsizedString(head, tail, headBoundaries, tailBoundaries, lowLength, middle)
+	wrappedLine.Dimensions.Width = Clay__MeasureTextCached(&wrappedLine.Line, textConfig).UnwrappedDimensions.Width
+}
+
+// Truncates the final span of a wrapped line of rich text so that the line fits within maxWidth. Fragments that start
+// past maxWidth are dropped. When forceEllipsis is true the line ends with an ellipsis even if it already fits, and
+// CLAY_TEXT_OVERFLOW_ELLIPSIS_MIDDLE behaves like CLAY_TEXT_OVERFLOW_ELLIPSIS as the end of the text isn't on the line.
+func Clay__EllipsizeRichTextLine(textElementData *Clay__TextElementData, wrappedLine *Clay__WrappedTextLine, maxWidth float32, forceEllipsis bool) {
+	for wrappedLine.Fragments.Length() > 1 && Clay__Slice_Get(&wrappedLine.Fragments, wrappedLine.Fragments.Length()-1).Offset.X >= maxWidth {
+		Clay__Slice_Shrink(&wrappedLine.Fragments, 1)
+		forceEllipsis = true
+	}
+	if wrappedLine.Fragments.Length() == 0 {
+		return
+	}
+	fragment := Clay__Slice_Get(&wrappedLine.Fragments, wrappedLine.Fragments.Length()-1)
+	spanConfig := textElementData.Spans[fragment.SpanIndex].Config
+	if forceEllipsis && spanConfig.Overflow == CLAY_TEXT_OVERFLOW_ELLIPSIS_MIDDLE {
+		endConfig := *spanConfig
+		endConfig.Overflow = CLAY_TEXT_OVERFLOW_ELLIPSIS
+		spanConfig = &endConfig
+	}
+	fragmentLine := Clay__WrappedTextLine{Line: fragment.Line, Dimensions: fragment.Dimensions}
+	Clay__EllipsizeWrappedTextLine(&fragmentLine, fragment.Line, spanConfig, maxWidth-fragment.Offset.X, forceEllipsis)
+	fragment.Line = fragmentLine.Line
+	fragment.Dimensions.Width = fragmentLine.Dimensions.Width
+	wrappedLine.Dimensions.Width = fragment.Offset.X + fragment.Dimensions.Width
+}
+
+// Appends up to length characters taken from the start of head (and the end of tail in middle mode) along with an
+// ellipsis to currentContext.DynamicStringData, and returns the appended string.
+// Characters are only removed at the grapheme cluster boundaries of head and tail, as returned by
+// Clay__AppendGraphemeBoundaries, and whitespace next to the ellipsis is dropped.
+func Clay__BuildEllipsizedString(head Clay_String, tail Clay_String, headBoundaries []int32, tailBoundaries []int32, length int32, middle bool) Clay_String {
+	currentContext := Clay_GetCurrentContext()
+	headLength := length
+	tailLength := int32(0)
+	if middle {
+		headLength = CLAY__MIN((length+1)/2, head.Length)
+		tailLength = CLAY__MIN(length-headLength, tail.Length)
+	}
+	headLength = Clay__NearestGraphemeBoundary(headBoundaries, headLength, false)
+	for headLength > 0 && (head.Chars[headLength-1] == ' ' || head.Chars[headLength-1] == '\n') {
+		headLength--
+	}
+	tailStart := tail.Length - tailLength
+	tailStart = Clay__NearestGraphemeBoundary(tailBoundaries, tailStart, true)
+	for tailStart < tail.Length && (tail.Chars[tailStart] == ' ' || tail.Chars[tailStart] == '\n') {
+		tailStart++
+	}
+
+	stringStart := currentContext.DynamicStringData.Length()
+	stringLength := headLength + CLAY__ELLIPSIS.Length + tail.Length - tailStart
+	Clay__Array_Grow(&currentContext.DynamicStringData, stringLength)
+	chars := mem.MArray_GetSlice(&currentContext.DynamicStringData, stringStart, stringStart+stringLength)
+	copy(chars, head.Chars[:headLength])
+	copy(chars[headLength:], CLAY__ELLIPSIS.Chars)
+	copy(chars[headLength+CLAY__ELLIPSIS.Length:], tail.Chars[tailStart:tail.Length])
+	return Clay_String{Length: stringLength, Chars: chars}
+}
@@ -0,0 +1,149 @@
+package clay
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// Lays out text inside a parent of the provided width and returns the contents of the emitted text lines.
+func testTruncatedTextLines(t *testing.T, width float32, text string, options ...TextOption) []string {
+	t.Helper()
+	newTestContext(t, 800, 600)
+	commands := runTestLayout(func() {
+		CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{},
+			CLAY(CLAY_ID("parent"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(width)}}},
+				CLAY_TEXT(text, options...),
+			),
+		)
+	})
+	lines := []string{}
+	for _, command := range commands {
+		if command.CommandType != CLAY_RENDER_COMMAND_TYPE_TEXT {
+			continue
+		}
+		contents := command.RenderData.Text.StringContents
+		lines = append(lines, string(contents.Chars[:contents.Length]))
+		if command.BoundingBox.Width > width {
+			t.Errorf("line %q is %v wide, which overflows the parent width %v", lines[len(lines)-1], command.BoundingBox.Width, width)
+		}
+	}
+	return lines
+}
+
+func testExpectTextLines(t *testing.T, expected []string, lines []string) {
+	t.Helper()
+	if !reflect.DeepEqual(expected, lines) {
+		t.Errorf("expected text lines %q, got %q", expected, lines)
+	}
+}
+
+func TestTextOverflowSingleLine(t *testing.T) {
+	t.Run("clip leaves the line alone", func(t *testing.T) {
+		newTestContext(t, 800, 600)
+		runTestLayout(func() {
+			CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{},
+				CLAY(CLAY_ID("parent"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(100)}}},
+					CLAY_TEXT("a_very_long_file_name.txt"),
+				),
+			)
+		})
+		// Without an ellipsis the text can't shrink below its longest word
+		testExpectBoundingBox(t, "parent", Clay_BoundingBox{X: 0, Y: 0, Width: 100, Height: 20})
+	})
+
+	t.Run("ellipsis", func(t *testing.T) {
+		lines := testTruncatedTextLines(t, 100, "a_very_long_file_name.txt", TextWithOverflow(CLAY_TEXT_OVERFLOW_ELLIPSIS))
+		testExpectTextLines(t, []string{"a_very_…"}, lines)
+	})
+
+	t.Run("ellipsis in middle", func(t *testing.T) {
+		lines := testTruncatedTextLines(t, 100, "a_very_long_file_name.txt", TextWithOverflow(CLAY_TEXT_OVERFLOW_ELLIPSIS_MIDDLE))
+		testExpectTextLines(t, []string{"a_ve…txt"}, lines)
+	})
+
+	t.Run("text that fits is unchanged", func(t *testing.T) {
+		lines := testTruncatedTextLines(t, 100, "short.txt", TextWithOverflow(CLAY_TEXT_OVERFLOW_ELLIPSIS))
+		testExpectTextLines(t, []string{"short.txt"}, lines)
+	})
+
+	t.Run("truncates at rune boundaries", func(t *testing.T) {
+		// "ééééé" is ten bytes, so only two whole runes fit alongside the ellipsis
+		lines := testTruncatedTextLines(t, 80, "ééééé", TextWithOverflow(CLAY_TEXT_OVERFLOW_ELLIPSIS))
+		testExpectTextLines(t, []string{"éé…"}, lines)
+	})
+}
+
+func TestTextOverflowMaxLines(t *testing.T) {
+	t.Run("clip", func(t *testing.T) {
+		lines := testTruncatedTextLines(t, 90, "one two three four five", TextWithMaxLines(2))
+		testExpectTextLines(t, []string{"one two", "three"}, lines)
+		testExpectBoundingBox(t, "parent", Clay_BoundingBox{X: 0, Y: 0, Width: 90, Height: 40})
+	})
+
+	t.Run("ellipsis", func(t *testing.T) {
+		lines := testTruncatedTextLines(t, 90, "one two three four five", TextWithMaxLines(2), TextWithOverflow(CLAY_TEXT_OVERFLOW_ELLIPSIS))
+		testExpectTextLines(t, []string{"one two", "three…"}, lines)
+		testExpectBoundingBox(t, "parent", Clay_BoundingBox{X: 0, Y: 0, Width: 90, Height: 40})
+	})
+
+	t.Run("ellipsis in middle keeps the end of the text", func(t *testing.T) {
+		lines := testTruncatedTextLines(t, 100, "one two three four", TextWithMaxLines(1), TextWithOverflow(CLAY_TEXT_OVERFLOW_ELLIPSIS_MIDDLE))
+		testExpectTextLines(t, []string{"one…four"}, lines)
+		testExpectBoundingBox(t, "parent", Clay_BoundingBox{X: 0, Y: 0, Width: 100, Height: 20})
+	})
+
+	t.Run("fewer lines than the limit", func(t *testing.T) {
+		lines := testTruncatedTextLines(t, 100, "one two", TextWithMaxLines(2), TextWithOverflow(CLAY_TEXT_OVERFLOW_ELLIPSIS))
+		testExpectTextLines(t, []string{"one two"}, lines)
+	})
+}
+
+func TestTextOverflowIsStableAcrossFrames(t *testing.T) {
+	newTestContext(t, 800, 600)
+	layout := func() {
+		CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{},
+			CLAY(CLAY_ID("parent"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(100)}}},
+				CLAY_TEXT("a_very_long_file_name.txt", TextWithOverflow(CLAY_TEXT_OVERFLOW_ELLIPSIS)),
+			),
+		)
+	}
+	for frame := 0; frame < 3; frame++ {
+		for _, command := range runTestLayout(layout) {
+			if command.CommandType != CLAY_RENDER_COMMAND_TYPE_TEXT {
+				continue
+			}
+			contents := command.RenderData.Text.StringContents
+			if text := string(contents.Chars[:contents.Length]); text != "a_very_…" {
+				t.Errorf("frame %d: expected %q, got %q", frame, "a_very_…", text)
+			}
+		}
+	}
+}
+
+func TestTextOverflowReportsDynamicStringCapacity(t *testing.T) {
+	// 0 leaves no room for generated text at all
+	for _, maxBytes := range []int32{4, 0} {
+		t.Run(fmt.Sprint(maxBytes), func(t *testing.T) {
+			errors := newTestContext(t, 800, 600)
+			currentContext := Clay_GetCurrentContext()
+			currentContext.MaxDynamicStringDataBytes = maxBytes
+			t.Cleanup(func() { currentContext.MaxDynamicStringDataBytes = Clay__defaultMaxDynamicStringDataBytes })
+			runTestLayout(func() {
+				CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{},
+					CLAY(CLAY_ID("parent"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(100)}}},
+						CLAY_TEXT("a_very_long_file_name.txt", TextWithOverflow(CLAY_TEXT_OVERFLOW_ELLIPSIS)),
+					),
+				)
+			})
+
+			if len(*errors) != 1 || (*errors)[0].ErrorType != CLAY_ERROR_TYPE_DYNAMIC_STRING_CAPACITY_EXCEEDED {
+				t.Fatalf("expected a single DYNAMIC_STRING_CAPACITY_EXCEEDED error, got %v", *errors)
+			}
+			// Running out of string space doesn't affect the handling of elements
+			if currentContext.BooleanWarnings.MaxElementsExceeded {
+				t.Errorf("expected MaxElementsExceeded to stay unset")
+			}
+		})
+	}
+}
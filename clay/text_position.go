@@ -0,0 +1,272 @@
+package clay
+
+// A wrapped line of a text element, as laid out by the last call to Clay_EndLayout. See Clay_GetTextLines.
+type Clay_TextLine struct {
+	// The byte offset of the start of the line within the text of the element. The text of a rich text element is the
+	// text of all of its spans joined together.
+	StartOffset int32
+	// The number of bytes of the text of the element that are displayed on the line. The whitespace or newline the
+	// line was broken at is left out, as is any text replaced by a hyphen or an ellipsis.
+	Length int32
+	// The position of the line in the layout. Every line is as tall as the line height of the element.
+	BoundingBox Clay_BoundingBox
+}
+
+// A part of a wrapped line of text that is measured with a single text config: the whole line of a text element, or
+// one fragment of a line of rich text.
+type Clay__TextRun struct {
+	// The text as displayed, which may end with a hyphen or an ellipsis.
+	Text      Clay_String
+	BaseChars []byte
+	// The byte offset of the run within the text of the element, and the number of displayed bytes taken from it.
+	StartOffset int32
+	Length      int32
+	Config      *Clay_TextElementConfig
+	BoundingBox Clay_BoundingBox
+}
+
+// Returns the wrapped lines of the text element with the provided id, as laid out by the last call to Clay_EndLayout.
+// Returns nil if no text element with the id was declared during the last layout, see CLAY_TEXT_WITH_ID.
+func Clay_GetTextLines(elementId Clay_ElementId) []Clay_TextLine {
+	mapItem := Clay__GetLaidOutTextElement(elementId)
+	if mapItem == nil {
+		return nil
+	}
+	textElementData := mapItem.LayoutElement.ChildrenOrTextContent.TextElementData
+	lines := make([]Clay_TextLine, textElementData.WrappedLines.Length())
+	var runs []Clay__TextRun
+	for lineIndex := range lines {
+		var lineBox Clay_BoundingBox
+		lineBox, runs = Clay__GetTextLineRuns(mapItem, int32(lineIndex), runs[:0])
+		lastRun := &runs[len(runs)-1]
+		lines[lineIndex] = Clay_TextLine{
+			StartOffset: runs[0].StartOffset,
+			Length:      lastRun.StartOffset + lastRun.Length - runs[0].StartOffset,
+			BoundingBox: lineBox,
+		}
+	}
+	return lines
+}
+
+// Returns the byte offset within the text of the text element with the provided id that is closest to position, e.g.
+// to place the caret where the text was clicked. Offsets always fall on grapheme cluster boundaries. Positions above or
+// below the text resolve to the first or last line, and positions beside a line to its start or end.
+// Returns false if no text element with the id was declared during the last layout.
+func Clay_GetTextPositionAt(elementId Clay_ElementId, position Clay_Vector2) (int32, bool) {
+	mapItem := Clay__GetLaidOutTextElement(elementId)
+	if mapItem == nil {
+		return 0, false
+	}
+	textElementData := mapItem.LayoutElement.ChildrenOrTextContent.TextElementData
+	lineCount := textElementData.WrappedLines.Length()
+	if lineCount == 0 {
+		return 0, true
+	}
+	lineIndex := int32(0)
+	if lineHeight := Clay__TextElementLineHeight(mapItem.LayoutElement); lineHeight > 0 {
+		lineIndex = CLAY__MIN(CLAY__MAX(int32((position.Y-mapItem.BoundingBox.Y)/lineHeight), 0), lineCount-1)
+	}
+	_, runs := Clay__GetTextLineRuns(mapItem, lineIndex, nil)
+
+	// Find the run under the position, or else the closest one
+	closestRun := &runs[0]
+	closestDistance := float32(CLAY__MAXFLOAT)
+	for i := range runs {
+		runBox := runs[i].BoundingBox
+		distance := CLAY__MAX(CLAY__MAX(runBox.X-position.X, position.X-runBox.X-runBox.Width), 0)
+		if distance < closestDistance {
+			closestRun = &runs[i]
+			closestDistance = distance
+		}
+	}
+	return Clay__TextRunOffsetAt(closestRun, position.X), true
+}
+
+// Returns the rectangle of the caret placed at the provided byte offset within the text of the text element with the
+// provided id. The rectangle has no width and is as tall as the line. An offset at which a line was wrapped is placed at
+// the start of the following line, and an offset that isn't displayed, e.g. within text replaced by an ellipsis, is
+// placed after the closest displayed text before it.
+// Returns false if no text element with the id was declared during the last layout.
+func Clay_GetCaretRect(elementId Clay_ElementId, offset int32) (Clay_BoundingBox, bool) {
+	mapItem := Clay__GetLaidOutTextElement(elementId)
+	if mapItem == nil {
+		return Clay_BoundingBox{}, false
+	}
+	textElementData := mapItem.LayoutElement.ChildrenOrTextContent.TextElementData
+	if textElementData.WrappedLines.Length() == 0 {
+		return Clay_BoundingBox{X: mapItem.BoundingBox.X, Y: mapItem.BoundingBox.Y, Height: mapItem.BoundingBox.Height}, true
+	}
+
+	// Find the last run that starts at or before the offset
+	var runs []Clay__TextRun
+	caretRun := Clay__TextRun{StartOffset: -1}
+	for lineIndex := int32(0); lineIndex < textElementData.WrappedLines.Length(); lineIndex++ {
+		_, runs = Clay__GetTextLineRuns(mapItem, lineIndex, runs[:0])
+		for i := range runs {
+			if caretRun.StartOffset == -1 || runs[i].StartOffset <= offset {
+				caretRun = runs[i]
+			}
+		}
+	}
+	runOffset := CLAY__MIN(CLAY__MAX(offset-caretRun.StartOffset, 0), caretRun.Length)
+	runOffset = Clay__GraphemeBoundary(caretRun.Text.Chars[:caretRun.Length], runOffset, false)
+	return Clay_BoundingBox{
+		X:      Clay__TextRunCaretX(&caretRun, runOffset),
+		Y:      caretRun.BoundingBox.Y,
+		Height: caretRun.BoundingBox.Height,
+	}, true
+}
+
+// Returns the byte offset of the grapheme cluster boundary before the byte offset within text, so that a caret can be
+// moved back over a whole user-perceived character, like the emoji sequences and combining marks that clay never splits.
+func Clay_PreviousGraphemeBoundary(text string, offset int32) int32 {
+	if offset <= 0 {
+		return 0
+	}
+	offset = CLAY__MIN(offset, int32(len(text)))
+	return Clay__GraphemeBoundary([]byte(text), offset-1, false)
+}
+
+// Returns the byte offset of the grapheme cluster boundary after the byte offset within text, see
+// Clay_PreviousGraphemeBoundary.
+func Clay_NextGraphemeBoundary(text string, offset int32) int32 {
+	if offset >= int32(len(text)) {
+		return int32(len(text))
+	}
+	offset = CLAY__MAX(offset, 0)
+	return Clay__GraphemeBoundary([]byte(text), offset+1, true)
+}
+
+// Returns the hash map item of the text element with the provided id if it was declared during the last layout, or nil.
+func Clay__GetLaidOutTextElement(elementId Clay_ElementId) *Clay_LayoutElementHashMapItem {
+	currentContext := Clay_GetCurrentContext()
+	if currentContext.BooleanWarnings.MaxElementsExceeded {
+		return nil
+	}
+	mapItem := Clay__GetHashMapItem(elementId.Id)
+	// Elements declared during the last layout have the generation that Clay_BeginLayout moves on to next
+	if mapItem == &Clay_LayoutElementHashMapItem_DEFAULT || mapItem.Generation != currentContext.Generation+1 {
+		return nil
+	}
+	if !Clay__ElementHasConfig(mapItem.LayoutElement, CLAY__ELEMENT_CONFIG_TYPE_TEXT) || mapItem.LayoutElement.ChildrenOrTextContent.TextElementData == nil {
+		return nil
+	}
+	return mapItem
+}
+
+// Returns the height of each wrapped line of a text element.
+func Clay__TextElementLineHeight(element *Clay_LayoutElement) float32 {
+	textConfig := Clay__FindElementConfigWithType(element, CLAY__ELEMENT_CONFIG_TYPE_TEXT).TextElementConfig
+	if textConfig.LineHeight > 0 {
+		return float32(textConfig.LineHeight)
+	}
+	return element.ChildrenOrTextContent.TextElementData.PreferredDimensions.Height
+}
+
+// Appends the runs of the wrapped line at lineIndex of a laid out text element to runs in text order, and returns the
+// bounding box of the line along with them. A line without any text gets a single empty run at its start, so that
+// there is always at least one run to place the caret in. The boxes are mirrored along with the layout when
+// RTL mirroring is enabled.
+func Clay__GetTextLineRuns(mapItem *Clay_LayoutElementHashMapItem, lineIndex int32, runs []Clay__TextRun) (Clay_BoundingBox, []Clay__TextRun) {
+	currentContext := Clay_GetCurrentContext()
+	element := mapItem.LayoutElement
+	elementBox := mapItem.BoundingBox
+	textConfig := Clay__FindElementConfigWithType(element, CLAY__ELEMENT_CONFIG_TYPE_TEXT).TextElementConfig
+	textElementData := element.ChildrenOrTextContent.TextElementData
+	lineHeight := Clay__TextElementLineHeight(element)
+	wrappedLine := Clay__Slice_Get(&textElementData.WrappedLines, lineIndex)
+	lineBox := Clay_BoundingBox{
+		X:      elementBox.X + Clay__TextLineOffset(textConfig, elementBox.Width, wrappedLine.Dimensions.Width),
+		Y:      elementBox.Y + float32(lineIndex)*lineHeight,
+		Width:  wrappedLine.Dimensions.Width,
+		Height: lineHeight,
+	}
+	mirror := func(box Clay_BoundingBox) Clay_BoundingBox {
+		// The render commands of the lines were mirrored along with the element
+		if currentContext.RTLEnabled {
+			box.X = 2*elementBox.X + elementBox.Width - box.X - box.Width
+		}
+		return box
+	}
+
+	if len(textElementData.Spans) == 0 {
+		runs = append(runs, Clay__TextRun{
+			Text:        wrappedLine.Line,
+			BaseChars:   textElementData.Text.Chars,
+			StartOffset: wrappedLine.StartOffset,
+			Length:      Clay__DisplayedTextLength(wrappedLine.Line, textElementData.Text.Chars[wrappedLine.StartOffset:textElementData.Text.Length]),
+			Config:      textConfig,
+			BoundingBox: mirror(lineBox),
+		})
+		return mirror(lineBox), runs
+	}
+	for i := int32(0); i < wrappedLine.Fragments.Length(); i++ {
+		fragment := Clay__Slice_Get(&wrappedLine.Fragments, i)
+		span := &textElementData.Spans[fragment.SpanIndex]
+		runs = append(runs, Clay__TextRun{
+			Text:        fragment.Line,
+			BaseChars:   span.Text.Chars,
+			StartOffset: Clay__TextSpanOffset(textElementData.Spans, fragment.SpanIndex) + fragment.StartOffset,
+			Length:      Clay__DisplayedTextLength(fragment.Line, span.Text.Chars[fragment.StartOffset:span.Text.Length]),
+			Config:      span.Config,
+			BoundingBox: mirror(Clay_BoundingBox{X: lineBox.X + fragment.Offset.X, Y: lineBox.Y, Width: fragment.Dimensions.Width, Height: lineHeight}),
+		})
+	}
+	if len(runs) == 0 {
+		runs = append(runs, Clay__TextRun{
+			StartOffset: wrappedLine.StartOffset,
+			Config:      textConfig,
+			BoundingBox: mirror(Clay_BoundingBox{X: lineBox.X, Y: lineBox.Y, Height: lineHeight}),
+		})
+	}
+	return mirror(lineBox), runs
+}
+
+// Returns the number of bytes at the start of displayed that were taken from source, ending at a grapheme cluster
+// boundary. Lines that were hyphenated or truncated end with characters that aren't in the source text.
+func Clay__DisplayedTextLength(displayed Clay_String, source []byte) int32 {
+	length := int32(0)
+	for length < displayed.Length && length < int32(len(source)) && displayed.Chars[length] == source[length] {
+		length++
+	}
+	if length == displayed.Length {
+		return length
+	}
+	return Clay__GraphemeBoundary(displayed.Chars[:displayed.Length], length, false)
+}
+
+// Returns the horizontal position of the caret placed offset bytes into the run, measured with the measure function.
+func Clay__TextRunCaretX(run *Clay__TextRun, offset int32) float32 {
+	if offset <= 0 {
+		return run.BoundingBox.X
+	}
+	currentContext := Clay_GetCurrentContext()
+	return run.BoundingBox.X + Clay__MeasureText(Clay_StringSlice{
+		Length:    offset,
+		Chars:     run.Text.Chars,
+		BaseChars: run.BaseChars,
+	},
+		run.Config,
+		currentContext.MeasureTextUserData,
+	).Width
+}
+
+// Returns the byte offset within the text of the element of the grapheme cluster boundary in the run that is closest
+// to the horizontal position x.
+func Clay__TextRunOffsetAt(run *Clay__TextRun, x float32) int32 {
+	boundaries := Clay__GraphemeBoundaries(run.Text.Chars[:run.Length])
+	// Binary search for the first boundary at or past x, then step back if the boundary before it is closer
+	low, high := 0, len(boundaries)-1
+	for low < high {
+		middle := (low + high) / 2
+		if Clay__TextRunCaretX(run, boundaries[middle]) < x {
+			low = middle + 1
+		} else {
+			high = middle
+		}
+	}
+	if low > 0 && x-Clay__TextRunCaretX(run, boundaries[low-1]) < Clay__TextRunCaretX(run, boundaries[low])-x {
+		low--
+	}
+	return run.StartOffset + boundaries[low]
+}
@@ -0,0 +1,161 @@
+package clay
+
+import (
+	"testing"
+)
+
+// Lays out a text element with the id "text" inside a parent of the provided width.
+func testTextPositionLayout(width float32, text ClayContainer) {
+	runTestLayout(func() {
+		CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{},
+			CLAY(CLAY_ID("parent"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(width)}}},
+				text,
+			),
+		)
+	})
+}
+
+func TestGetTextLines(t *testing.T) {
+	newTestContext(t, 800, 600)
+	testTextPositionLayout(80, CLAY_TEXT_WITH_ID(CLAY_ID("text"), "one two three"))
+	expected := []Clay_TextLine{
+		{StartOffset: 0, Length: 7, BoundingBox: Clay_BoundingBox{X: 0, Y: 0, Width: 70, Height: 20}},
+		{StartOffset: 8, Length: 5, BoundingBox: Clay_BoundingBox{X: 0, Y: 20, Width: 50, Height: 20}},
+	}
+	lines := Clay_GetTextLines(CLAY_ID("text"))
+	if len(lines) != len(expected) {
+		t.Fatalf("expected %d lines, got %v", len(expected), lines)
+	}
+	for i := range expected {
+		if lines[i] != expected[i] {
+			t.Errorf("line %d: expected %v, got %v", i, expected[i], lines[i])
+		}
+	}
+	if Clay_GetTextLines(CLAY_ID("missing")) != nil {
+		t.Errorf("expected no lines for an undeclared element")
+	}
+}
+
+func TestGetTextPositionAt(t *testing.T) {
+	newTestContext(t, 800, 600)
+	testTextPositionLayout(80, CLAY_TEXT_WITH_ID(CLAY_ID("text"), "one two three"))
+	tests := []struct {
+		name     string
+		position Clay_Vector2
+		expected int32
+	}{
+		{"rounds down to the closer boundary", Clay_Vector2{X: 33, Y: 5}, 3},
+		{"rounds up to the closer boundary", Clay_Vector2{X: 36, Y: 5}, 4},
+		{"second line", Clay_Vector2{X: 21, Y: 25}, 10},
+		{"before a line", Clay_Vector2{X: -5, Y: 25}, 8},
+		{"past the end of a line", Clay_Vector2{X: 200, Y: 5}, 7},
+		{"below the text", Clay_Vector2{X: 200, Y: 500}, 13},
+	}
+	for _, test := range tests {
+		offset, found := Clay_GetTextPositionAt(CLAY_ID("text"), test.position)
+		if !found || offset != test.expected {
+			t.Errorf("%s: expected offset %d, got %d (found %v)", test.name, test.expected, offset, found)
+		}
+	}
+	if _, found := Clay_GetTextPositionAt(CLAY_ID("missing"), Clay_Vector2{}); found {
+		t.Errorf("expected no position for an undeclared element")
+	}
+}
+
+func TestGetCaretRect(t *testing.T) {
+	newTestContext(t, 800, 600)
+	testTextPositionLayout(80, CLAY_TEXT_WITH_ID(CLAY_ID("text"), "one two three"))
+	tests := []struct {
+		name     string
+		offset   int32
+		expected Clay_BoundingBox
+	}{
+		{"start", 0, Clay_BoundingBox{X: 0, Y: 0, Height: 20}},
+		{"end of a wrapped line", 7, Clay_BoundingBox{X: 70, Y: 0, Height: 20}},
+		{"start of the next line", 8, Clay_BoundingBox{X: 0, Y: 20, Height: 20}},
+		{"within a line", 10, Clay_BoundingBox{X: 20, Y: 20, Height: 20}},
+		{"past the end", 50, Clay_BoundingBox{X: 50, Y: 20, Height: 20}},
+	}
+	for _, test := range tests {
+		rect, found := Clay_GetCaretRect(CLAY_ID("text"), test.offset)
+		if !found || rect != test.expected {
+			t.Errorf("%s: expected %v, got %v (found %v)", test.name, test.expected, rect, found)
+		}
+	}
+}
+
+func TestTextPositionsKeepGraphemesWhole(t *testing.T) {
+	newTestContext(t, 800, 600)
+	// "é" is written as "e" followed by a combining acute accent, 3 bytes wide
+	testTextPositionLayout(200, CLAY_TEXT_WITH_ID(CLAY_ID("text"), "ae\u0301b"))
+	if offset, _ := Clay_GetTextPositionAt(CLAY_ID("text"), Clay_Vector2{X: 22, Y: 5}); offset != 1 {
+		t.Errorf("expected the position inside the cluster to resolve to its start, got %d", offset)
+	}
+	if rect, _ := Clay_GetCaretRect(CLAY_ID("text"), 2); rect.X != 10 {
+		t.Errorf("expected the caret inside the cluster to be placed at its start, got %v", rect)
+	}
+}
+
+func TestTextPositionsOfTruncatedText(t *testing.T) {
+	newTestContext(t, 800, 600)
+	testTextPositionLayout(100, CLAY_TEXT_WITH_ID(CLAY_ID("text"), "one two three four", TextWithMaxLines(1), TextWithOverflow(CLAY_TEXT_OVERFLOW_ELLIPSIS)))
+	lines := Clay_GetTextLines(CLAY_ID("text"))
+	if len(lines) != 1 || lines[0].Length != 7 {
+		t.Fatalf("expected a single line showing 7 bytes of the text, got %v", lines)
+	}
+	if rect, _ := Clay_GetCaretRect(CLAY_ID("text"), 12); rect.X != 70 {
+		t.Errorf("expected the caret in hidden text to be placed after the displayed text, got %v", rect)
+	}
+	if offset, _ := Clay_GetTextPositionAt(CLAY_ID("text"), Clay_Vector2{X: 95, Y: 5}); offset != 7 {
+		t.Errorf("expected a position over the ellipsis to resolve to the end of the displayed text, got %d", offset)
+	}
+}
+
+func TestRichTextPositions(t *testing.T) {
+	newTestContext(t, 800, 600)
+	testTextPositionLayout(200, CLAY_RICH_TEXT_WITH_ID(CLAY_ID("text"), []TextSpan{
+		CLAY_TEXT_SPAN("ab "),
+		CLAY_TEXT_SPAN("cd", TextWithFontId(1)),
+		CLAY_TEXT_SPAN("\n"),
+		CLAY_TEXT_SPAN("\nef"),
+	}))
+	lines := Clay_GetTextLines(CLAY_ID("text"))
+	expected := []Clay_TextLine{
+		{StartOffset: 0, Length: 5, BoundingBox: Clay_BoundingBox{X: 0, Y: 0, Width: 50, Height: 20}},
+		{StartOffset: 6, Length: 0, BoundingBox: Clay_BoundingBox{X: 0, Y: 20, Width: 0, Height: 20}},
+		{StartOffset: 7, Length: 2, BoundingBox: Clay_BoundingBox{X: 0, Y: 40, Width: 20, Height: 20}},
+	}
+	if len(lines) != len(expected) {
+		t.Fatalf("expected %d lines, got %v", len(expected), lines)
+	}
+	for i := range expected {
+		if lines[i] != expected[i] {
+			t.Errorf("line %d: expected %v, got %v", i, expected[i], lines[i])
+		}
+	}
+	if offset, _ := Clay_GetTextPositionAt(CLAY_ID("text"), Clay_Vector2{X: 38, Y: 5}); offset != 4 {
+		t.Errorf("expected the position in the second span to resolve to offset 4, got %d", offset)
+	}
+	if offset, _ := Clay_GetTextPositionAt(CLAY_ID("text"), Clay_Vector2{X: 38, Y: 25}); offset != 6 {
+		t.Errorf("expected the position on the empty line to resolve to offset 6, got %d", offset)
+	}
+	if rect, _ := Clay_GetCaretRect(CLAY_ID("text"), 4); rect != (Clay_BoundingBox{X: 40, Y: 0, Height: 20}) {
+		t.Errorf("expected the caret in the second span at x 40, got %v", rect)
+	}
+	if rect, _ := Clay_GetCaretRect(CLAY_ID("text"), 8); rect != (Clay_BoundingBox{X: 10, Y: 40, Height: 20}) {
+		t.Errorf("expected the caret on the last line at x 10, got %v", rect)
+	}
+}
+
+func TestTextPositionsWithRTL(t *testing.T) {
+	newTestContext(t, 800, 600)
+	Clay_SetRTLEnabled(true)
+	testTextPositionLayout(100, CLAY_TEXT_WITH_ID(CLAY_ID("text"), "abc"))
+	// The text is mirrored to the right edge of the layout, and still reads from left to right within the line
+	if rect, _ := Clay_GetCaretRect(CLAY_ID("text"), 1); rect.X != 780 {
+		t.Errorf("expected the caret at x 780, got %v", rect)
+	}
+	if offset, _ := Clay_GetTextPositionAt(CLAY_ID("text"), Clay_Vector2{X: 792, Y: 5}); offset != 2 {
+		t.Errorf("expected offset 2, got %d", offset)
+	}
+}
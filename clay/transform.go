@@ -0,0 +1,90 @@
+package clay
+
+// Transforms and fades an element and all of its descendants when they are rendered, without affecting layout.
+// The element is scaled, then rotated around Origin, then translated. Renderers apply the transform to all render
+// commands between the CLAY_RENDER_COMMAND_TYPE_TRANSFORM_START and CLAY_RENDER_COMMAND_TYPE_TRANSFORM_END commands
+// of the element, including those of floating descendants. Pointer hit testing and culling still use the untransformed
+// bounding boxes.
+type Clay_TransformElementConfig struct {
+	// Moves the element after it has been scaled and rotated.
+	Translation Clay_Vector2
+	// Scales the element around Origin. An axis that is 0 isn't scaled unless ScaleSet is set.
+	Scale Clay_Vector2
+	// Uses Scale as is, so that an axis that is 0 scales the element away, e.g. when zooming in from nothing.
+	ScaleSet bool
+	// Rotates the element clockwise around Origin, in radians.
+	Rotation float32
+	// The point that the element is scaled and rotated around, as a fraction of its size, e.g. {0.5, 0.5} for its
+	// center. Defaults to its top left corner.
+	Origin Clay_Vector2
+	// The opacity of the element and its descendants, between 0 and 1. 0 is treated as 1 unless OpacitySet is set.
+	Opacity float32
+	// Uses Opacity as is, so that 0 hides the element, e.g. at the end of a fade out.
+	OpacitySet bool
+}
+
+var Clay__TransformElementConfig_DEFAULT = Clay_TransformElementConfig{}
+
+func Clay__TransformRenderData(config *Clay_TransformElementConfig) Clay_TransformRenderData {
+	renderData := Clay_TransformRenderData{
+		Translation: config.Translation,
+		Scale:       config.Scale,
+		Rotation:    config.Rotation,
+		Origin:      config.Origin,
+		Opacity:     config.Opacity,
+	}
+	if renderData.Scale.X == 0 && !config.ScaleSet {
+		renderData.Scale.X = 1
+	}
+	if renderData.Scale.Y == 0 && !config.ScaleSet {
+		renderData.Scale.Y = 1
+	}
+	if renderData.Opacity == 0 && !config.OpacitySet {
+		renderData.Opacity = 1
+	}
+	return renderData
+}
+
+// Floating elements are laid out as separate trees, so the transforms of the elements they were declared inside of are
+// started again around them. Starts the transforms of the declaring ancestors of the element, outermost first, that are
+// inside of clipElementId when insideClip is set, or outside of it otherwise. Returns the number of transforms started.
+func Clay__StartAncestorTransforms(elementId uint32, clipElementId uint32, insideClip bool, zIndex int16) int32 {
+	return Clay__StartAncestorTransformsFrom(Clay__GetHashMapItem(elementId).ParentId, clipElementId, insideClip, clipElementId != 0, zIndex)
+}
+
+func Clay__StartAncestorTransformsFrom(elementId uint32, clipElementId uint32, insideClip bool, inside bool, zIndex int16) int32 {
+	currentContext := Clay_GetCurrentContext()
+	if elementId == 0 {
+		return 0
+	}
+	hashMapItem := Clay__GetHashMapItem(elementId)
+	if hashMapItem.Generation != currentContext.Generation+1 || hashMapItem.LayoutElement == nil {
+		return 0
+	}
+	if elementId == clipElementId {
+		inside = false
+	}
+	count := Clay__StartAncestorTransformsFrom(hashMapItem.ParentId, clipElementId, insideClip, inside, zIndex)
+	config := Clay__FindElementConfigWithType(hashMapItem.LayoutElement, CLAY__ELEMENT_CONFIG_TYPE_TRANSFORM).TransformElementConfig
+	if config != nil && inside == insideClip {
+		Clay__AddRenderCommand(Clay_RenderCommand{
+			BoundingBox: hashMapItem.BoundingBox,
+			RenderData:  Clay_RenderData{Transform: Clay__TransformRenderData(config)},
+			Id:          elementId,
+			ZIndex:      zIndex,
+			CommandType: CLAY_RENDER_COMMAND_TYPE_TRANSFORM_START,
+		})
+		count++
+	}
+	return count
+}
+
+func Clay__EndTransforms(elementId uint32, count int32, zIndex int16) {
+	for i := int32(0); i < count; i++ {
+		Clay__AddRenderCommand(Clay_RenderCommand{
+			Id:          elementId,
+			ZIndex:      zIndex,
+			CommandType: CLAY_RENDER_COMMAND_TYPE_TRANSFORM_END,
+		})
+	}
+}
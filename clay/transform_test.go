@@ -0,0 +1,133 @@
+package clay
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Returns the types of the render commands, in order.
+func testRenderCommandTypes(renderCommands []Clay_RenderCommand) []string {
+	types := make([]string, len(renderCommands))
+	for i, renderCommand := range renderCommands {
+		types[i] = renderCommand.CommandType.String()
+	}
+	return types
+}
+
+func TestTransformWrapsDescendantRenderCommands(t *testing.T) {
+	newTestContext(t, 800, 600)
+	renderCommands := runTestLayout(func() {
+		CLAY_ROOT(CLAY_ID("card"), Clay_ElementDeclaration{
+			Layout:    Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(100), Height: CLAY_SIZING_FIXED(50)}},
+			Transform: Clay_TransformElementConfig{Scale: Clay_Vector2{X: 2}, Origin: Clay_Vector2{X: 0.5, Y: 0.5}},
+		}, CLAY(CLAY_ID("child"), Clay_ElementDeclaration{
+			Layout:          Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(20), Height: CLAY_SIZING_FIXED(20)}},
+			BackgroundColor: Clay_Color{R: 255, A: 255},
+		}))
+	})
+
+	expectedTypes := []string{"TRANSFORM_START", "RECTANGLE", "TRANSFORM_END"}
+	if types := testRenderCommandTypes(renderCommands); !reflect.DeepEqual(types, expectedTypes) {
+		t.Fatalf("expected render commands %v, got %v", expectedTypes, types)
+	}
+	transform := testFindRenderCommand(t, renderCommands, "card", CLAY_RENDER_COMMAND_TYPE_TRANSFORM_START)
+	expectedTransform := Clay_TransformRenderData{Scale: Clay_Vector2{X: 2, Y: 1}, Origin: Clay_Vector2{X: 0.5, Y: 0.5}, Opacity: 1}
+	if transform.RenderData.Transform != expectedTransform {
+		t.Errorf("expected unset scale and opacity to resolve to 1, got %+v", transform.RenderData.Transform)
+	}
+	expectedBox := Clay_BoundingBox{Width: 100, Height: 50}
+	if transform.BoundingBox != expectedBox {
+		t.Errorf("expected the transform to start with the bounding box of the element %+v, got %+v", expectedBox, transform.BoundingBox)
+	}
+	if box := testBoundingBox(t, "child"); box != (Clay_BoundingBox{Width: 20, Height: 20}) {
+		t.Errorf("expected transforms not to affect layout, got %+v", box)
+	}
+}
+
+func TestTransformIsNotCulled(t *testing.T) {
+	newTestContext(t, 800, 600)
+	renderCommands := runTestLayout(func() {
+		CLAY_ROOT(CLAY_ID("outer"), Clay_ElementDeclaration{
+			Layout: Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(100), Height: CLAY_SIZING_FIXED(50)}, Padding: Clay_Padding{Left: 1000}},
+		}, CLAY(CLAY_ID("card"), Clay_ElementDeclaration{
+			Layout:    Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(100), Height: CLAY_SIZING_FIXED(50)}},
+			Transform: Clay_TransformElementConfig{Translation: Clay_Vector2{X: -1000}},
+		}))
+	})
+
+	expectedTypes := []string{"TRANSFORM_START", "TRANSFORM_END"}
+	if types := testRenderCommandTypes(renderCommands); !reflect.DeepEqual(types, expectedTypes) {
+		t.Errorf("expected render commands %v, got %v", expectedTypes, types)
+	}
+}
+
+func TestTransformKeepsZeroScaleAndOpacityWhenSet(t *testing.T) {
+	newTestContext(t, 800, 600)
+	transform := Clay_TransformElementConfig{Scale: Clay_Vector2{X: 0.5}, ScaleSet: true, OpacitySet: true}
+	renderCommands := runTestLayout(func() {
+		CLAY_ROOT(CLAY_ID("card"), Clay_ElementDeclaration{
+			Layout:    Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(100), Height: CLAY_SIZING_FIXED(50)}},
+			Transform: transform,
+		})
+	})
+
+	expectedTransform := Clay_TransformRenderData{Scale: Clay_Vector2{X: 0.5}}
+	if start := testFindRenderCommand(t, renderCommands, "card", CLAY_RENDER_COMMAND_TYPE_TRANSFORM_START); start.RenderData.Transform != expectedTransform {
+		t.Errorf("expected set scale and opacity to keep their zeros, got %+v", start.RenderData.Transform)
+	}
+}
+
+func TestTransformAppliesToFloatingDescendants(t *testing.T) {
+	newTestContext(t, 800, 600)
+	renderCommands := runTestLayout(func() {
+		CLAY_ROOT(CLAY_ID("list"), Clay_ElementDeclaration{
+			Layout: Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(200), Height: CLAY_SIZING_FIXED(200)}},
+			Clip:   Clay_ClipElementConfig{Vertical: true},
+		}, CLAY(CLAY_ID("card"), Clay_ElementDeclaration{
+			Layout:    Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(100), Height: CLAY_SIZING_FIXED(50)}},
+			Transform: Clay_TransformElementConfig{Opacity: 0.5},
+		}, CLAY(CLAY_ID("tooltip"), Clay_ElementDeclaration{
+			Layout:          Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(20), Height: CLAY_SIZING_FIXED(20)}},
+			BackgroundColor: Clay_Color{R: 255, A: 255},
+			Floating:        Clay_FloatingElementConfig{AttachTo: CLAY_ATTACH_TO_PARENT, ClipTo: CLAY_CLIP_TO_ATTACHED_PARENT},
+		})))
+	})
+
+	// The transform of the card is inside the clipping rectangle of the list
+	expectedTypes := []string{
+		"SCISSOR_START", "TRANSFORM_START", "TRANSFORM_END", "SCISSOR_END",
+		"SCISSOR_START", "TRANSFORM_START", "RECTANGLE", "TRANSFORM_END", "SCISSOR_END",
+	}
+	if types := testRenderCommandTypes(renderCommands); !reflect.DeepEqual(types, expectedTypes) {
+		t.Fatalf("expected render commands %v, got %v", expectedTypes, types)
+	}
+	if transform := renderCommands[5]; transform.Id != CLAY_ID("card").Id || transform.RenderData.Transform.Opacity != 0.5 {
+		t.Errorf("expected the floating element to be wrapped in the transform of the card, got %+v", transform)
+	}
+}
+
+func TestTransformIsMirroredInRTL(t *testing.T) {
+	newTestContext(t, 800, 600)
+	Clay_SetRTLEnabled(true)
+	renderCommands := runTestLayout(func() {
+		CLAY_ROOT(CLAY_ID("card"), Clay_ElementDeclaration{
+			Layout:    Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(100), Height: CLAY_SIZING_FIXED(50)}},
+			Transform: Clay_TransformElementConfig{Translation: Clay_Vector2{X: 10, Y: 5}, Rotation: 0.5, Origin: Clay_Vector2{X: 0.25, Y: 0.75}},
+		})
+	})
+
+	transform := testFindRenderCommand(t, renderCommands, "card", CLAY_RENDER_COMMAND_TYPE_TRANSFORM_START)
+	expectedTransform := Clay_TransformRenderData{
+		Translation: Clay_Vector2{X: -10, Y: 5},
+		Scale:       Clay_Vector2{X: 1, Y: 1},
+		Rotation:    -0.5,
+		Origin:      Clay_Vector2{X: 0.75, Y: 0.75},
+		Opacity:     1,
+	}
+	if transform.RenderData.Transform != expectedTransform {
+		t.Errorf("expected mirrored transform %+v, got %+v", expectedTransform, transform.RenderData.Transform)
+	}
+	if expectedBox := (Clay_BoundingBox{X: 700, Width: 100, Height: 50}); transform.BoundingBox != expectedBox {
+		t.Errorf("expected mirrored bounding box %+v, got %+v", expectedBox, transform.BoundingBox)
+	}
+}
@@ -0,0 +1,670 @@
+package clay
+
+import (
+	"slices"
+
+	"github.com/zodimo/clay-go/pkg/mem"
+)
+
+// Maps the linear progress of a transition, between 0 and 1, to its eased progress. Easing functions should return 0
+// for 0 and 1 for 1, but may overshoot in between.
+type Clay_EasingFunction func(t float32) float32
+
+func Clay_EaseLinear(t float32) float32 {
+	return t
+}
+
+func Clay_EaseInQuad(t float32) float32 {
+	return t * t
+}
+
+func Clay_EaseOutQuad(t float32) float32 {
+	return t * (2 - t)
+}
+
+func Clay_EaseInOutQuad(t float32) float32 {
+	if t < 0.5 {
+		return 2 * t * t
+	}
+	return -1 + (4-2*t)*t
+}
+
+func Clay_EaseOutCubic(t float32) float32 {
+	t--
+	return t*t*t + 1
+}
+
+// Selects the properties of an element that are animated when they change.
+type Clay_TransitionProperty uint8
+
+const (
+	CLAY_TRANSITION_PROPERTY_POSITION Clay_TransitionProperty = 1 << iota
+	CLAY_TRANSITION_PROPERTY_SIZE
+	// Animates BackgroundColor. Gradients aren't interpolated, so a changed BackgroundGradient is drawn right away and
+	// is only faded along with the element.
+	CLAY_TRANSITION_PROPERTY_BACKGROUND_COLOR
+	CLAY_TRANSITION_PROPERTY_CORNER_RADIUS
+	CLAY_TRANSITION_PROPERTY_ALL = CLAY_TRANSITION_PROPERTY_POSITION | CLAY_TRANSITION_PROPERTY_SIZE | CLAY_TRANSITION_PROPERTY_BACKGROUND_COLOR | CLAY_TRANSITION_PROPERTY_CORNER_RADIUS
+)
+
+// Controls how an element animates when it appears or disappears. The element animates between its laid out state and
+// the state described here, e.g. an Offset of {Y: 20} with an Opacity of 0 slides it up into place while fading it in.
+type Clay_TransitionEnterExitConfig struct {
+	Enabled bool
+	// How far the element is moved from its laid out position.
+	Offset Clay_Vector2
+	// The fraction of its width and height that the element is shrunk by around its center, between 0 and 1.
+	Shrink Clay_Vector2
+	// The opacity the element is faded to, between 0 and 1.
+	Opacity float32
+}
+
+// Controls how an element animates between layouts. Elements with a Duration keep their bounding box, background color
+// and corner radius from the last layout under their id, and animate the Properties that changed from where they were
+// to where they were laid out. Advance the animations with Clay_UpdateTransitions before each layout.
+// The render commands of descendants move along with the element, but only the element's own rectangle, border, image,
+// custom and clip commands are resized. Positions are relative to the nearest ancestor with a transition, so elements
+// don't lag behind ancestors that are animated themselves. Floating elements are laid out as separate trees, and don't
+// move along with their parent.
+// Elements with Exit enabled keep their render commands from the last layout they were declared in, which are drawn
+// on top of the layout while they animate out.
+type Clay_TransitionElementConfig struct {
+	// The length of the animation in seconds. Transitions are disabled while it is 0.
+	Duration float32
+	// Eases the progress of the animation, or nil for a linear animation.
+	Easing Clay_EasingFunction
+	// The properties that animate when they change, while the others change immediately.
+	Properties Clay_TransitionProperty
+	// Animates the element when it is declared for the first time, or again after it disappeared.
+	Enter Clay_TransitionEnterExitConfig
+	// Animates the element once it isn't declared anymore.
+	Exit Clay_TransitionEnterExitConfig
+}
+
+// The animated properties of a transitioning element. The position is relative to the nearest ancestor with a
+// transition.
+type Clay__TransitionState struct {
+	BoundingBox     Clay_BoundingBox
+	BackgroundColor Clay_Color
+	CornerRadius    Clay_CornerRadius
+	Opacity         float32
+}
+
+// An element with a transition that was positioned during the current layout.
+type Clay__TransitionElement struct {
+	ElementId       uint32
+	Config          *Clay_TransitionElementConfig
+	BackgroundColor Clay_Color
+	CornerRadius    Clay_CornerRadius
+	// The index of the nearest ancestor with a transition, or -1.
+	Parent int32
+	// The render commands of the element run from RenderCommandsStart to RenderCommandsEnd, except for those of its
+	// descendants from ChildrenStart to ChildrenEnd. RenderCommandsEnd is -1 until the element has been positioned.
+	RenderCommandsStart int32
+	ChildrenStart       int32
+	ChildrenEnd         int32
+	RenderCommandsEnd   int32
+	// How far the render commands were moved from where they were laid out and the opacity they were faded to by the
+	// animation of this element, not including those of its ancestors.
+	Offset  Clay_Vector2
+	Opacity float32
+}
+
+// The animation of an element with a transition, kept across layouts.
+type Clay__TransitionDataInternal struct {
+	ElementId uint32
+	Config    Clay_TransitionElementConfig
+	// The id of the nearest ancestor with a transition, which the positions are relative to, or 0.
+	ParentElementId uint32
+	// The animation runs from From to Target, and after Elapsed seconds has reached Current.
+	From    Clay__TransitionState
+	Current Clay__TransitionState
+	Target  Clay__TransitionState
+	Elapsed float32
+	// The properties that are interpolated from From to Target, while the others are taken from Target.
+	Animating     Clay_TransitionProperty
+	Exiting       bool
+	OpenThisFrame bool
+	// The index of the element in TransitionElements, while OpenThisFrame is set.
+	TransitionIndex int32
+	// The render commands of the element and its descendants during the last layout it was declared in when Exit is
+	// enabled, along with the bounding box it had and where the commands of its descendants start and end.
+	RenderCommands []Clay_RenderCommand
+	BoundingBox    Clay_BoundingBox
+	ChildrenStart  int32
+	ChildrenEnd    int32
+	// The text generated by clay for RenderCommands, which outlives the layout it was generated for.
+	Text []byte
+	// The faded copies of the gradient stops of the render commands during the current layout, reused across layouts.
+	GradientStops []Clay_GradientStop
+	// The SCISSOR_START and TRANSFORM_START commands of the clip and transform ancestors that enclosed RenderCommands,
+	// outermost first.
+	Wrappers []Clay_RenderCommand
+}
+
+// Returns whether the background color of element animates, in which case its rectangle is drawn even while it is
+// transparent so that it can fade in and out.
+func Clay__TransitionsBackgroundColor(element *Clay_LayoutElement) bool {
+	config := Clay__FindElementConfigWithType(element, CLAY__ELEMENT_CONFIG_TYPE_TRANSITION).TransitionElementConfig
+	return config != nil && config.Properties&CLAY_TRANSITION_PROPERTY_BACKGROUND_COLOR != 0
+}
+
+// Starts recording the render commands of an element that is being positioned, before any of them have been added.
+// Returns the index of the element in TransitionElements, or -1 if it has no transition.
+func Clay__OpenTransitionElement(element *Clay_LayoutElement) int32 {
+	config := Clay__FindElementConfigWithType(element, CLAY__ELEMENT_CONFIG_TYPE_TRANSITION).TransitionElementConfig
+	if config == nil || config.Duration <= 0 {
+		return -1
+	}
+	currentContext := Clay_GetCurrentContext()
+	// Elements are positioned depth first, so the nearest ancestor with a transition is the nearest one that is still
+	// being positioned among the last element and its ancestors
+	parent := currentContext.TransitionElements.Length() - 1
+	for parent != -1 && Clay__Array_Get(&currentContext.TransitionElements, parent).RenderCommandsEnd != -1 {
+		parent = Clay__Array_Get(&currentContext.TransitionElements, parent).Parent
+	}
+	sharedConfig := Clay__FindElementConfigWithType(element, CLAY__ELEMENT_CONFIG_TYPE_SHARED).SharedElementConfig
+	if sharedConfig == nil {
+		sharedConfig = &Clay_SharedElementConfig_DEFAULT
+	}
+	Clay__Array_Add(&currentContext.TransitionElements, Clay__TransitionElement{
+		ElementId:           element.Id,
+		Config:              config,
+		BackgroundColor:     sharedConfig.BackgroundColor,
+		CornerRadius:        sharedConfig.CornerRadius,
+		Parent:              parent,
+		RenderCommandsStart: currentContext.RenderCommands.Length(),
+		RenderCommandsEnd:   -1,
+		Opacity:             1,
+	})
+	return currentContext.TransitionElements.Length() - 1
+}
+
+// Marks where the render commands of the descendants of a transitioning element start, once its own have been added.
+func Clay__BeginTransitionChildren(index int32) {
+	if index == -1 {
+		return
+	}
+	currentContext := Clay_GetCurrentContext()
+	Clay__Array_Get(&currentContext.TransitionElements, index).ChildrenStart = currentContext.RenderCommands.Length()
+}
+
+// Marks where the render commands of the descendants of a transitioning element end, once they have been positioned.
+func Clay__EndTransitionChildren(index int32) {
+	if index == -1 {
+		return
+	}
+	currentContext := Clay_GetCurrentContext()
+	Clay__Array_Get(&currentContext.TransitionElements, index).ChildrenEnd = currentContext.RenderCommands.Length()
+}
+
+// Completes the render commands of a transitioning element once the element and its descendants have been positioned.
+func Clay__CloseTransitionElement(index int32) {
+	if index == -1 {
+		return
+	}
+	currentContext := Clay_GetCurrentContext()
+	Clay__Array_Get(&currentContext.TransitionElements, index).RenderCommandsEnd = currentContext.RenderCommands.Length()
+}
+
+// Returns the animation of the element with hashMapItem, or nil if it has none.
+func Clay__GetTransitionData(hashMapItem *Clay_LayoutElementHashMapItem) *Clay__TransitionDataInternal {
+	currentContext := Clay_GetCurrentContext()
+	index := hashMapItem.TransitionDataIndex
+	if hashMapItem == &Clay_LayoutElementHashMapItem_DEFAULT || index < 0 || index >= currentContext.TransitionDatas.Length() {
+		return nil
+	}
+	data := Clay__Array_Get(&currentContext.TransitionDatas, index)
+	if data.ElementId != hashMapItem.ElementId.Id {
+		return nil
+	}
+	return data
+}
+
+// Discards the animation at index, moving the last animation into its place.
+func Clay__RemoveTransitionData(index int32) {
+	currentContext := Clay_GetCurrentContext()
+	Clay__Array_RemoveSwapback(&currentContext.TransitionDatas, index)
+	if index < currentContext.TransitionDatas.Length() {
+		moved := Clay__Array_Get(&currentContext.TransitionDatas, index)
+		if hashMapItem := Clay__GetHashMapItem(moved.ElementId); hashMapItem != &Clay_LayoutElementHashMapItem_DEFAULT {
+			hashMapItem.TransitionDataIndex = index
+		}
+	}
+}
+
+func Clay__Lerp(from float32, to float32, t float32) float32 {
+	return from + (to-from)*t
+}
+
+func Clay__LerpColor(from Clay_Color, to Clay_Color, t float32) Clay_Color {
+	return Clay_Color{
+		R: Clay__Lerp(from.R, to.R, t),
+		G: Clay__Lerp(from.G, to.G, t),
+		B: Clay__Lerp(from.B, to.B, t),
+		A: Clay__Lerp(from.A, to.A, t),
+	}
+}
+
+// Interpolates properties and the opacity between two states, taking the other properties from to.
+func Clay__LerpTransitionState(from Clay__TransitionState, to Clay__TransitionState, t float32, properties Clay_TransitionProperty) Clay__TransitionState {
+	state := to
+	if properties&CLAY_TRANSITION_PROPERTY_POSITION != 0 {
+		state.BoundingBox.X = Clay__Lerp(from.BoundingBox.X, to.BoundingBox.X, t)
+		state.BoundingBox.Y = Clay__Lerp(from.BoundingBox.Y, to.BoundingBox.Y, t)
+	}
+	if properties&CLAY_TRANSITION_PROPERTY_SIZE != 0 {
+		state.BoundingBox.Width = Clay__Lerp(from.BoundingBox.Width, to.BoundingBox.Width, t)
+		state.BoundingBox.Height = Clay__Lerp(from.BoundingBox.Height, to.BoundingBox.Height, t)
+	}
+	if properties&CLAY_TRANSITION_PROPERTY_BACKGROUND_COLOR != 0 {
+		state.BackgroundColor = Clay__LerpColor(from.BackgroundColor, to.BackgroundColor, t)
+	}
+	if properties&CLAY_TRANSITION_PROPERTY_CORNER_RADIUS != 0 {
+		state.CornerRadius = Clay_CornerRadius{
+			TopLeft:     Clay__Lerp(from.CornerRadius.TopLeft, to.CornerRadius.TopLeft, t),
+			TopRight:    Clay__Lerp(from.CornerRadius.TopRight, to.CornerRadius.TopRight, t),
+			BottomLeft:  Clay__Lerp(from.CornerRadius.BottomLeft, to.CornerRadius.BottomLeft, t),
+			BottomRight: Clay__Lerp(from.CornerRadius.BottomRight, to.CornerRadius.BottomRight, t),
+		}
+	}
+	state.Opacity = Clay__Lerp(from.Opacity, to.Opacity, t)
+	return state
+}
+
+// Returns whether any of properties differs between two states.
+func Clay__TransitionStateChanged(a Clay__TransitionState, b Clay__TransitionState, properties Clay_TransitionProperty) bool {
+	return properties&CLAY_TRANSITION_PROPERTY_POSITION != 0 && (a.BoundingBox.X != b.BoundingBox.X || a.BoundingBox.Y != b.BoundingBox.Y) ||
+		properties&CLAY_TRANSITION_PROPERTY_SIZE != 0 && (a.BoundingBox.Width != b.BoundingBox.Width || a.BoundingBox.Height != b.BoundingBox.Height) ||
+		properties&CLAY_TRANSITION_PROPERTY_BACKGROUND_COLOR != 0 && a.BackgroundColor != b.BackgroundColor ||
+		properties&CLAY_TRANSITION_PROPERTY_CORNER_RADIUS != 0 && a.CornerRadius != b.CornerRadius
+}
+
+// Returns state moved, shrunk and faded like config describes for an element that is appearing or disappearing.
+func Clay__TransitionEnterExitState(state Clay__TransitionState, config Clay_TransitionEnterExitConfig) Clay__TransitionState {
+	shrink := Clay_Dimensions{Width: state.BoundingBox.Width * config.Shrink.X, Height: state.BoundingBox.Height * config.Shrink.Y}
+	state.BoundingBox = Clay_BoundingBox{
+		X:      state.BoundingBox.X + config.Offset.X + shrink.Width/2,
+		Y:      state.BoundingBox.Y + config.Offset.Y + shrink.Height/2,
+		Width:  state.BoundingBox.Width - shrink.Width,
+		Height: state.BoundingBox.Height - shrink.Height,
+	}
+	state.Opacity = config.Opacity
+	return state
+}
+
+// Returns the eased progress of an animation, between 0 and 1.
+func Clay__TransitionProgress(data *Clay__TransitionDataInternal) float32 {
+	if data.Elapsed >= data.Config.Duration {
+		return 1
+	}
+	t := data.Elapsed / data.Config.Duration
+	if data.Config.Easing != nil {
+		return data.Config.Easing(t)
+	}
+	return t
+}
+
+// Advances the animation of an element that was declared during the current layout by deltaTime and towards target,
+// restarting it from where it is if target changed. Elements that appear start their enter animation. Returns nil if
+// there's no room to keep the animation.
+func Clay__UpdateTransitionData(hashMapItem *Clay_LayoutElementHashMapItem, config *Clay_TransitionElementConfig, parentElementId uint32, target Clay__TransitionState, deltaTime float32) *Clay__TransitionDataInternal {
+	currentContext := Clay_GetCurrentContext()
+	data := Clay__GetTransitionData(hashMapItem)
+	if data == nil {
+		if currentContext.TransitionDatas.Length() == currentContext.TransitionDatas.Capacity() {
+			return nil
+		}
+		hashMapItem.TransitionDataIndex = currentContext.TransitionDatas.Length()
+		data = Clay__Array_Add(&currentContext.TransitionDatas, Clay__TransitionDataInternal{
+			ElementId: hashMapItem.ElementId.Id,
+			From:      target,
+			Target:    target,
+			Elapsed:   config.Duration,
+		})
+		if config.Enter.Enabled {
+			data.From = Clay__TransitionEnterExitState(target, config.Enter)
+			data.Elapsed = 0
+			data.Animating = config.Properties | CLAY_TRANSITION_PROPERTY_POSITION | CLAY_TRANSITION_PROPERTY_SIZE
+		}
+	} else if data.Exiting {
+		// Declared again before it finished disappearing, so it returns from wherever it got to
+		data.Exiting = false
+		data.From = data.Current
+		data.Elapsed = 0
+		data.Animating = config.Properties | CLAY_TRANSITION_PROPERTY_POSITION | CLAY_TRANSITION_PROPERTY_SIZE
+	} else if data.ParentElementId != parentElementId {
+		// The position was relative to another element, so it can't be animated from
+		data.From = target
+		data.Elapsed = config.Duration
+	} else {
+		data.Elapsed = CLAY__MIN(data.Elapsed+deltaTime, config.Duration)
+		if Clay__TransitionStateChanged(data.Target, target, config.Properties) {
+			// Properties that were still animating keep doing so from where they got to
+			if data.Elapsed >= config.Duration {
+				data.Animating = 0
+			}
+			data.Animating |= config.Properties
+			data.From = data.Current
+			data.Elapsed = 0
+		}
+	}
+	data.Config = *config
+	data.ParentElementId = parentElementId
+	data.Target = target
+	data.OpenThisFrame = true
+	data.Current = Clay__LerpTransitionState(data.From, data.Target, Clay__TransitionProgress(data), data.Animating)
+	return data
+}
+
+// Moves, resizes, restyles and fades the render commands of a transitioning element and its descendants from where
+// the element was laid out at boundingBox to state, which holds an absolute position. Descendants, which have render
+// commands from childrenStart to childrenEnd, are only moved and faded. Faded gradient stops are stored in
+// gradientStops, which is reset first.
+func Clay__ApplyTransitionState(renderCommands []Clay_RenderCommand, childrenStart int32, childrenEnd int32, boundingBox Clay_BoundingBox, state Clay__TransitionState, gradientStops *[]Clay_GradientStop) {
+	*gradientStops = (*gradientStops)[:0]
+	offset := Clay_Vector2{X: state.BoundingBox.X - boundingBox.X, Y: state.BoundingBox.Y - boundingBox.Y}
+	for i := range renderCommands {
+		renderCommand := &renderCommands[i]
+		renderData := &renderCommand.RenderData
+		if renderCommand.CommandType == CLAY_RENDER_COMMAND_TYPE_SCISSOR_END || renderCommand.CommandType == CLAY_RENDER_COMMAND_TYPE_TRANSFORM_END {
+			continue
+		}
+		ownCommand := int32(i) < childrenStart || int32(i) >= childrenEnd
+		// Text lines and borders between children only cover part of the element, and keep their size
+		if ownCommand && renderCommand.BoundingBox == boundingBox {
+			renderCommand.BoundingBox = state.BoundingBox
+			switch renderCommand.CommandType {
+			case CLAY_RENDER_COMMAND_TYPE_RECTANGLE:
+				renderData.Rectangle.BackgroundColor = state.BackgroundColor
+				renderData.Rectangle.CornerRadius = state.CornerRadius
+			case CLAY_RENDER_COMMAND_TYPE_IMAGE:
+				renderData.Image.BackgroundColor = state.BackgroundColor
+				renderData.Image.CornerRadius = state.CornerRadius
+			case CLAY_RENDER_COMMAND_TYPE_CUSTOM:
+				renderData.Custom.BackgroundColor = state.BackgroundColor
+				renderData.Custom.CornerRadius = state.CornerRadius
+			case CLAY_RENDER_COMMAND_TYPE_BORDER:
+				renderData.Border.CornerRadius = state.CornerRadius
+			case CLAY_RENDER_COMMAND_TYPE_SHADOW:
+				renderData.Shadow.CornerRadius = state.CornerRadius
+			}
+		} else {
+			renderCommand.BoundingBox.X += offset.X
+			renderCommand.BoundingBox.Y += offset.Y
+		}
+		if state.Opacity < 1 {
+			Clay__FadeRenderCommand(renderCommand, state.Opacity, gradientStops)
+		}
+	}
+}
+
+// Multiplies the alpha of the colors of a render command by opacity, appending the faded stops of its gradient to
+// gradientStops.
+func Clay__FadeRenderCommand(renderCommand *Clay_RenderCommand, opacity float32, gradientStops *[]Clay_GradientStop) {
+	renderData := &renderCommand.RenderData
+	switch renderCommand.CommandType {
+	case CLAY_RENDER_COMMAND_TYPE_RECTANGLE:
+		renderData.Rectangle.BackgroundColor.A *= opacity
+		if Clay_GradientIsSet(&renderData.Rectangle.BackgroundGradient) {
+			renderData.Rectangle.BackgroundGradient = Clay__FadeGradient(renderData.Rectangle.BackgroundGradient, opacity, gradientStops)
+		}
+	case CLAY_RENDER_COMMAND_TYPE_TEXT, CLAY_RENDER_COMMAND_TYPE_TEXT_INPUT:
+		renderData.Text.TextColor.A *= opacity
+	case CLAY_RENDER_COMMAND_TYPE_IMAGE:
+		renderData.Image.BackgroundColor.A *= opacity
+	case CLAY_RENDER_COMMAND_TYPE_CUSTOM:
+		renderData.Custom.BackgroundColor.A *= opacity
+	case CLAY_RENDER_COMMAND_TYPE_BORDER:
+		renderData.Border.Color.A *= opacity
+	case CLAY_RENDER_COMMAND_TYPE_SHADOW:
+		renderData.Shadow.Color.A *= opacity
+	}
+}
+
+// Returns how far the render commands of a transitioning element were moved in total by its own animation and those of
+// its ancestors, and the opacity they were faded to.
+func Clay__TransitionElementTotalOffset(index int32) (Clay_Vector2, float32) {
+	currentContext := Clay_GetCurrentContext()
+	offset := Clay_Vector2{}
+	opacity := float32(1)
+	for index != -1 {
+		transitionElement := Clay__Array_Get(&currentContext.TransitionElements, index)
+		offset.X += transitionElement.Offset.X
+		offset.Y += transitionElement.Offset.Y
+		opacity *= transitionElement.Opacity
+		index = transitionElement.Parent
+	}
+	return offset, opacity
+}
+
+// Animates the render commands of the elements with a transition that were positioned during the current layout, and
+// draws those that are animating their exit. Called once the layout is complete, after it has been mirrored for RTL.
+func Clay__ApplyTransitions() {
+	currentContext := Clay_GetCurrentContext()
+	deltaTime := currentContext.TransitionDeltaTime
+	currentContext.TransitionDeltaTime = 0
+	currentContext.TransitionsActive = false
+	for i := int32(0); i < currentContext.TransitionDatas.Length(); i++ {
+		Clay__Array_Get(&currentContext.TransitionDatas, i).OpenThisFrame = false
+	}
+
+	// Descendants are animated before their ancestors, which then move the render commands of their descendants along
+	// with their own
+	for i := currentContext.TransitionElements.Length() - 1; i >= 0; i-- {
+		transitionElement := Clay__Array_Get(&currentContext.TransitionElements, i)
+		hashMapItem := Clay__GetHashMapItem(transitionElement.ElementId)
+		if hashMapItem == &Clay_LayoutElementHashMapItem_DEFAULT || transitionElement.RenderCommandsEnd == -1 {
+			continue
+		}
+		boundingBox := hashMapItem.BoundingBox
+		var parentElementId uint32
+		parentPosition := Clay_Vector2{}
+		if transitionElement.Parent != -1 {
+			parentElementId = Clay__Array_Get(&currentContext.TransitionElements, transitionElement.Parent).ElementId
+			parentBoundingBox := Clay__GetHashMapItem(parentElementId).BoundingBox
+			parentPosition = Clay_Vector2{X: parentBoundingBox.X, Y: parentBoundingBox.Y}
+		}
+		target := Clay__TransitionState{
+			BoundingBox:     Clay_BoundingBox{X: boundingBox.X - parentPosition.X, Y: boundingBox.Y - parentPosition.Y, Width: boundingBox.Width, Height: boundingBox.Height},
+			BackgroundColor: transitionElement.BackgroundColor,
+			CornerRadius:    transitionElement.CornerRadius,
+			Opacity:         1,
+		}
+		if currentContext.RTLEnabled {
+			target.CornerRadius = Clay__MirrorCornerRadius(target.CornerRadius)
+		}
+		data := Clay__UpdateTransitionData(hashMapItem, transitionElement.Config, parentElementId, target, deltaTime)
+		if data == nil {
+			continue
+		}
+		data.TransitionIndex = i
+		if data.Elapsed < data.Config.Duration {
+			currentContext.TransitionsActive = true
+		}
+
+		renderCommands := mem.MArray_GetSlice(&currentContext.RenderCommands, transitionElement.RenderCommandsStart, transitionElement.RenderCommandsEnd)
+		childrenStart := transitionElement.ChildrenStart - transitionElement.RenderCommandsStart
+		childrenEnd := transitionElement.ChildrenEnd - transitionElement.RenderCommandsStart
+		if data.Config.Exit.Enabled {
+			data.RenderCommands = append(data.RenderCommands[:0], renderCommands...)
+			Clay__KeepDynamicStrings(data.RenderCommands, &data.Text)
+			data.BoundingBox = boundingBox
+			data.ChildrenStart = childrenStart
+			data.ChildrenEnd = childrenEnd
+		}
+		state := data.Current
+		state.BoundingBox.X += parentPosition.X
+		state.BoundingBox.Y += parentPosition.Y
+		transitionElement.Offset = Clay_Vector2{X: state.BoundingBox.X - boundingBox.X, Y: state.BoundingBox.Y - boundingBox.Y}
+		transitionElement.Opacity = state.Opacity
+		Clay__ApplyTransitionState(renderCommands, childrenStart, childrenEnd, boundingBox, state, &data.GradientStops)
+	}
+
+	Clay__RecordExitTransitionWrappers()
+	Clay__ApplyExitTransitions(deltaTime)
+}
+
+// Keeps the clip and transform commands that enclose the render commands of each element with Exit enabled, so that
+// they can be drawn inside of them again while the element exits. Elements with a transition are recorded in the order
+// their render commands were added, so a single pass over the render commands finds the enclosing commands of all of
+// them.
+func Clay__RecordExitTransitionWrappers() {
+	currentContext := Clay_GetCurrentContext()
+	var wrapperStorage [32]int32
+	wrappers := wrapperStorage[:0]
+	next := int32(0)
+	for i := int32(0); i < currentContext.TransitionElements.Length(); i++ {
+		transitionElement := Clay__Array_Get(&currentContext.TransitionElements, i)
+		for ; next < transitionElement.RenderCommandsStart; next++ {
+			switch Clay__Array_Get(&currentContext.RenderCommands, next).CommandType {
+			case CLAY_RENDER_COMMAND_TYPE_SCISSOR_START, CLAY_RENDER_COMMAND_TYPE_TRANSFORM_START:
+				wrappers = append(wrappers, next)
+			case CLAY_RENDER_COMMAND_TYPE_SCISSOR_END, CLAY_RENDER_COMMAND_TYPE_TRANSFORM_END:
+				if len(wrappers) > 0 {
+					wrappers = wrappers[:len(wrappers)-1]
+				}
+			}
+		}
+		if transitionElement.RenderCommandsEnd == -1 || !transitionElement.Config.Exit.Enabled {
+			continue
+		}
+		data := Clay__GetTransitionData(Clay__GetHashMapItem(transitionElement.ElementId))
+		if data == nil {
+			continue
+		}
+		data.Wrappers = data.Wrappers[:0]
+		for _, index := range wrappers {
+			data.Wrappers = append(data.Wrappers, Clay__Array_GetValue(&currentContext.RenderCommands, index))
+		}
+	}
+}
+
+// Animates the elements with a transition that weren't declared during the current layout. Those with Exit enabled
+// are drawn with the render commands from the last layout they were declared in until their exit animation finishes,
+// while the animations of the others are discarded. Elements that disappear along with their ancestor are already part
+// of its render commands.
+//
+// Exiting elements are drawn last inside the innermost of their clip and transform ancestors that is still laid out,
+// with the clip and transform commands of the ancestors that are gone added around them again. When none of those
+// ancestors are left, they are drawn after the roots with the same or a lower z index.
+func Clay__ApplyExitTransitions(deltaTime float32) {
+	currentContext := Clay_GetCurrentContext()
+	for i := int32(0); i < currentContext.TransitionDatas.Length(); {
+		data := Clay__Array_Get(&currentContext.TransitionDatas, i)
+		if data.OpenThisFrame {
+			i++
+			continue
+		}
+		var parent *Clay__TransitionDataInternal
+		if data.ParentElementId != 0 {
+			parent = Clay__GetTransitionData(Clay__GetHashMapItem(data.ParentElementId))
+		}
+		removed := !data.Config.Exit.Enabled || data.ParentElementId != 0 && (parent == nil || !parent.OpenThisFrame)
+		if !removed && !data.Exiting {
+			data.Exiting = true
+			data.From = data.Current
+			data.Target = Clay__TransitionEnterExitState(data.Target, data.Config.Exit)
+			data.Elapsed = 0
+			data.Animating = data.Config.Properties | CLAY_TRANSITION_PROPERTY_POSITION | CLAY_TRANSITION_PROPERTY_SIZE
+		} else if !removed {
+			data.Elapsed += deltaTime
+			removed = data.Elapsed >= data.Config.Duration
+		}
+		if removed {
+			Clay__RemoveTransitionData(i)
+			continue
+		}
+		data.Current = Clay__LerpTransitionState(data.From, data.Target, Clay__TransitionProgress(data), data.Animating)
+		currentContext.TransitionsActive = true
+
+		state := data.Current
+		if parent != nil {
+			parentBoundingBox := Clay__GetHashMapItem(data.ParentElementId).BoundingBox
+			offset, opacity := Clay__TransitionElementTotalOffset(parent.TransitionIndex)
+			state.BoundingBox.X += parentBoundingBox.X + offset.X
+			state.BoundingBox.Y += parentBoundingBox.Y + offset.Y
+			state.Opacity *= opacity
+		}
+		insertIndex, wrappersDrawn := Clay__ExitTransitionInsertIndex(data)
+		blockStart := currentContext.RenderCommands.Length()
+		for _, wrapper := range data.Wrappers[wrappersDrawn:] {
+			Clay__AddRenderCommand(wrapper)
+		}
+		renderCommandsStart := currentContext.RenderCommands.Length()
+		Clay__AddRenderCommands(data.RenderCommands)
+		renderCommands := mem.MArray_GetSlice(&currentContext.RenderCommands, renderCommandsStart, currentContext.RenderCommands.Length())
+		Clay__ApplyTransitionState(renderCommands, data.ChildrenStart, data.ChildrenEnd, data.BoundingBox, state, &data.GradientStops)
+		for j := len(data.Wrappers) - 1; j >= wrappersDrawn; j-- {
+			wrapper := &data.Wrappers[j]
+			endCommandType := CLAY_RENDER_COMMAND_TYPE_TRANSFORM_END
+			if wrapper.CommandType == CLAY_RENDER_COMMAND_TYPE_SCISSOR_START {
+				endCommandType = CLAY_RENDER_COMMAND_TYPE_SCISSOR_END
+			}
+			Clay__AddRenderCommand(Clay_RenderCommand{Id: wrapper.Id, ZIndex: wrapper.ZIndex, CommandType: endCommandType})
+		}
+		Clay__MoveRenderCommands(blockStart, insertIndex)
+		i++
+	}
+}
+
+// Returns where the render commands of an exiting element are inserted, along with how many of the clip and transform
+// commands that enclosed them are still drawn around that index by the current layout.
+func Clay__ExitTransitionInsertIndex(data *Clay__TransitionDataInternal) (int32, int) {
+	renderCommands := Clay_GetCurrentContext().RenderCommands.InternalArray()
+	for wrapperIndex := len(data.Wrappers) - 1; wrapperIndex >= 0; wrapperIndex-- {
+		wrapper := &data.Wrappers[wrapperIndex]
+		for i := range renderCommands {
+			if renderCommands[i].Id == wrapper.Id && renderCommands[i].CommandType == wrapper.CommandType && renderCommands[i].ZIndex == wrapper.ZIndex {
+				return Clay__MatchingEndCommandIndex(renderCommands, int32(i)), wrapperIndex + 1
+			}
+		}
+	}
+	var zIndex int16
+	if len(data.RenderCommands) > 0 {
+		zIndex = data.RenderCommands[0].ZIndex
+	}
+	// END commands don't have the z index of their root, so those after the last command of a root are skipped too
+	for i := int32(len(renderCommands)) - 1; i >= 0; i-- {
+		if !Clay__IsEndCommand(renderCommands[i].CommandType) && renderCommands[i].ZIndex <= zIndex {
+			insertIndex := i + 1
+			for insertIndex < int32(len(renderCommands)) && Clay__IsEndCommand(renderCommands[insertIndex].CommandType) {
+				insertIndex++
+			}
+			return insertIndex, 0
+		}
+	}
+	return 0, 0
+}
+
+// Returns the index of the SCISSOR_END or TRANSFORM_END command that closes the START command at index.
+func Clay__MatchingEndCommandIndex(renderCommands []Clay_RenderCommand, index int32) int32 {
+	depth := 0
+	for i := index + 1; i < int32(len(renderCommands)); i++ {
+		switch renderCommands[i].CommandType {
+		case CLAY_RENDER_COMMAND_TYPE_SCISSOR_START, CLAY_RENDER_COMMAND_TYPE_TRANSFORM_START:
+			depth++
+		case CLAY_RENDER_COMMAND_TYPE_SCISSOR_END, CLAY_RENDER_COMMAND_TYPE_TRANSFORM_END:
+			if depth == 0 {
+				return i
+			}
+			depth--
+		}
+	}
+	return int32(len(renderCommands))
+}
+
+func Clay__IsEndCommand(commandType Clay_RenderCommandType) bool {
+	return commandType == CLAY_RENDER_COMMAND_TYPE_SCISSOR_END || commandType == CLAY_RENDER_COMMAND_TYPE_TRANSFORM_END
+}
+
+// Moves the render commands from start to the end of the render commands to index, ahead of those that were there.
+func Clay__MoveRenderCommands(start int32, index int32) {
+	renderCommands := Clay_GetCurrentContext().RenderCommands.InternalArray()
+	if index >= start {
+		return
+	}
+	slices.Reverse(renderCommands[index:start])
+	slices.Reverse(renderCommands[start:])
+	slices.Reverse(renderCommands[index:])
+}
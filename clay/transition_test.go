@@ -0,0 +1,352 @@
+package clay
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+var (
+	testTransitionRed  = Clay_Color{R: 255, A: 255}
+	testTransitionBlue = Clay_Color{B: 255, A: 255}
+)
+
+// Returns the render command of the element with the id and type, failing the test if there is none.
+func testFindRenderCommand(tb testing.TB, renderCommands []Clay_RenderCommand, id string, commandType Clay_RenderCommandType) Clay_RenderCommand {
+	tb.Helper()
+	for _, renderCommand := range renderCommands {
+		if renderCommand.Id == CLAY_ID(id).Id && renderCommand.CommandType == commandType {
+			return renderCommand
+		}
+	}
+	tb.Fatalf("no render command of type %d for %q", commandType, id)
+	return Clay_RenderCommand{}
+}
+
+// Returns the only text render command, failing the test if there is none.
+func testFindTextRenderCommand(tb testing.TB, renderCommands []Clay_RenderCommand) Clay_RenderCommand {
+	tb.Helper()
+	for _, renderCommand := range renderCommands {
+		if renderCommand.CommandType == CLAY_RENDER_COMMAND_TYPE_TEXT {
+			return renderCommand
+		}
+	}
+	tb.Fatalf("no text render command")
+	return Clay_RenderCommand{}
+}
+
+// Runs a layout after advancing transitions by deltaTime.
+func testRunTransitionLayout(deltaTime float32, layout func()) []Clay_RenderCommand {
+	Clay_UpdateTransitions(deltaTime)
+	return runTestLayout(layout)
+}
+
+func testTransitionPanel(width float32, color Clay_Color, transition Clay_TransitionElementConfig) func() {
+	return func() {
+		CLAY_ROOT(CLAY_ID("panel"), Clay_ElementDeclaration{
+			Layout:          Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(width), Height: CLAY_SIZING_FIXED(50)}},
+			BackgroundColor: color,
+			Transition:      transition,
+		})
+	}
+}
+
+func TestTransitionInterpolatesChangedProperties(t *testing.T) {
+	newTestContext(t, 800, 600)
+	transition := Clay_TransitionElementConfig{Duration: 1, Properties: CLAY_TRANSITION_PROPERTY_ALL}
+	testRunTransitionLayout(0, testTransitionPanel(100, testTransitionRed, transition))
+	if Clay_TransitionsActive() {
+		t.Errorf("expected elements that appear without an enter animation not to transition")
+	}
+
+	// The layout the properties change in starts from where they were
+	renderCommands := testRunTransitionLayout(0.5, testTransitionPanel(200, testTransitionBlue, transition))
+	rectangle := testFindRenderCommand(t, renderCommands, "panel", CLAY_RENDER_COMMAND_TYPE_RECTANGLE)
+	if rectangle.BoundingBox.Width != 100 || rectangle.RenderData.Rectangle.BackgroundColor != testTransitionRed {
+		t.Errorf("expected the transition to start from a width of 100 in red, got %v in %v", rectangle.BoundingBox.Width, rectangle.RenderData.Rectangle.BackgroundColor)
+	}
+
+	renderCommands = testRunTransitionLayout(0.5, testTransitionPanel(200, testTransitionBlue, transition))
+	rectangle = testFindRenderCommand(t, renderCommands, "panel", CLAY_RENDER_COMMAND_TYPE_RECTANGLE)
+	if expected := (Clay_Color{R: 127.5, B: 127.5, A: 255}); rectangle.BoundingBox.Width != 150 || rectangle.RenderData.Rectangle.BackgroundColor != expected {
+		t.Errorf("expected a width of 150 in %v halfway through the transition, got %v in %v", expected, rectangle.BoundingBox.Width, rectangle.RenderData.Rectangle.BackgroundColor)
+	}
+	if !Clay_TransitionsActive() {
+		t.Errorf("expected the transition to be active halfway through")
+	}
+
+	renderCommands = testRunTransitionLayout(0.5, testTransitionPanel(200, testTransitionBlue, transition))
+	rectangle = testFindRenderCommand(t, renderCommands, "panel", CLAY_RENDER_COMMAND_TYPE_RECTANGLE)
+	if rectangle.BoundingBox.Width != 200 || rectangle.RenderData.Rectangle.BackgroundColor != testTransitionBlue {
+		t.Errorf("expected the transition to end at a width of 200 in blue, got %v in %v", rectangle.BoundingBox.Width, rectangle.RenderData.Rectangle.BackgroundColor)
+	}
+	if Clay_TransitionsActive() {
+		t.Errorf("expected the transition to have finished")
+	}
+}
+
+func TestTransitionChangesOtherPropertiesImmediately(t *testing.T) {
+	newTestContext(t, 800, 600)
+	transition := Clay_TransitionElementConfig{Duration: 1, Properties: CLAY_TRANSITION_PROPERTY_BACKGROUND_COLOR, Easing: Clay_EaseInQuad}
+	testRunTransitionLayout(0, testTransitionPanel(100, testTransitionRed, transition))
+	testRunTransitionLayout(0, testTransitionPanel(200, Clay_Color{}, transition))
+
+	// Transparent backgrounds are still drawn while they fade out
+	renderCommands := testRunTransitionLayout(0.5, testTransitionPanel(200, Clay_Color{}, transition))
+	rectangle := testFindRenderCommand(t, renderCommands, "panel", CLAY_RENDER_COMMAND_TYPE_RECTANGLE)
+	if expected := (Clay_Color{R: 191.25, A: 191.25}); rectangle.BoundingBox.Width != 200 || rectangle.RenderData.Rectangle.BackgroundColor != expected {
+		t.Errorf("expected a width of 200 in %v a quarter of the way through the eased transition, got %v in %v", expected, rectangle.BoundingBox.Width, rectangle.RenderData.Rectangle.BackgroundColor)
+	}
+}
+
+// Declares a panel with a transition after a spacer that moves it to the right, with a child declared by child.
+func testTransitionMovingPanel(offset float32, child ClayContainer) func() {
+	return func() {
+		CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{},
+			CLAY(CLAY_ID("spacer"), Clay_ElementDeclaration{
+				Layout: Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(offset), Height: CLAY_SIZING_FIXED(10)}},
+			}),
+			CLAY(CLAY_ID("panel"), Clay_ElementDeclaration{
+				Layout:          Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(100), Height: CLAY_SIZING_FIXED(100)}, Padding: CLAY_PADDING_ALL(10)},
+				BackgroundColor: testTransitionRed,
+				Transition:      Clay_TransitionElementConfig{Duration: 1, Properties: CLAY_TRANSITION_PROPERTY_POSITION},
+			}, child),
+		)
+	}
+}
+
+func TestTransitionMovesDescendantsAlongWithElement(t *testing.T) {
+	newTestContext(t, 800, 600)
+	layout := func(offset float32) func() {
+		return testTransitionMovingPanel(offset, CLAY(CLAY_ID("child"), Clay_ElementDeclaration{
+			Layout:          Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(50), Height: CLAY_SIZING_FIXED(50)}},
+			BackgroundColor: testTransitionBlue,
+		}))
+	}
+	testRunTransitionLayout(0, layout(0))
+	testRunTransitionLayout(0, layout(100))
+	renderCommands := testRunTransitionLayout(0.5, layout(100))
+
+	if x := testFindRenderCommand(t, renderCommands, "panel", CLAY_RENDER_COMMAND_TYPE_RECTANGLE).BoundingBox.X; x != 50 {
+		t.Errorf("expected the panel to be halfway at 50, got %v", x)
+	}
+	if x := testFindRenderCommand(t, renderCommands, "child", CLAY_RENDER_COMMAND_TYPE_RECTANGLE).BoundingBox.X; x != 60 {
+		t.Errorf("expected the child to stay inside the padding of the panel at 60, got %v", x)
+	}
+	// Pointer hit testing uses where the elements were laid out
+	if x := testBoundingBox(t, "child").X; x != 110 {
+		t.Errorf("expected the bounding box of the child to be laid out at 110, got %v", x)
+	}
+}
+
+func TestTransitionPositionsAreRelativeToAncestorWithTransition(t *testing.T) {
+	newTestContext(t, 800, 600)
+	layout := func(offset float32) func() {
+		return testTransitionMovingPanel(offset, CLAY(CLAY_ID("child"), Clay_ElementDeclaration{
+			Layout:          Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(50), Height: CLAY_SIZING_FIXED(50)}},
+			BackgroundColor: testTransitionBlue,
+			Transition:      Clay_TransitionElementConfig{Duration: 2, Properties: CLAY_TRANSITION_PROPERTY_POSITION},
+		}))
+	}
+	testRunTransitionLayout(0, layout(0))
+	testRunTransitionLayout(0, layout(100))
+	renderCommands := testRunTransitionLayout(0.5, layout(100))
+
+	// The child didn't move within the panel, so its own slower transition doesn't hold it back
+	if x := testFindRenderCommand(t, renderCommands, "child", CLAY_RENDER_COMMAND_TYPE_RECTANGLE).BoundingBox.X; x != 60 {
+		t.Errorf("expected the child to move along with the panel to 60, got %v", x)
+	}
+}
+
+// Declares a list with a first item, and a second item with a transition if second is set.
+func testTransitionList(second bool, transition Clay_TransitionElementConfig) func() {
+	return func() {
+		items := []ClayContainer{CLAY(CLAY_ID("first"), Clay_ElementDeclaration{
+			Layout:          Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(100), Height: CLAY_SIZING_FIXED(20)}},
+			BackgroundColor: testTransitionRed,
+		})}
+		if second {
+			items = append(items, CLAY(CLAY_ID("second"), Clay_ElementDeclaration{
+				Layout:          Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(100), Height: CLAY_SIZING_FIXED(20)}},
+				BackgroundColor: testTransitionBlue,
+				Transition:      transition,
+			}, CLAY_TEXT("label", TextWithColor(Clay_Color{A: 255}))))
+		}
+		CLAY_ROOT(CLAY_ID("list"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{LayoutDirection: CLAY_TOP_TO_BOTTOM}}, items...)
+	}
+}
+
+func TestTransitionEnter(t *testing.T) {
+	newTestContext(t, 800, 600)
+	transition := Clay_TransitionElementConfig{Duration: 1, Enter: Clay_TransitionEnterExitConfig{Enabled: true, Offset: Clay_Vector2{Y: 20}}}
+	testRunTransitionLayout(0, testTransitionList(false, transition))
+
+	renderCommands := testRunTransitionLayout(0, testTransitionList(true, transition))
+	rectangle := testFindRenderCommand(t, renderCommands, "second", CLAY_RENDER_COMMAND_TYPE_RECTANGLE)
+	if rectangle.BoundingBox.Y != 40 || rectangle.RenderData.Rectangle.BackgroundColor.A != 0 {
+		t.Errorf("expected the item to appear transparent at 40, got alpha %v at %v", rectangle.RenderData.Rectangle.BackgroundColor.A, rectangle.BoundingBox.Y)
+	}
+
+	renderCommands = testRunTransitionLayout(0.5, testTransitionList(true, transition))
+	rectangle = testFindRenderCommand(t, renderCommands, "second", CLAY_RENDER_COMMAND_TYPE_RECTANGLE)
+	if rectangle.BoundingBox.Y != 30 || rectangle.RenderData.Rectangle.BackgroundColor.A != 127.5 {
+		t.Errorf("expected the item halfway at 30 with alpha 127.5, got alpha %v at %v", rectangle.RenderData.Rectangle.BackgroundColor.A, rectangle.BoundingBox.Y)
+	}
+	text := testFindTextRenderCommand(t, renderCommands)
+	if text.BoundingBox.Y != 30 || text.RenderData.Text.TextColor.A != 127.5 {
+		t.Errorf("expected the label to move and fade with the item, got alpha %v at %v", text.RenderData.Text.TextColor.A, text.BoundingBox.Y)
+	}
+}
+
+func TestTransitionExit(t *testing.T) {
+	newTestContext(t, 800, 600)
+	transition := Clay_TransitionElementConfig{Duration: 1, Exit: Clay_TransitionEnterExitConfig{Enabled: true, Shrink: Clay_Vector2{X: 1}}}
+	testRunTransitionLayout(0, testTransitionList(true, transition))
+
+	// The item keeps being drawn where it was after it is gone from the layout
+	renderCommands := testRunTransitionLayout(0, testTransitionList(false, transition))
+	rectangle := testFindRenderCommand(t, renderCommands, "second", CLAY_RENDER_COMMAND_TYPE_RECTANGLE)
+	if expected := (Clay_BoundingBox{Y: 20, Width: 100, Height: 20}); rectangle.BoundingBox != expected || rectangle.RenderData.Rectangle.BackgroundColor != testTransitionBlue {
+		t.Errorf("expected the item to start its exit at %v in blue, got %v in %v", expected, rectangle.BoundingBox, rectangle.RenderData.Rectangle.BackgroundColor)
+	}
+
+	renderCommands = testRunTransitionLayout(0.5, testTransitionList(false, transition))
+	rectangle = testFindRenderCommand(t, renderCommands, "second", CLAY_RENDER_COMMAND_TYPE_RECTANGLE)
+	if expected := (Clay_BoundingBox{X: 25, Y: 20, Width: 50, Height: 20}); rectangle.BoundingBox != expected || rectangle.RenderData.Rectangle.BackgroundColor.A != 127.5 {
+		t.Errorf("expected the item to shrink to %v with alpha 127.5 halfway, got %v with alpha %v", expected, rectangle.BoundingBox, rectangle.RenderData.Rectangle.BackgroundColor.A)
+	}
+	if text := testFindTextRenderCommand(t, renderCommands); text.RenderData.Text.TextColor.A != 127.5 {
+		t.Errorf("expected the label to fade out with the item, got alpha %v", text.RenderData.Text.TextColor.A)
+	}
+	if !Clay_TransitionsActive() {
+		t.Errorf("expected the exit to be active halfway through")
+	}
+
+	renderCommands = testRunTransitionLayout(0.5, testTransitionList(false, transition))
+	for _, renderCommand := range renderCommands {
+		if renderCommand.Id == CLAY_ID("second").Id {
+			t.Errorf("expected the item not to be drawn once its exit finished, got %+v", renderCommand)
+		}
+	}
+	if Clay_TransitionsActive() || Clay_GetCurrentContext().TransitionDatas.Length() != 0 {
+		t.Errorf("expected the animation of the item to be discarded once its exit finished")
+	}
+}
+
+func TestTransitionWithoutExitIsDiscarded(t *testing.T) {
+	newTestContext(t, 800, 600)
+	transition := Clay_TransitionElementConfig{Duration: 1, Properties: CLAY_TRANSITION_PROPERTY_ALL}
+	testRunTransitionLayout(0, testTransitionList(true, transition))
+	renderCommands := testRunTransitionLayout(0, testTransitionList(false, transition))
+
+	if len(renderCommands) != 1 || Clay_GetCurrentContext().TransitionDatas.Length() != 0 {
+		t.Errorf("expected only the first item to be drawn and no animations to be kept, got %d render commands and %d animations", len(renderCommands), Clay_GetCurrentContext().TransitionDatas.Length())
+	}
+}
+
+// Declares a clipped list with an overlay above it, and a second item with a transition in the list if second is set.
+func testTransitionClippedList(second bool, transition Clay_TransitionElementConfig) func() {
+	return func() {
+		items := []ClayContainer{CLAY(CLAY_ID("first"), Clay_ElementDeclaration{
+			Layout:          Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(100), Height: CLAY_SIZING_FIXED(20)}},
+			BackgroundColor: testTransitionRed,
+		})}
+		if second {
+			items = append(items, CLAY(CLAY_ID("second"), Clay_ElementDeclaration{
+				Layout:          Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(100), Height: CLAY_SIZING_FIXED(20)}},
+				BackgroundColor: testTransitionBlue,
+				Transition:      transition,
+			}))
+		}
+		CLAY_ROOT(CLAY_ID("page"), Clay_ElementDeclaration{},
+			CLAY(CLAY_ID("overlay"), Clay_ElementDeclaration{
+				Layout:          Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(50), Height: CLAY_SIZING_FIXED(50)}},
+				BackgroundColor: testTransitionRed,
+				Floating:        Clay_FloatingElementConfig{AttachTo: CLAY_ATTACH_TO_PARENT, ZIndex: 1},
+			}),
+			CLAY(CLAY_ID("scroll"), Clay_ElementDeclaration{
+				Layout: Clay_LayoutConfig{LayoutDirection: CLAY_TOP_TO_BOTTOM, Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(100), Height: CLAY_SIZING_FIXED(30)}},
+				Clip:   Clay_ClipElementConfig{Vertical: true},
+			}, items...),
+		)
+	}
+}
+
+func TestTransitionExitIsDrawnInsideClipOfAncestor(t *testing.T) {
+	newTestContext(t, 800, 600)
+	transition := Clay_TransitionElementConfig{Duration: 1, Exit: Clay_TransitionEnterExitConfig{Enabled: true}}
+	testRunTransitionLayout(0, testTransitionClippedList(true, transition))
+	renderCommands := testRunTransitionLayout(0.5, testTransitionClippedList(false, transition))
+
+	indexOf := func(id string, commandType Clay_RenderCommandType) int {
+		t.Helper()
+		for i, renderCommand := range renderCommands {
+			if renderCommand.Id == CLAY_ID(id).Id && renderCommand.CommandType == commandType {
+				return i
+			}
+		}
+		t.Fatalf("no render command of type %d for %q", commandType, id)
+		return -1
+	}
+	scissorStart := indexOf("scroll", CLAY_RENDER_COMMAND_TYPE_SCISSOR_START)
+	second := indexOf("second", CLAY_RENDER_COMMAND_TYPE_RECTANGLE)
+	overlay := indexOf("overlay", CLAY_RENDER_COMMAND_TYPE_RECTANGLE)
+	scissorEnd := scissorStart + 1
+	for scissorEnd < len(renderCommands) && renderCommands[scissorEnd].CommandType != CLAY_RENDER_COMMAND_TYPE_SCISSOR_END {
+		scissorEnd++
+	}
+	if second <= scissorStart || second >= scissorEnd {
+		t.Errorf("expected the exiting item at %d to be drawn inside the clip of the list from %d to %d", second, scissorStart, scissorEnd)
+	}
+	if overlay <= second {
+		t.Errorf("expected the exiting item at %d to be drawn below the overlay at %d", second, overlay)
+	}
+}
+
+func TestTransitionExitRestoresClipOfRemovedAncestor(t *testing.T) {
+	newTestContext(t, 800, 600)
+	transition := Clay_TransitionElementConfig{Duration: 1, Exit: Clay_TransitionEnterExitConfig{Enabled: true}}
+	testRunTransitionLayout(0, testTransitionClippedList(true, transition))
+	renderCommands := testRunTransitionLayout(0.5, func() {
+		CLAY_ROOT(CLAY_ID("page"), Clay_ElementDeclaration{})
+	})
+
+	expectedTypes := []string{"SCISSOR_START", "RECTANGLE", "SCISSOR_END"}
+	if types := testRenderCommandTypes(renderCommands); !slices.Equal(types, expectedTypes) {
+		t.Fatalf("expected the exiting item to be clipped again with render commands %v, got %v", expectedTypes, types)
+	}
+	if scissor := renderCommands[0]; scissor.Id != CLAY_ID("scroll").Id || scissor.BoundingBox != (Clay_BoundingBox{Width: 100, Height: 30}) {
+		t.Errorf("expected the clip of the removed list, got %v for id %d", scissor.BoundingBox, scissor.Id)
+	}
+}
+
+func TestTransitionExitKeepsGeneratedText(t *testing.T) {
+	newTestContext(t, 800, 600)
+	transition := Clay_TransitionElementConfig{Duration: 1, Exit: Clay_TransitionEnterExitConfig{Enabled: true}}
+	declare := func(exiting bool, other string) func() {
+		return func() {
+			items := []ClayContainer{CLAY(CLAY_ID("other"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(100)}}},
+				CLAY_TEXT(other, TextWithOverflow(CLAY_TEXT_OVERFLOW_ELLIPSIS)),
+			)}
+			if exiting {
+				items = append(items, CLAY(CLAY_ID("exiting"), Clay_ElementDeclaration{
+					Layout:     Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(100)}},
+					Transition: transition,
+				}, CLAY_TEXT("a_very_long_file_name.txt", TextWithOverflow(CLAY_TEXT_OVERFLOW_ELLIPSIS))))
+			}
+			CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{LayoutDirection: CLAY_TOP_TO_BOTTOM}}, items...)
+		}
+	}
+	testRunTransitionLayout(0, declare(true, "short"))
+
+	// The text generated by the layouts after the item is gone takes the memory its text was truncated into
+	for frame := 0; frame < 3; frame++ {
+		lines := []string{}
+		for _, command := range testRunTransitionLayout(0.1, declare(false, fmt.Sprintf("other_long_name_%d.txt", frame))) {
+			if command.CommandType == CLAY_RENDER_COMMAND_TYPE_TEXT {
+				lines = append(lines, string(command.RenderData.Text.StringContents.Chars[:command.RenderData.Text.StringContents.Length]))
+			}
+		}
+		testExpectTextLines(t, []string{"other_l…", "a_very_…"}, lines)
+	}
+}
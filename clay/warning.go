@@ -5,6 +5,7 @@ type Clay_BooleanWarnings struct {
 	MaxRenderCommandsExceeded     bool
 	MaxTextMeasureCacheExceeded   bool
 	TextMeasurementFunctionNotSet bool
+	MaxDynamicStringDataExceeded  bool
 }
 
 type Clay__Warning struct {
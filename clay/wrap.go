@@ -0,0 +1,352 @@
+package clay
+
+// A line of children inside an element laid out with CLAY_WRAP_WRAP.
+type Clay__WrapLine struct {
+	StartChild int32   // Index of the first child on this line.
+	EndChild   int32   // Index one past the last child on this line.
+	MainSize   float32 // Size of the line along the layout axis, including child gaps and margins.
+	CrossSize  float32 // Size of the largest child on this line along the non layout axis, including its margins.
+}
+
+func Clay__LayoutDirectionIsHorizontal(layoutDirection Clay_LayoutDirection) bool {
+	return layoutDirection == CLAY_LEFT_TO_RIGHT || layoutDirection == CLAY_RIGHT_TO_LEFT
+}
+
+// Returns true for the layout directions that place the first child at the end of the layout axis.
+func Clay__LayoutDirectionIsReversed(layoutDirection Clay_LayoutDirection) bool {
+	return layoutDirection == CLAY_RIGHT_TO_LEFT || layoutDirection == CLAY_BOTTOM_TO_TOP
+}
+
+func Clay__ElementWrapsChildren(element *Clay_LayoutElement) bool {
+	return element.LayoutConfig != nil && element.LayoutConfig.Wrap == CLAY_WRAP_WRAP && !Clay__ElementHasConfig(element, CLAY__ELEMENT_CONFIG_TYPE_TEXT)
+}
+
+func Clay__SizeAlongAxis(dimensions Clay_Dimensions, xAxis bool) float32 {
+	if xAxis {
+		return dimensions.Width
+	}
+	return dimensions.Height
+}
+
+func Clay__SetSizeAlongAxis(dimensions *Clay_Dimensions, xAxis bool, size float32) {
+	if xAxis {
+		dimensions.Width = size
+	} else {
+		dimensions.Height = size
+	}
+}
+
+func Clay__SizingAlongAxis(sizing Clay_Sizing, xAxis bool) Clay_SizingAxis {
+	if xAxis {
+		return sizing.Width
+	}
+	return sizing.Height
+}
+
+// Returns the padding at the start (left / top) and end (right / bottom) of the provided axis.
+func Clay__PaddingAlongAxis(padding Clay_Padding, xAxis bool) (float32, float32) {
+	if xAxis {
+		return float32(padding.Left), float32(padding.Right)
+	}
+	return float32(padding.Top), float32(padding.Bottom)
+}
+
+// Returns the margin at the start (left / top) and end (right / bottom) of the provided axis.
+func Clay__MarginAlongAxis(margin Clay_Margin, xAxis bool) (float32, float32) {
+	if xAxis {
+		return float32(margin.Left), float32(margin.Right)
+	}
+	return float32(margin.Top), float32(margin.Bottom)
+}
+
+// Returns the total margin of the element along the provided axis.
+func Clay__MarginSizeAlongAxis(element *Clay_LayoutElement, xAxis bool) float32 {
+	marginStart, marginEnd := Clay__MarginAlongAxis(element.LayoutConfig.Margin, xAxis)
+	return marginStart + marginEnd
+}
+
+// Returns the size of the element along the provided axis including its margins.
+func Clay__OuterSizeAlongAxis(element *Clay_LayoutElement, xAxis bool) float32 {
+	return Clay__SizeAlongAxis(element.Dimensions, xAxis) + Clay__MarginSizeAlongAxis(element, xAxis)
+}
+
+// Returns the space available to children of the element along the provided axis.
+func Clay__InnerSizeAlongAxis(element *Clay_LayoutElement, xAxis bool) float32 {
+	paddingStart, paddingEnd := Clay__PaddingAlongAxis(element.LayoutConfig.Padding, xAxis)
+	return Clay__SizeAlongAxis(element.Dimensions, xAxis) - paddingStart - paddingEnd
+}
+
+// Returns 0 for start alignment, 0.5 for center alignment and 1 for end alignment along the provided axis.
+func Clay__ChildAlignmentFactor(childAlignment Clay_ChildAlignment, xAxis bool) float32 {
+	if xAxis {
+		switch childAlignment.X {
+		case CLAY_ALIGN_X_CENTER:
+			return 0.5
+		case CLAY_ALIGN_X_RIGHT:
+			return 1
+		}
+		return 0
+	}
+	switch childAlignment.Y {
+	case CLAY_ALIGN_Y_CENTER:
+		return 0.5
+	case CLAY_ALIGN_Y_BOTTOM:
+		return 1
+	}
+	return 0
+}
+
+// Collects the children of a wrapping element into the line starting at startChild. A child is moved onto the next line
+// when adding it would overflow the element along its layout axis, unless it would be the first child on the line.
+func Clay__NextWrapLine(element *Clay_LayoutElement, startChild int32) Clay__WrapLine {
+	currentContext := Clay_GetCurrentContext()
+	layoutConfig := element.LayoutConfig
+	mainAxisX := Clay__LayoutDirectionIsHorizontal(layoutConfig.LayoutDirection)
+	availableSize := Clay__InnerSizeAlongAxis(element, mainAxisX)
+	line := Clay__WrapLine{StartChild: startChild, EndChild: startChild}
+	for line.EndChild < int32(element.ChildrenOrTextContent.Children.Length) {
+		child := Clay__Array_Get(&currentContext.LayoutElements, element.ChildrenOrTextContent.Children.Elements[line.EndChild])
+		childSize := Clay__OuterSizeAlongAxis(child, mainAxisX)
+		if line.EndChild > line.StartChild {
+			if line.MainSize+float32(layoutConfig.ChildGap)+childSize > availableSize+CLAY__EPSILON {
+				break
+			}
+			line.MainSize += float32(layoutConfig.ChildGap)
+		}
+		line.MainSize += childSize
+		line.CrossSize = CLAY__MAX(line.CrossSize, Clay__OuterSizeAlongAxis(child, !mainAxisX))
+		line.EndChild++
+	}
+	return line
+}
+
+// Returns the size of the wrapped children of the element along the layout axis (the longest line) and along the
+// non layout axis (all lines plus line gaps), excluding padding, as well as the number of lines.
+func Clay__WrappedContentSize(element *Clay_LayoutElement) (float32, float32, int32) {
+	var mainSize, crossSize float32
+	lineCount := int32(0)
+	for startChild := int32(0); startChild < int32(element.ChildrenOrTextContent.Children.Length); {
+		line := Clay__NextWrapLine(element, startChild)
+		if lineCount > 0 {
+			crossSize += float32(element.LayoutConfig.LineGap)
+		}
+		mainSize = CLAY__MAX(mainSize, line.MainSize)
+		crossSize += line.CrossSize
+		lineCount++
+		startChild = line.EndChild
+	}
+	return mainSize, crossSize, lineCount
+}
+
+// Returns the size of a wrapped line along the non layout axis. A single line fills the element, like an unwrapped container.
+func Clay__WrapLineCrossSize(element *Clay_LayoutElement, line Clay__WrapLine, lineCount int32) float32 {
+	if lineCount == 1 {
+		innerSize := Clay__InnerSizeAlongAxis(element, !Clay__LayoutDirectionIsHorizontal(element.LayoutConfig.LayoutDirection))
+		return CLAY__MAX(innerSize, line.CrossSize)
+	}
+	return line.CrossSize
+}
+
+func Clay__ChildIsResizableAlongAxis(childElement *Clay_LayoutElement, xAxis bool) bool {
+	childSizing := Clay__SizingAlongAxis(childElement.LayoutConfig.Sizing, xAxis)
+	if childSizing.Type == CLAY__SIZING_TYPE_PERCENT || childSizing.Type == CLAY__SIZING_TYPE_FIXED {
+		return false
+	}
+	return !Clay__ElementHasConfig(childElement, CLAY__ELEMENT_CONFIG_TYPE_TEXT) || Clay__FindElementConfigWithType(childElement, CLAY__ELEMENT_CONFIG_TYPE_TEXT).TextElementConfig.WrapMode == CLAY_TEXT_WRAP_WORDS
+}
+
+// Sizes the children of a wrapping element along its layout axis. Children larger than the element are compressed
+// towards their minimum size, then the children are broken into lines and the GROW children of each line share the
+// space remaining in that line.
+func Clay__SizeWrappedChildrenAlongAxis(parent *Clay_LayoutElement, resizableContainerBuffer *Clay__Array[int32], xAxis bool) {
+	currentContext := Clay_GetCurrentContext()
+	availableSize := Clay__InnerSizeAlongAxis(parent, xAxis)
+	for childOffset := int32(0); childOffset < int32(parent.ChildrenOrTextContent.Children.Length); childOffset++ {
+		childElement := Clay__Array_Get(&currentContext.LayoutElements, parent.ChildrenOrTextContent.Children.Elements[childOffset])
+		childSizing := Clay__SizingAlongAxis(childElement.LayoutConfig.Sizing, xAxis)
+		childSize := Clay__SizeAlongAxis(childElement.Dimensions, xAxis)
+		childAvailableSize := CLAY__MAX(0, availableSize-Clay__MarginSizeAlongAxis(childElement, xAxis))
+		if childSizing.Type == CLAY__SIZING_TYPE_PERCENT {
+			Clay__SetSizeAlongAxis(&childElement.Dimensions, xAxis, childAvailableSize*childSizing.Size.Percent)
+			Clay__UpdateAspectRatioBox(childElement)
+		} else if childSize > childAvailableSize && Clay__ChildIsResizableAlongAxis(childElement, xAxis) {
+			Clay__SetSizeAlongAxis(&childElement.Dimensions, xAxis, CLAY__MAX(Clay__SizeAlongAxis(childElement.MinDimensions, xAxis), childAvailableSize))
+		}
+	}
+
+	for startChild := int32(0); startChild < int32(parent.ChildrenOrTextContent.Children.Length); {
+		line := Clay__NextWrapLine(parent, startChild)
+		Clay__Array_Reset(resizableContainerBuffer)
+		for childOffset := line.StartChild; childOffset < line.EndChild; childOffset++ {
+			childElementIndex := parent.ChildrenOrTextContent.Children.Elements[childOffset]
+			childElement := Clay__Array_Get(&currentContext.LayoutElements, childElementIndex)
+			if Clay__SizingAlongAxis(childElement.LayoutConfig.Sizing, xAxis).Type == CLAY__SIZING_TYPE_GROW {
+				Clay__Array_Add(resizableContainerBuffer, childElementIndex)
+			}
+		}
+		if sizeToDistribute := availableSize - line.MainSize; sizeToDistribute > 0 {
+			Clay__GrowChildrenAlongAxis(resizableContainerBuffer, sizeToDistribute, xAxis)
+		}
+		startChild = line.EndChild
+	}
+}
+
+// Sizes the children of a wrapping element along its non layout axis, where GROW children fill the line they are on.
+// For CLAY_TOP_TO_BOTTOM the lines aren't known yet when the x axis is sized, so GROW children keep their FIT width.
+func Clay__SizeWrappedChildrenAcrossAxis(parent *Clay_LayoutElement, xAxis bool) {
+	currentContext := Clay_GetCurrentContext()
+	linesKnown := !xAxis
+	_, _, lineCount := Clay__WrappedContentSize(parent)
+	for startChild := int32(0); startChild < int32(parent.ChildrenOrTextContent.Children.Length); {
+		line := Clay__NextWrapLine(parent, startChild)
+		lineCrossSize := Clay__WrapLineCrossSize(parent, line, lineCount)
+		for childOffset := line.StartChild; childOffset < line.EndChild; childOffset++ {
+			childElement := Clay__Array_Get(&currentContext.LayoutElements, parent.ChildrenOrTextContent.Children.Elements[childOffset])
+			if !Clay__ChildIsResizableAlongAxis(childElement, xAxis) {
+				continue
+			}
+			childSizing := Clay__SizingAlongAxis(childElement.LayoutConfig.Sizing, xAxis)
+			childSize := Clay__SizeAlongAxis(childElement.Dimensions, xAxis)
+			if linesKnown && childSizing.Type == CLAY__SIZING_TYPE_GROW {
+				childSize = CLAY__MIN(lineCrossSize-Clay__MarginSizeAlongAxis(childElement, xAxis), childSizing.Size.MinMax.Max)
+			}
+			Clay__SetSizeAlongAxis(&childElement.Dimensions, xAxis, CLAY__MAX(Clay__SizeAlongAxis(childElement.MinDimensions, xAxis), childSize))
+		}
+		startChild = line.EndChild
+	}
+}
+
+// Returns the offset of the first wrapped line from the element's edge along the non layout axis, aligning the block
+// of lines according to ChildAlignment, as well as the number of lines.
+func Clay__WrapLinesStartOffset(element *Clay_LayoutElement) (float32, int32) {
+	crossAxisX := !Clay__LayoutDirectionIsHorizontal(element.LayoutConfig.LayoutDirection)
+	_, crossSize, lineCount := Clay__WrappedContentSize(element)
+	paddingStart, _ := Clay__PaddingAlongAxis(element.LayoutConfig.Padding, crossAxisX)
+	extraSpace := CLAY__MAX(0, Clay__InnerSizeAlongAxis(element, crossAxisX)-crossSize)
+	return paddingStart + extraSpace*Clay__ChildAlignmentFactor(element.LayoutConfig.ChildAlignment, crossAxisX), lineCount
+}
+
+// Returns the offset of the first child of a wrapped line from the element's edge along the layout axis, and the space
+// added between the children of the line when ChildAlignment distributes space.
+func Clay__WrapLineStartOffset(element *Clay_LayoutElement, line Clay__WrapLine) (float32, float32) {
+	mainAxisX := Clay__LayoutDirectionIsHorizontal(element.LayoutConfig.LayoutDirection)
+	paddingStart, _ := Clay__PaddingAlongAxis(element.LayoutConfig.Padding, mainAxisX)
+	extraSpace := CLAY__MAX(0, Clay__InnerSizeAlongAxis(element, mainAxisX)-line.MainSize)
+	if distribution := Clay__SpaceDistributionAlongAxis(element.LayoutConfig.ChildAlignment, mainAxisX); distribution != CLAY__SPACE_DISTRIBUTION_NONE {
+		leadingSpace, distributedGap := Clay__DistributeSpace(distribution, extraSpace, line.EndChild-line.StartChild)
+		return paddingStart + leadingSpace, distributedGap
+	}
+	return paddingStart + extraSpace*Clay__ChildAlignmentFactor(element.LayoutConfig.ChildAlignment, mainAxisX), 0
+}
+
+// Widens FIT elements that wrap their children from top to bottom to fit their columns, along with the FIT ancestors
+// that contain them. The columns are estimated when the element is closed, so this only changes widths when the heights
+// of the children or the element changed while sizing the y axis, e.g. by wrapping text or growing. The siblings of
+// widened elements are not sized again. Ancestors that wrap their children or lay them out as a grid keep their width.
+// Returns true when the width of the element changed.
+func Clay__FitWrappedColumnWidths(element *Clay_LayoutElement) bool {
+	currentContext := Clay_GetCurrentContext()
+	if Clay__ElementHasConfig(element, CLAY__ELEMENT_CONFIG_TYPE_TEXT) || element.ChildrenOrTextContent.Children.Length == 0 {
+		return false
+	}
+	childrenChanged := false
+	for childIndex := int32(0); childIndex < int32(element.ChildrenOrTextContent.Children.Length); childIndex++ {
+		childElement := Clay__Array_Get(&currentContext.LayoutElements, element.ChildrenOrTextContent.Children.Elements[childIndex])
+		if Clay__FitWrappedColumnWidths(childElement) {
+			childrenChanged = true
+		}
+	}
+
+	layoutConfig := element.LayoutConfig
+	if layoutConfig.Sizing.Width.Type != CLAY__SIZING_TYPE_FIT {
+		return false
+	}
+	wrapsChildren := Clay__ElementWrapsChildren(element)
+	horizontal := Clay__LayoutDirectionIsHorizontal(layoutConfig.LayoutDirection)
+	contentWidth := float32(layoutConfig.Padding.Left + layoutConfig.Padding.Right)
+	if wrapsChildren && !horizontal {
+		_, columnsWidth, _ := Clay__WrappedContentSize(element)
+		contentWidth += columnsWidth
+	} else if !childrenChanged || wrapsChildren || Clay__GetGridLayoutData(element) != nil {
+		return false
+	} else if horizontal {
+		for childIndex := int32(0); childIndex < int32(element.ChildrenOrTextContent.Children.Length); childIndex++ {
+			childElement := Clay__Array_Get(&currentContext.LayoutElements, element.ChildrenOrTextContent.Children.Elements[childIndex])
+			contentWidth += Clay__OuterSizeAlongAxis(childElement, true)
+		}
+		contentWidth += float32((int32(element.ChildrenOrTextContent.Children.Length) - 1) * int32(layoutConfig.ChildGap))
+	} else {
+		var largestChildWidth float32
+		for childIndex := int32(0); childIndex < int32(element.ChildrenOrTextContent.Children.Length); childIndex++ {
+			childElement := Clay__Array_Get(&currentContext.LayoutElements, element.ChildrenOrTextContent.Children.Elements[childIndex])
+			largestChildWidth = CLAY__MAX(largestChildWidth, Clay__OuterSizeAlongAxis(childElement, true))
+		}
+		contentWidth += largestChildWidth
+	}
+	width := CLAY__MIN(CLAY__MAX(CLAY__MAX(contentWidth, element.Dimensions.Width), layoutConfig.Sizing.Width.Size.MinMax.Min), layoutConfig.Sizing.Width.Size.MinMax.Max)
+	if width == element.Dimensions.Width {
+		return false
+	}
+	element.Dimensions.Width = width
+	return true
+}
+
+// Returns the bounding box at mainOffset along the layout axis and crossOffset along the non layout axis of a wrapping
+// element, relative to its bounding box.
+func Clay__WrapAxisBoundingBox(boundingBox Clay_BoundingBox, mainAxisX bool, mainOffset, crossOffset, mainSize, crossSize float32) Clay_BoundingBox {
+	if mainAxisX {
+		return Clay_BoundingBox{X: boundingBox.X + mainOffset, Y: boundingBox.Y + crossOffset, Width: mainSize, Height: crossSize}
+	}
+	return Clay_BoundingBox{X: boundingBox.X + crossOffset, Y: boundingBox.Y + mainOffset, Width: crossSize, Height: mainSize}
+}
+
+// Adds the borders between the children of a wrapping element. Borders between the children of a line span that line,
+// and borders between lines span the whole element, each sitting in the middle of its gap.
+func Clay__AddWrappedBetweenChildrenBorders(element *Clay_LayoutElement, boundingBox Clay_BoundingBox, borderConfig *Clay_BorderElementConfig, userData interface{}) {
+	currentContext := Clay_GetCurrentContext()
+	layoutConfig := element.LayoutConfig
+	mainAxisX := Clay__LayoutDirectionIsHorizontal(layoutConfig.LayoutDirection)
+	reversed := Clay__LayoutDirectionIsReversed(layoutConfig.LayoutDirection)
+	borderWidth := float32(borderConfig.Width.BetweenChildren)
+	childCount := int32(element.ChildrenOrTextContent.Children.Length)
+	lineOffset, lineCount := Clay__WrapLinesStartOffset(element)
+	addBorder := func(id uint32, borderBox Clay_BoundingBox) {
+		Clay__AddRenderCommand(Clay_RenderCommand{
+			BoundingBox: borderBox,
+			RenderData: Clay_RenderData{
+				Rectangle: Clay_RectangleRenderData{
+					BackgroundColor: borderConfig.Color,
+				},
+			},
+			UserData:    userData,
+			Id:          Clay__HashNumber(element.Id, id).Id,
+			CommandType: CLAY_RENDER_COMMAND_TYPE_RECTANGLE,
+		})
+	}
+	for startChild := int32(0); startChild < childCount; {
+		line := Clay__NextWrapLine(element, startChild)
+		lineCrossSize := Clay__WrapLineCrossSize(element, line, lineCount)
+		if startChild > 0 {
+			// The first child of each line has no border before it, so its id is free for the border above the line
+			borderOffset := lineOffset - float32(layoutConfig.LineGap) + float32(layoutConfig.LineGap/2)
+			addBorder(uint32(childCount+1+startChild), Clay__WrapAxisBoundingBox(boundingBox, mainAxisX, 0, borderOffset, Clay__SizeAlongAxis(element.Dimensions, mainAxisX), borderWidth))
+		}
+		// Walk the children of the line in the order they are placed along the layout axis
+		childOffset, distributedGap := Clay__WrapLineStartOffset(element, line)
+		childGap := float32(layoutConfig.ChildGap) + distributedGap
+		halfGap := float32(layoutConfig.ChildGap/2) + distributedGap/2
+		for placementIndex := line.StartChild; placementIndex < line.EndChild; placementIndex++ {
+			i := placementIndex
+			if reversed {
+				i = line.StartChild + line.EndChild - 1 - placementIndex
+			}
+			if placementIndex > line.StartChild {
+				addBorder(uint32(childCount+1+i), Clay__WrapAxisBoundingBox(boundingBox, mainAxisX, childOffset-childGap+halfGap, lineOffset, borderWidth, lineCrossSize))
+			}
+			childElement := Clay__Array_Get(&currentContext.LayoutElements, element.ChildrenOrTextContent.Children.Elements[i])
+			childOffset += Clay__OuterSizeAlongAxis(childElement, mainAxisX) + childGap
+		}
+		lineOffset += lineCrossSize + float32(layoutConfig.LineGap)
+		startChild = line.EndChild
+	}
+}
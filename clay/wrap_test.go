@@ -0,0 +1,241 @@
+package clay
+
+import (
+	"fmt"
+	"testing"
+)
+
+func testFixedBox(id string, width, height float32) ClayContainer {
+	return CLAY(CLAY_ID(id), Clay_ElementDeclaration{
+		Layout: Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(width), Height: CLAY_SIZING_FIXED(height)}},
+	})
+}
+
+func testExpectBoundingBox(t *testing.T, id string, expected Clay_BoundingBox) {
+	t.Helper()
+	if got := testBoundingBox(t, id); got != expected {
+		t.Errorf("%s: expected bounding box %v, got %v", id, expected, got)
+	}
+}
+
+func TestWrapBreaksChildrenIntoLines(t *testing.T) {
+	newTestContext(t, 800, 600)
+	runTestLayout(func() {
+		tags := []ClayContainer{}
+		for i := 0; i < 7; i++ {
+			tags = append(tags, testFixedBox(fmt.Sprintf("tag%d", i), 50, 20))
+		}
+		CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{},
+			CLAY(CLAY_ID("cloud"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{
+				Sizing:   Clay_Sizing{Width: CLAY_SIZING_FIXED(200)},
+				Padding:  CLAY_PADDING_ALL(10),
+				ChildGap: 10,
+				LineGap:  5,
+				Wrap:     CLAY_WRAP_WRAP,
+			}}, tags...),
+		)
+	})
+
+	for i := 0; i < 7; i++ {
+		testExpectBoundingBox(t, fmt.Sprintf("tag%d", i), Clay_BoundingBox{X: 10 + float32(i%3)*60, Y: 10 + float32(i/3)*25, Width: 50, Height: 20})
+	}
+	// Three lines of 20 with two line gaps of 5 and padding, instead of the tallest child
+	testExpectBoundingBox(t, "cloud", Clay_BoundingBox{X: 0, Y: 0, Width: 200, Height: 90})
+}
+
+func TestWrapGrowsChildrenPerLine(t *testing.T) {
+	newTestContext(t, 800, 600)
+	grow := func(id string) ClayContainer {
+		return CLAY(CLAY_ID(id), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{
+			Sizing: Clay_Sizing{Width: CLAY_SIZING_GROW(Clay_SizingMinMax{}), Height: CLAY_SIZING_GROW(Clay_SizingMinMax{})},
+		}})
+	}
+	runTestLayout(func() {
+		CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{},
+			CLAY(CLAY_ID("container"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{
+				Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(200)},
+				Wrap:   CLAY_WRAP_WRAP,
+			}},
+				testFixedBox("a", 120, 40),
+				grow("growA"),
+				testFixedBox("b", 150, 20),
+				grow("growB"),
+			),
+		)
+	})
+
+	testExpectBoundingBox(t, "a", Clay_BoundingBox{X: 0, Y: 0, Width: 120, Height: 40})
+	// GROW children fill the remaining space of their own line, along both axes
+	testExpectBoundingBox(t, "growA", Clay_BoundingBox{X: 120, Y: 0, Width: 80, Height: 40})
+	testExpectBoundingBox(t, "b", Clay_BoundingBox{X: 0, Y: 40, Width: 150, Height: 20})
+	testExpectBoundingBox(t, "growB", Clay_BoundingBox{X: 150, Y: 40, Width: 50, Height: 20})
+	testExpectBoundingBox(t, "container", Clay_BoundingBox{X: 0, Y: 0, Width: 200, Height: 60})
+}
+
+func TestWrapAlignsLines(t *testing.T) {
+	newTestContext(t, 800, 600)
+	runTestLayout(func() {
+		boxes := []ClayContainer{}
+		for i := 0; i < 5; i++ {
+			boxes = append(boxes, testFixedBox(fmt.Sprintf("box%d", i), 50, 20))
+		}
+		CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{},
+			CLAY(CLAY_ID("container"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{
+				Sizing:         Clay_Sizing{Width: CLAY_SIZING_FIXED(200), Height: CLAY_SIZING_FIXED(100)},
+				ChildAlignment: Clay_ChildAlignment{X: CLAY_ALIGN_X_CENTER, Y: CLAY_ALIGN_Y_CENTER},
+				Wrap:           CLAY_WRAP_WRAP,
+			}}, boxes...),
+		)
+	})
+
+	for i := 0; i < 4; i++ {
+		testExpectBoundingBox(t, fmt.Sprintf("box%d", i), Clay_BoundingBox{X: float32(i) * 50, Y: 30, Width: 50, Height: 20})
+	}
+	// The last line is centered by its own width, and the block of lines by its total height
+	testExpectBoundingBox(t, "box4", Clay_BoundingBox{X: 75, Y: 50, Width: 50, Height: 20})
+}
+
+func TestWrapTopToBottom(t *testing.T) {
+	newTestContext(t, 800, 600)
+	runTestLayout(func() {
+		CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{},
+			CLAY(CLAY_ID("container"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{
+				Sizing:          Clay_Sizing{Width: CLAY_SIZING_FIXED(300), Height: CLAY_SIZING_FIXED(100)},
+				LayoutDirection: CLAY_TOP_TO_BOTTOM,
+				ChildGap:        10,
+				LineGap:         20,
+				Wrap:            CLAY_WRAP_WRAP,
+			}},
+				testFixedBox("a", 50, 40),
+				testFixedBox("b", 30, 40),
+				testFixedBox("c", 50, 40),
+			),
+		)
+	})
+
+	testExpectBoundingBox(t, "a", Clay_BoundingBox{X: 0, Y: 0, Width: 50, Height: 40})
+	testExpectBoundingBox(t, "b", Clay_BoundingBox{X: 0, Y: 50, Width: 30, Height: 40})
+	testExpectBoundingBox(t, "c", Clay_BoundingBox{X: 70, Y: 0, Width: 50, Height: 40})
+}
+
+func TestWrapPropagatesHeightToFitParents(t *testing.T) {
+	newTestContext(t, 800, 600)
+	runTestLayout(func() {
+		CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{},
+			CLAY(CLAY_ID("column"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{LayoutDirection: CLAY_TOP_TO_BOTTOM}},
+				CLAY(CLAY_ID("container"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{
+					Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(100)},
+					Wrap:   CLAY_WRAP_WRAP,
+				}},
+					testFixedBox("a", 60, 20),
+					testFixedBox("b", 60, 20),
+					CLAY_TEXT("hello world"),
+				),
+				testFixedBox("below", 10, 10),
+			),
+		)
+	})
+
+	// The text is narrowed to the container width and wraps onto two lines of its own
+	testExpectBoundingBox(t, "container", Clay_BoundingBox{X: 0, Y: 0, Width: 100, Height: 80})
+	testExpectBoundingBox(t, "below", Clay_BoundingBox{X: 0, Y: 80, Width: 10, Height: 10})
+	testExpectBoundingBox(t, "column", Clay_BoundingBox{X: 0, Y: 0, Width: 100, Height: 90})
+}
+
+func TestWrapBorderBetweenChildren(t *testing.T) {
+	newTestContext(t, 800, 600)
+	borderColor := Clay_Color{R: 255, A: 255}
+	commands := runTestLayout(func() {
+		boxes := []ClayContainer{}
+		for i := 0; i < 5; i++ {
+			boxes = append(boxes, testFixedBox(fmt.Sprintf("box%d", i), 50, 20))
+		}
+		CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{},
+			CLAY(CLAY_ID("cloud"), Clay_ElementDeclaration{
+				Layout: Clay_LayoutConfig{
+					Sizing:   Clay_Sizing{Width: CLAY_SIZING_FIXED(200)},
+					Padding:  CLAY_PADDING_ALL(10),
+					ChildGap: 10,
+					LineGap:  6,
+					Wrap:     CLAY_WRAP_WRAP,
+				},
+				Border: Clay_BorderElementConfig{Color: borderColor, Width: Clay_BorderWidth{BetweenChildren: 2}},
+			}, boxes...),
+		)
+	})
+
+	// Borders between the children of a line span the line, and the border between lines spans the element
+	expected := []Clay_BoundingBox{
+		{X: 65, Y: 10, Width: 2, Height: 20},
+		{X: 125, Y: 10, Width: 2, Height: 20},
+		{X: 0, Y: 33, Width: 200, Height: 2},
+		{X: 65, Y: 36, Width: 2, Height: 20},
+	}
+	found := []Clay_BoundingBox{}
+	for _, command := range commands {
+		if command.CommandType == CLAY_RENDER_COMMAND_TYPE_RECTANGLE && command.RenderData.Rectangle.BackgroundColor == borderColor {
+			found = append(found, command.BoundingBox)
+		}
+	}
+	if len(found) != len(expected) {
+		t.Fatalf("expected %d borders between children, got %v", len(expected), found)
+	}
+	for i := range expected {
+		if found[i] != expected[i] {
+			t.Errorf("border %d: expected %v, got %v", i, expected[i], found[i])
+		}
+	}
+}
+
+func TestWrapTopToBottomPropagatesWidthToFitParents(t *testing.T) {
+	newTestContext(t, 800, 600)
+	runTestLayout(func() {
+		CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{},
+			CLAY(CLAY_ID("row"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{Padding: CLAY_PADDING_ALL(5)}},
+				CLAY(CLAY_ID("container"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{
+					Sizing:          Clay_Sizing{Height: CLAY_SIZING_FIXED(100)},
+					LayoutDirection: CLAY_TOP_TO_BOTTOM,
+					ChildGap:        10,
+					LineGap:         20,
+					Wrap:            CLAY_WRAP_WRAP,
+				}},
+					testFixedBox("a", 50, 40),
+					testFixedBox("b", 30, 40),
+					testFixedBox("c", 50, 40),
+				),
+				testFixedBox("after", 10, 10),
+			),
+		)
+	})
+
+	// Two columns of 50 with a line gap of 20, instead of the widest child
+	testExpectBoundingBox(t, "container", Clay_BoundingBox{X: 5, Y: 5, Width: 120, Height: 100})
+	testExpectBoundingBox(t, "c", Clay_BoundingBox{X: 75, Y: 5, Width: 50, Height: 40})
+	testExpectBoundingBox(t, "after", Clay_BoundingBox{X: 125, Y: 5, Width: 10, Height: 10})
+	testExpectBoundingBox(t, "row", Clay_BoundingBox{X: 0, Y: 0, Width: 140, Height: 110})
+}
+
+func TestWrapTopToBottomLeavesRoomForGrowSiblings(t *testing.T) {
+	newTestContext(t, 800, 600)
+	runTestLayout(func() {
+		boxes := []ClayContainer{}
+		for i := 0; i < 5; i++ {
+			boxes = append(boxes, testFixedBox(fmt.Sprintf("box%d", i), 40, 30))
+		}
+		CLAY_ROOT(CLAY_ID("root"), Clay_ElementDeclaration{},
+			CLAY(CLAY_ID("row"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_FIXED(300)}}},
+				CLAY(CLAY_ID("container"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{
+					Sizing:          Clay_Sizing{Height: CLAY_SIZING_FIXED(70)},
+					LayoutDirection: CLAY_TOP_TO_BOTTOM,
+					Wrap:            CLAY_WRAP_WRAP,
+				}}, boxes...),
+				CLAY(CLAY_ID("sibling"), Clay_ElementDeclaration{Layout: Clay_LayoutConfig{Sizing: Clay_Sizing{Width: CLAY_SIZING_GROW(Clay_SizingMinMax{}), Height: CLAY_SIZING_FIXED(10)}}}),
+			),
+		)
+	})
+
+	// Three columns of two, two and one boxes, and the sibling grows into the rest of the row
+	testExpectBoundingBox(t, "container", Clay_BoundingBox{Width: 120, Height: 70})
+	testExpectBoundingBox(t, "box4", Clay_BoundingBox{X: 80, Width: 40, Height: 30})
+	testExpectBoundingBox(t, "sibling", Clay_BoundingBox{X: 120, Width: 180, Height: 10})
+}
@@ -69,7 +69,11 @@ func run(w *app.Window) error {
 					}, nil),
 				)
 				// clay.Clay_SetDebugModeEnabled(true)
-				clay.Clay_SetMeasureTextFunction(measurer.MeasureText, gtx)
+				if metricsMeasurer, ok := measurer.(claygio.TextMetricsMeasurer); ok {
+					clay.Clay_SetMeasureTextMetricsFunction(metricsMeasurer.MeasureTextMetrics, gtx)
+				} else {
+					clay.Clay_SetMeasureTextFunction(measurer.MeasureText, gtx)
+				}
 				clayReady = true
 			}
 
@@ -0,0 +1,133 @@
+package claygio
+
+import (
+	"image"
+	"math"
+
+	"gioui.org/f32"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"github.com/zodimo/clay-go/clay"
+)
+
+// The number of rings of solid color that radial gradients are approximated with.
+const radialGradientRings = 32
+
+// RenderGradientRectangle fills a rectangle, clipped to its corner radius, with its background gradient. Gio only
+// paints linear gradients between two colors, so linear gradients are painted as one band per pair of stops, and radial
+// gradients are approximated with rings of solid color.
+func RenderGradientRectangle(ops *op.Ops, bounds clay.Clay_BoundingBox, cmd clay.Clay_RenderCommand) error {
+	rectangleData := cmd.RenderData.Rectangle
+	gradient := rectangleData.BackgroundGradient
+	if IsCornerRadiusZero(rectangleData.CornerRadius) {
+		clipOp := clip.Rect(image.Rect(int(bounds.X), int(bounds.Y), int(bounds.X+bounds.Width), int(bounds.Y+bounds.Height))).Push(ops)
+		defer clipOp.Pop()
+	} else {
+		shapedRect := ShapedRect{
+			MinPoint: f32.Pt(bounds.X, bounds.Y),
+			MaxPoint: f32.Pt(bounds.X+bounds.Width, bounds.Y+bounds.Height),
+			Shapes:   MapClayCornerRadius(rectangleData.CornerRadius),
+		}
+		clipOp := clip.Outline{Path: shapedRect.Path(layout.Context{Ops: ops})}.Op().Push(ops)
+		defer clipOp.Pop()
+	}
+
+	start := f32.Pt(bounds.X+bounds.Width*gradient.Start.X, bounds.Y+bounds.Height*gradient.Start.Y)
+	end := f32.Pt(bounds.X+bounds.Width*gradient.End.X, bounds.Y+bounds.Height*gradient.End.Y)
+	direction := end.Sub(start)
+	length := float32(math.Hypot(float64(direction.X), float64(direction.Y)))
+	if length == 0 {
+		paintSolid(ops, gradientColorAt(gradient.Stops, 1))
+		return nil
+	}
+
+	switch gradient.Type {
+	case clay.CLAY_GRADIENT_TYPE_LINEAR:
+		// Bands reach across the whole rectangle, wherever the gradient starts
+		extent := bounds.Width + bounds.Height + float32(math.Abs(float64(start.X-bounds.X))+math.Abs(float64(start.Y-bounds.Y))) + length
+		normal := f32.Pt(-direction.Y, direction.X).Mul(extent / length)
+		reach := extent/length + 1
+		stops := gradient.Stops
+		first, last := stops[0], stops[len(stops)-1]
+		paintGradientBand(ops, start, direction, normal, first.Position-reach, first.Position, func() { paintSolid(ops, first.Color) })
+		for i := 0; i < len(stops)-1; i++ {
+			from, to := stops[i], stops[i+1]
+			paintGradientBand(ops, start, direction, normal, from.Position, to.Position, func() {
+				paint.LinearGradientOp{
+					Stop1:  start.Add(direction.Mul(from.Position)),
+					Color1: ClayToGioColor(from.Color),
+					Stop2:  start.Add(direction.Mul(to.Position)),
+					Color2: ClayToGioColor(to.Color),
+				}.Add(ops)
+				paint.PaintOp{}.Add(ops)
+			})
+		}
+		paintGradientBand(ops, start, direction, normal, last.Position, last.Position+reach, func() { paintSolid(ops, last.Color) })
+	case clay.CLAY_GRADIENT_TYPE_RADIAL:
+		// Paint from the outside in, so each ring covers the rings inside of it until they are painted
+		paintSolid(ops, gradientColorAt(gradient.Stops, 1))
+		for ring := radialGradientRings - 1; ring >= 0; ring-- {
+			radius := length * float32(ring+1) / radialGradientRings
+			var path clip.Path
+			path.Begin(ops)
+			path.MoveTo(start.Add(f32.Pt(radius, 0)))
+			path.ArcTo(start, start, 2*math.Pi)
+			path.Close()
+			clipOp := clip.Outline{Path: path.End()}.Op().Push(ops)
+			paintSolid(ops, gradientColorAt(gradient.Stops, (float32(ring)+0.5)/radialGradientRings))
+			clipOp.Pop()
+		}
+	}
+	return nil
+}
+
+// Paints the band of a linear gradient between the positions from and to along direction, if it isn't empty.
+func paintGradientBand(ops *op.Ops, start, direction, normal f32.Point, from, to float32, paintBand func()) {
+	if to <= from {
+		return
+	}
+	fromPoint := start.Add(direction.Mul(from))
+	toPoint := start.Add(direction.Mul(to))
+	var path clip.Path
+	path.Begin(ops)
+	path.MoveTo(fromPoint.Add(normal))
+	path.LineTo(toPoint.Add(normal))
+	path.LineTo(toPoint.Sub(normal))
+	path.LineTo(fromPoint.Sub(normal))
+	path.Close()
+	clipOp := clip.Outline{Path: path.End()}.Op().Push(ops)
+	paintBand()
+	clipOp.Pop()
+}
+
+func paintSolid(ops *op.Ops, color clay.Clay_Color) {
+	paint.ColorOp{Color: ClayToGioColor(color)}.Add(ops)
+	paint.PaintOp{}.Add(ops)
+}
+
+// Returns the color of a gradient at position, blending between the stops around it.
+func gradientColorAt(stops []clay.Clay_GradientStop, position float32) clay.Clay_Color {
+	if position <= stops[0].Position {
+		return stops[0].Color
+	}
+	for i := 1; i < len(stops); i++ {
+		if position > stops[i].Position {
+			continue
+		}
+		from, to := stops[i-1], stops[i]
+		span := to.Position - from.Position
+		if span <= 0 {
+			return to.Color
+		}
+		t := (position - from.Position) / span
+		return clay.Clay_Color{
+			R: from.Color.R + (to.Color.R-from.Color.R)*t,
+			G: from.Color.G + (to.Color.G-from.Color.G)*t,
+			B: from.Color.B + (to.Color.B-from.Color.B)*t,
+			A: from.Color.A + (to.Color.A-from.Color.A)*t,
+		}
+	}
+	return stops[len(stops)-1].Color
+}
@@ -15,6 +15,14 @@ type TextMeasurer interface {
 	MeasureText(text clay.Clay_StringSlice, config *clay.Clay_TextElementConfig, userData interface{}) clay.Clay_Dimensions
 }
 
+// TextMetricsMeasurer is implemented by TextMeasurers that can also report the ascent of text, for use with
+// clay.Clay_SetMeasureTextMetricsFunction. The measurer returned by NewMeasurer implements it.
+type TextMetricsMeasurer interface {
+	TextMeasurer
+	// MeasureTextMetrics measures text like MeasureText and also reports its ascent.
+	MeasureTextMetrics(text clay.Clay_StringSlice, config *clay.Clay_TextElementConfig, userData interface{}) clay.Clay_TextMetrics
+}
+
 type measurer struct {
 	options *MeasurerOptions
 }
@@ -46,6 +54,10 @@ func NewMeasurer(opts ...MeasurerOption) TextMeasurer {
 }
 
 func (m *measurer) MeasureText(textToMeasureSlice clay.Clay_StringSlice, cfg *clay.Clay_TextElementConfig, userData interface{}) clay.Clay_Dimensions {
+	return m.MeasureTextMetrics(textToMeasureSlice, cfg, userData).Dimensions
+}
+
+func (m *measurer) MeasureTextMetrics(textToMeasureSlice clay.Clay_StringSlice, cfg *clay.Clay_TextElementConfig, userData interface{}) clay.Clay_TextMetrics {
 	textToMeasure := textToMeasureSlice.String()
 
 	gtx, ok := userData.(layout.Context)
@@ -95,6 +107,8 @@ func (m *measurer) MeasureText(textToMeasureSlice clay.Clay_StringSlice, cfg *cl
 	// Calculate bounds the same way as textIterator.processGlyph in Label
 	var bounds image.Rectangle
 	var first bool = true
+	// The baseline of the first line, the glyph Y coordinates are relative to it
+	var firstBaseline int
 
 	// Iterate through all glyphs until NextGlyph returns false (matching Label's iteration)
 	for g, ok := fontManager.GetShaper().NextGlyph(); ok; g, ok = fontManager.GetShaper().NextGlyph() {
@@ -107,6 +121,7 @@ func (m *measurer) MeasureText(textToMeasureSlice clay.Clay_StringSlice, cfg *cl
 		if first {
 			first = false
 			bounds = logicalBounds
+			firstBaseline = int(g.Y)
 		} else {
 			// Accumulate bounds by taking min/max - same as Label
 			bounds.Min.X = min(bounds.Min.X, logicalBounds.Min.X)
@@ -122,8 +137,11 @@ func (m *measurer) MeasureText(textToMeasureSlice clay.Clay_StringSlice, cfg *cl
 	// fmt.Printf("bounds: %+v, size: %+v\n", bounds, size)
 
 	// Convert to float32 dimensions
-	return clay.Clay_Dimensions{
-		Width:  float32(size.X),
-		Height: float32(size.Y),
+	return clay.Clay_TextMetrics{
+		Dimensions: clay.Clay_Dimensions{
+			Width:  float32(size.X),
+			Height: float32(size.Y),
+		},
+		Ascent: float32(firstBaseline - bounds.Min.Y),
 	}
 }
@@ -24,6 +24,9 @@ func RenderRectangleWithBounds(ops *op.Ops, cmd clay.Clay_RenderCommand) error {
 
 	rectangleData := cmd.RenderData.Rectangle
 	boundingBox := cmd.BoundingBox
+	if clay.Clay_GradientIsSet(&rectangleData.BackgroundGradient) {
+		return RenderGradientRectangle(ops, boundingBox, cmd)
+	}
 	// Check if corner radius is needed
 	if IsCornerRadiusZero(rectangleData.CornerRadius) {
 		return RenderSimpleRectangle(ops, boundingBox, cmd)
@@ -67,7 +70,7 @@ func (s ShapedRect) Path(gtx layout.Context) clip.PathSpec {
 		return clip.PathSpec{}
 	}
 
-	// Use shapes as-is (simplified - no RTL handling for now)
+	// Clay corners are physical and already mirrored by clay in RTL layouts, so start corners are the left ones
 	corners := s.Shapes
 
 	// Calculate actual corner sizes
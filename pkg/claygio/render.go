@@ -23,6 +23,14 @@ func (r *Renderer) render(ops *op.Ops, renderCommand clay.Clay_RenderCommand) {
 		RenderScissorEnd(renderCommand)
 	case clay.CLAY_RENDER_COMMAND_TYPE_CUSTOM:
 		RenderCustom(renderCommand)
+	case clay.CLAY_RENDER_COMMAND_TYPE_TEXT_INPUT:
+		r.RenderTextInput(ops, renderCommand)
+	case clay.CLAY_RENDER_COMMAND_TYPE_SHADOW:
+		RenderShadow(ops, renderCommand)
+	case clay.CLAY_RENDER_COMMAND_TYPE_TRANSFORM_START:
+		r.RenderTransformStart(ops, renderCommand)
+	case clay.CLAY_RENDER_COMMAND_TYPE_TRANSFORM_END:
+		r.RenderTransformEnd()
 	}
 }
 
@@ -7,6 +7,7 @@ import (
 
 type RendererOptions struct {
 	FontManager *FontManager
+	TextInputs  *TextInputs
 }
 
 type RendererOption func(*RendererOptions)
@@ -17,24 +18,47 @@ func RendererWithFontManager(fontManager *FontManager) RendererOption {
 	}
 }
 
+// RendererWithTextInputs sets the store whose text inputs are rendered for CLAY_RENDER_COMMAND_TYPE_TEXT_INPUT
+// commands. The same store must be used to update the inputs, see TextInput.Update.
+func RendererWithTextInputs(textInputs *TextInputs) RendererOption {
+	return func(o *RendererOptions) {
+		o.TextInputs = textInputs
+	}
+}
+
 type Renderer struct {
 	fontManager *FontManager
+	textInputs  *TextInputs
+	// The transforms that have been started and not ended yet, innermost last.
+	transforms []transformState
 }
 
 func NewRenderer(opts ...RendererOption) *Renderer {
 	options := &RendererOptions{
 		FontManager: NewFontManager(),
+		TextInputs:  NewTextInputs(),
 	}
 	for _, opt := range opts {
 		opt(options)
 	}
 	return &Renderer{
 		fontManager: options.FontManager,
+		textInputs:  options.TextInputs,
 	}
 }
 
 func (r *Renderer) Render(ops *op.Ops, commands []clay.Clay_RenderCommand) {
+	r.transforms = r.transforms[:0]
 	for _, command := range commands {
 		r.render(ops, command)
 	}
+	// Commands may be missing when clay ran out of space for them, so don't leak transforms into the rest of the frame
+	for len(r.transforms) > 0 {
+		r.RenderTransformEnd()
+	}
+}
+
+// TextInputs returns the store of text input state used by the renderer.
+func (r *Renderer) TextInputs() *TextInputs {
+	return r.textInputs
 }
@@ -0,0 +1,97 @@
+package claygio
+
+import (
+	"math"
+
+	"gioui.org/f32"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"github.com/zodimo/clay-go/clay"
+)
+
+// The number of layers that blurred shadows are approximated with.
+const shadowBlurLayers = 8
+
+// RenderShadow approximates a blurred shadow by layering translucent rounded rectangles, which are grown from half
+// the blur radius inside of the shadow's edge to half the blur radius outside of it. Shadows cast behind the element
+// aren't cut out underneath it, so they show through translucent backgrounds.
+func RenderShadow(ops *op.Ops, cmd clay.Clay_RenderCommand) {
+	spec := cmd.RenderData.Shadow
+	bounds := cmd.BoundingBox
+	layers := 1
+	if spec.BlurRadius > 0 {
+		layers = shadowBlurLayers
+	}
+	// Stacked layers add up to the color of the shadow where all of them overlap
+	layerColor := spec.Color
+	layerColor.A = 1 - float32(math.Pow(float64(1-min(max(spec.Color.A, 0), 1)), 1/float64(layers)))
+	gtx := layout.Context{Ops: ops}
+
+	if !spec.Inset {
+		shadowBounds := bounds
+		shadowBounds.X += spec.Offset.X
+		shadowBounds.Y += spec.Offset.Y
+		for layer := 0; layer < layers; layer++ {
+			grow := spec.Spread + shadowLayerGrowth(spec.BlurRadius, layer, layers)
+			if bounds.Width+grow*2 <= 0 || bounds.Height+grow*2 <= 0 {
+				continue
+			}
+			clipOp := clip.Outline{Path: shadowShape(shadowBounds, spec.CornerRadius, grow).Path(gtx)}.Op().Push(ops)
+			paint.ColorOp{Color: ClayToGioColor(layerColor)}.Add(ops)
+			paint.PaintOp{}.Add(ops)
+			clipOp.Pop()
+		}
+		return
+	}
+
+	// Inset shadows cover the element outside of a hole, drawn as a stroke around the hole that is wide enough to
+	// reach every edge of the element
+	elementClip := clip.Outline{Path: shadowShape(bounds, spec.CornerRadius, 0).Path(gtx)}.Op().Push(ops)
+	defer elementClip.Pop()
+	holeBounds := bounds
+	holeBounds.X += spec.Offset.X
+	holeBounds.Y += spec.Offset.Y
+	strokeWidth := bounds.Width + bounds.Height + float32(math.Abs(float64(spec.Offset.X))+math.Abs(float64(spec.Offset.Y))) + float32(math.Abs(float64(spec.Spread))) + spec.BlurRadius
+	for layer := 0; layer < layers; layer++ {
+		shrink := spec.Spread + shadowLayerGrowth(spec.BlurRadius, layer, layers)
+		paint.ColorOp{Color: ClayToGioColor(layerColor)}.Add(ops)
+		if holeBounds.Width-shrink*2 <= 0 || holeBounds.Height-shrink*2 <= 0 {
+			paint.PaintOp{}.Add(ops)
+			continue
+		}
+		// The inside edge of the stroke follows the hole
+		stroke := shadowShape(holeBounds, spec.CornerRadius, -shrink)
+		stroke.Offset += strokeWidth / 2
+		stroke.Shapes = growCornerShapes(stroke.Shapes, strokeWidth/2)
+		clipOp := clip.Stroke{Path: stroke.Path(gtx), Width: strokeWidth}.Op().Push(ops)
+		paint.PaintOp{}.Add(ops)
+		clipOp.Pop()
+	}
+}
+
+// Returns how far a layer of a blurred shadow is grown past the edge of the shadow.
+func shadowLayerGrowth(blurRadius float32, layer int, layers int) float32 {
+	return blurRadius * ((float32(layer)+0.5)/float32(layers) - 0.5)
+}
+
+// Grows the rounded corners of shapes by grow, keeping square corners square.
+func growCornerShapes(shapes CornerShapes, grow float32) CornerShapes {
+	for _, shape := range []*CornerShape{&shapes.TopStart, &shapes.TopEnd, &shapes.BottomStart, &shapes.BottomEnd} {
+		if shape.Size > 0 {
+			shape.Size = max(shape.Size+grow, 0)
+		}
+	}
+	return shapes
+}
+
+// Returns the rounded rectangle of bounds grown by grow on every side, with corners that grow along with it.
+func shadowShape(bounds clay.Clay_BoundingBox, cornerRadius clay.Clay_CornerRadius, grow float32) ShapedRect {
+	return ShapedRect{
+		MinPoint: f32.Pt(bounds.X, bounds.Y),
+		MaxPoint: f32.Pt(bounds.X+bounds.Width, bounds.Y+bounds.Height),
+		Offset:   grow,
+		Shapes:   growCornerShapes(MapClayCornerRadius(cornerRadius), grow),
+	}
+}
@@ -0,0 +1,595 @@
+package claygio
+
+import (
+	"image"
+	"image/color"
+	"io"
+	"math"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"gioui.org/f32"
+	"gioui.org/gesture"
+	"gioui.org/io/clipboard"
+	"gioui.org/io/event"
+	"gioui.org/io/key"
+	"gioui.org/io/pointer"
+	"gioui.org/io/transfer"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	"github.com/zodimo/clay-go/clay"
+)
+
+const (
+	textInputBlinksPerSecond  = 1
+	textInputMaxBlinkDuration = 10 * time.Second
+)
+
+// Replaces each newline in text entered into SingleLine inputs with a space.
+var singleLineReplacer = strings.NewReplacer("\r\n", " ", "\r", " ", "\n", " ")
+
+// TextInputs keeps the editing state of clay.CLAY_TEXT_INPUT elements across frames, keyed by element ID.
+// Pass it to the renderer with RendererWithTextInputs, so that the renderer can draw the selection and caret of each
+// input and register it for input events.
+type TextInputs struct {
+	// The color the selected text is highlighted with.
+	SelectionColor color.NRGBA
+	// The width of the caret in pixels.
+	CaretWidth float32
+
+	inputs map[uint32]*TextInput
+}
+
+func NewTextInputs() *TextInputs {
+	return &TextInputs{
+		SelectionColor: color.NRGBA{R: 0x40, G: 0x80, B: 0xff, A: 0x60},
+		CaretWidth:     1,
+		inputs:         map[uint32]*TextInput{},
+	}
+}
+
+// Get returns the state of the text input with the provided ID, creating it the first time the ID is used.
+func (t *TextInputs) Get(elementId clay.Clay_ElementId) *TextInput {
+	input, ok := t.inputs[elementId.Id]
+	if !ok {
+		input = &TextInput{elementId: elementId}
+		t.inputs[elementId.Id] = input
+	}
+	return input
+}
+
+// Remove drops the state of the text input with the provided ID, e.g. once the input is no longer declared.
+func (t *TextInputs) Remove(elementId clay.Clay_ElementId) {
+	delete(t.inputs, elementId.Id)
+}
+
+// TextInput is the editing state of a single clay.CLAY_TEXT_INPUT element. Each frame, call Update before declaring
+// the layout, then declare the input with the current Text:
+//
+//	input := textInputs.Get(clay.CLAY_ID("name"))
+//	input.Update(gtx)
+//	clay.CLAY_TEXT_INPUT(clay.CLAY_ID("name"), input.Text())
+//
+// Update handles pointer and keyboard input, the clipboard, and the input method protocol used for IME composition.
+// Positions within the text are resolved against the layout of the previous frame.
+type TextInput struct {
+	// SingleLine inputs call OnSubmit when Enter is pressed instead of inserting a newline, and replace newlines in
+	// pasted and composed text with spaces.
+	SingleLine bool
+	// ReadOnly inputs can still be focused, selected and copied from.
+	ReadOnly bool
+	// Called with the text of a SingleLine input when Enter is pressed.
+	OnSubmit func(text string)
+	// The kind of on-screen keyboard to show.
+	InputHint key.InputHint
+
+	elementId clay.Clay_ElementId
+	text      string
+	// The selection as byte offsets into text. The caret is at end, and start is where the selection was extended from.
+	start int
+	end   int
+
+	focused    bool
+	showCaret  bool
+	blinkStart time.Time
+	dragging   bool
+	clicker    gesture.Click
+	dragger    gesture.Drag
+
+	// The state shared with the input method, with ranges in runes.
+	ime struct {
+		selection key.Range
+		caret     key.Caret
+		snippet   key.Snippet
+	}
+}
+
+// Text returns the current text of the input.
+func (t *TextInput) Text() string {
+	return t.text
+}
+
+// SetText replaces the text of the input, keeping the selection within it.
+func (t *TextInput) SetText(text string) {
+	t.text = text
+	t.SetSelection(t.start, t.end)
+}
+
+// Selection returns the selection as byte offsets into the text. The caret is at end, which may be before start.
+func (t *TextInput) Selection() (start, end int) {
+	return t.start, t.end
+}
+
+// SetSelection selects the text between the byte offsets start and end, and places the caret at end.
+func (t *TextInput) SetSelection(start, end int) {
+	t.start = t.clampOffset(start)
+	t.end = t.clampOffset(end)
+}
+
+// SelectedText returns the selected part of the text.
+func (t *TextInput) SelectedText() string {
+	start, end := t.orderedSelection()
+	return t.text[start:end]
+}
+
+// Focused returns true if the input had keyboard focus during the last Update.
+func (t *TextInput) Focused() bool {
+	return t.focused
+}
+
+// Update handles the input events of the text input since the last frame, and returns true if its text was changed.
+func (t *TextInput) Update(gtx layout.Context) bool {
+	previousText := t.text
+	t.processPointer(gtx)
+	t.processKeys(gtx)
+	t.focused = gtx.Focused(t)
+	t.updateCaretBlink(gtx)
+	if t.focused {
+		t.updateInputMethod(gtx)
+	}
+	return t.text != previousText
+}
+
+func (t *TextInput) processPointer(gtx layout.Context) {
+	for {
+		ev, ok := t.clicker.Update(gtx.Source)
+		if !ok {
+			break
+		}
+		if !(ev.Kind == gesture.KindPress && ev.Source == pointer.Mouse) && !(ev.Kind == gesture.KindClick && ev.Source != pointer.Mouse) {
+			continue
+		}
+		gtx.Execute(key.FocusCmd{Tag: t})
+		if !t.ReadOnly {
+			gtx.Execute(key.SoftKeyboardCmd{Show: true})
+		}
+		t.blinkStart = gtx.Now
+		offset := t.offsetAt(f32.Pt(float32(ev.Position.X), float32(ev.Position.Y)))
+		if ev.Modifiers.Contain(key.ModShift) {
+			t.end = offset
+		} else {
+			t.start, t.end = offset, offset
+		}
+		t.dragging = true
+		switch {
+		case ev.NumClicks == 2:
+			t.start, t.end = t.wordStart(offset), t.wordEnd(offset)
+			t.dragging = false
+		case ev.NumClicks >= 3:
+			t.start, t.end = t.lineStart(offset), t.lineEnd(offset)
+			t.dragging = false
+		}
+	}
+	for {
+		ev, ok := t.dragger.Update(gtx.Metric, gtx.Source, gesture.Both)
+		if !ok {
+			break
+		}
+		if !t.dragging || ev.Source != pointer.Mouse || (ev.Kind != pointer.Drag && ev.Kind != pointer.Release) {
+			continue
+		}
+		t.blinkStart = gtx.Now
+		t.end = t.offsetAt(ev.Position)
+		if ev.Kind == pointer.Release {
+			t.dragging = false
+		}
+	}
+}
+
+func (t *TextInput) processKeys(gtx layout.Context) {
+	filters := []event.Filter{
+		key.FocusFilter{Target: t},
+		transfer.TargetFilter{Target: t, Type: "application/text"},
+		key.Filter{Focus: t, Name: key.NameEnter, Optional: key.ModShift},
+		key.Filter{Focus: t, Name: key.NameReturn, Optional: key.ModShift},
+		key.Filter{Focus: t, Name: "C", Required: key.ModShortcut},
+		key.Filter{Focus: t, Name: "V", Required: key.ModShortcut},
+		key.Filter{Focus: t, Name: "X", Required: key.ModShortcut},
+		key.Filter{Focus: t, Name: "A", Required: key.ModShortcut},
+		key.Filter{Focus: t, Name: key.NameDeleteBackward, Optional: key.ModShortcutAlt | key.ModShift},
+		key.Filter{Focus: t, Name: key.NameDeleteForward, Optional: key.ModShortcutAlt | key.ModShift},
+		key.Filter{Focus: t, Name: key.NameHome, Optional: key.ModShortcut | key.ModShift},
+		key.Filter{Focus: t, Name: key.NameEnd, Optional: key.ModShortcut | key.ModShift},
+		key.Filter{Focus: t, Name: key.NameLeftArrow, Optional: key.ModShortcutAlt | key.ModShift},
+		key.Filter{Focus: t, Name: key.NameRightArrow, Optional: key.ModShortcutAlt | key.ModShift},
+		key.Filter{Focus: t, Name: key.NameUpArrow, Optional: key.ModShift},
+		key.Filter{Focus: t, Name: key.NameDownArrow, Optional: key.ModShift},
+	}
+	for {
+		ev, ok := gtx.Event(filters...)
+		if !ok {
+			break
+		}
+		t.blinkStart = gtx.Now
+		switch ev := ev.(type) {
+		case key.FocusEvent:
+			// Start over with the input method
+			t.ime.selection = key.Range{}
+			t.ime.caret = key.Caret{}
+			t.ime.snippet = key.Snippet{}
+			if ev.Focus && !t.ReadOnly {
+				gtx.Execute(key.SoftKeyboardCmd{Show: true})
+			}
+		case key.Event:
+			if ev.State == key.Press {
+				t.command(gtx, ev)
+			}
+		case key.EditEvent:
+			// Sent by the input method, including while composing
+			if !t.ReadOnly {
+				t.edit(ev)
+			}
+		case key.SnippetEvent:
+			t.ime.snippet.Range = key.Range(ev)
+		case key.SelectionEvent:
+			t.SetSelection(t.byteOffset(ev.Start), t.byteOffset(ev.End))
+		case transfer.DataEvent:
+			// The second half of a paste started with Shortcut-V
+			content, err := io.ReadAll(ev.Open())
+			if err == nil && !t.ReadOnly {
+				t.insert(string(content))
+			}
+		}
+	}
+}
+
+func (t *TextInput) command(gtx layout.Context, ev key.Event) {
+	extend := ev.Modifiers.Contain(key.ModShift)
+	byWord := ev.Modifiers.Contain(key.ModShortcutAlt)
+	if ev.Modifiers.Contain(key.ModShortcut) {
+		switch ev.Name {
+		case "C", "X":
+			if selected := t.SelectedText(); selected != "" {
+				gtx.Execute(clipboard.WriteCmd{Type: "application/text", Data: io.NopCloser(strings.NewReader(selected))})
+				if ev.Name == "X" && !t.ReadOnly {
+					t.insert("")
+				}
+			}
+		case "V":
+			if !t.ReadOnly {
+				gtx.Execute(clipboard.ReadCmd{Tag: t})
+			}
+		case "A":
+			t.start, t.end = 0, len(t.text)
+		case key.NameHome:
+			t.moveTo(0, extend)
+		case key.NameEnd:
+			t.moveTo(len(t.text), extend)
+		}
+		return
+	}
+	switch ev.Name {
+	case key.NameReturn, key.NameEnter:
+		if t.SingleLine {
+			if t.OnSubmit != nil {
+				t.OnSubmit(t.text)
+			}
+		} else if !t.ReadOnly {
+			t.insert("\n")
+		}
+	case key.NameDeleteBackward, key.NameDeleteForward:
+		if t.ReadOnly {
+			break
+		}
+		if t.start == t.end {
+			if ev.Name == key.NameDeleteBackward {
+				t.start = t.previousOffset(t.end, byWord)
+			} else {
+				t.start = t.nextOffset(t.end, byWord)
+			}
+		}
+		t.insert("")
+	case key.NameLeftArrow:
+		if start, end := t.orderedSelection(); start != end && !extend && !byWord {
+			t.moveTo(start, false)
+		} else {
+			t.moveTo(t.previousOffset(t.end, byWord), extend)
+		}
+	case key.NameRightArrow:
+		if start, end := t.orderedSelection(); start != end && !extend && !byWord {
+			t.moveTo(end, false)
+		} else {
+			t.moveTo(t.nextOffset(t.end, byWord), extend)
+		}
+	case key.NameUpArrow, key.NameDownArrow:
+		caret, found := clay.Clay_GetCaretRect(t.elementId, int32(t.end))
+		if !found {
+			break
+		}
+		y := caret.Y - caret.Height/2
+		if ev.Name == key.NameDownArrow {
+			y = caret.Y + caret.Height*3/2
+		}
+		t.moveTo(t.offsetAt(f32.Pt(caret.X, y)), extend)
+	case key.NameHome:
+		t.moveTo(t.lineStart(t.end), extend)
+	case key.NameEnd:
+		t.moveTo(t.lineEnd(t.end), extend)
+	}
+}
+
+// Moves the caret to the byte offset, extending the selection to it or clearing it.
+func (t *TextInput) moveTo(offset int, extend bool) {
+	t.end = t.clampOffset(offset)
+	if !extend {
+		t.start = t.end
+	}
+}
+
+// Replaces the text between the byte offsets start and end with text, and places the caret after it.
+func (t *TextInput) replace(start, end int, text string) {
+	if start > end {
+		start, end = end, start
+	}
+	start, end = t.clampOffset(start), t.clampOffset(end)
+	if t.SingleLine {
+		text = singleLineReplacer.Replace(text)
+	}
+	t.text = t.text[:start] + text + t.text[end:]
+	t.start = start + len(text)
+	t.end = t.start
+}
+
+// Applies an edit of the input method. On-screen keyboards send Enter as an edit of a single newline, which submits
+// SingleLine inputs like the Enter key does.
+func (t *TextInput) edit(ev key.EditEvent) {
+	if t.SingleLine && (ev.Text == "\n" || ev.Text == "\r\n") {
+		if t.OnSubmit != nil {
+			t.OnSubmit(t.text)
+		}
+		return
+	}
+	t.replace(t.byteOffset(ev.Range.Start), t.byteOffset(ev.Range.End), ev.Text)
+}
+
+// Replaces the selection with text.
+func (t *TextInput) insert(text string) {
+	t.replace(t.start, t.end, text)
+}
+
+func (t *TextInput) orderedSelection() (int, int) {
+	if t.start > t.end {
+		return t.end, t.start
+	}
+	return t.start, t.end
+}
+
+func (t *TextInput) clampOffset(offset int) int {
+	return max(0, min(offset, len(t.text)))
+}
+
+// Returns the byte offset of the text closest to the layout position, using the layout of the last frame.
+func (t *TextInput) offsetAt(position f32.Point) int {
+	offset, found := clay.Clay_GetTextPositionAt(t.elementId, clay.Clay_Vector2{X: position.X, Y: position.Y})
+	if !found {
+		return len(t.text)
+	}
+	return t.clampOffset(int(offset))
+}
+
+// Returns the byte offset of the grapheme cluster, or word, before offset.
+func (t *TextInput) previousOffset(offset int, byWord bool) int {
+	if byWord {
+		return t.wordStart(t.skipSpaceBackward(offset))
+	}
+	return int(clay.Clay_PreviousGraphemeBoundary(t.text, int32(offset)))
+}
+
+// Returns the byte offset of the grapheme cluster, or word, after offset.
+func (t *TextInput) nextOffset(offset int, byWord bool) int {
+	if byWord {
+		return t.wordEnd(t.skipSpaceForward(offset))
+	}
+	return int(clay.Clay_NextGraphemeBoundary(t.text, int32(offset)))
+}
+
+func (t *TextInput) skipSpaceBackward(offset int) int {
+	for offset > 0 {
+		r, size := utf8.DecodeLastRuneInString(t.text[:offset])
+		if !unicode.IsSpace(r) {
+			break
+		}
+		offset -= size
+	}
+	return offset
+}
+
+func (t *TextInput) skipSpaceForward(offset int) int {
+	for offset < len(t.text) {
+		r, size := utf8.DecodeRuneInString(t.text[offset:])
+		if !unicode.IsSpace(r) {
+			break
+		}
+		offset += size
+	}
+	return offset
+}
+
+// Returns the start of the run of non-space characters around offset.
+func (t *TextInput) wordStart(offset int) int {
+	for offset > 0 {
+		r, size := utf8.DecodeLastRuneInString(t.text[:offset])
+		if unicode.IsSpace(r) {
+			break
+		}
+		offset -= size
+	}
+	return offset
+}
+
+// Returns the end of the run of non-space characters around offset.
+func (t *TextInput) wordEnd(offset int) int {
+	for offset < len(t.text) {
+		r, size := utf8.DecodeRuneInString(t.text[offset:])
+		if unicode.IsSpace(r) {
+			break
+		}
+		offset += size
+	}
+	return offset
+}
+
+// Returns the wrapped line of the last layout that holds the byte offset, if any.
+func (t *TextInput) lineAt(offset int) (clay.Clay_TextLine, bool) {
+	lines := clay.Clay_GetTextLines(t.elementId)
+	for i := len(lines) - 1; i >= 0; i-- {
+		if int(lines[i].StartOffset) <= offset || i == 0 {
+			return lines[i], true
+		}
+	}
+	return clay.Clay_TextLine{}, false
+}
+
+func (t *TextInput) lineStart(offset int) int {
+	if line, ok := t.lineAt(offset); ok {
+		return t.clampOffset(int(line.StartOffset))
+	}
+	return 0
+}
+
+func (t *TextInput) lineEnd(offset int) int {
+	if line, ok := t.lineAt(offset); ok {
+		return t.clampOffset(int(line.StartOffset + line.Length))
+	}
+	return len(t.text)
+}
+
+// Converts a rune offset used by the input method into a byte offset into the text.
+func (t *TextInput) byteOffset(runes int) int {
+	offset := 0
+	for ; runes > 0 && offset < len(t.text); runes-- {
+		_, size := utf8.DecodeRuneInString(t.text[offset:])
+		offset += size
+	}
+	return offset
+}
+
+// Converts a byte offset into the text into a rune offset used by the input method.
+func (t *TextInput) runeOffset(offset int) int {
+	return utf8.RuneCountInString(t.text[:t.clampOffset(offset)])
+}
+
+func (t *TextInput) updateCaretBlink(gtx layout.Context) {
+	t.showCaret = false
+	if !t.focused {
+		return
+	}
+	const timePerBlink = time.Second / textInputBlinksPerSecond
+	elapsed := gtx.Now.Sub(t.blinkStart)
+	blinking := elapsed < textInputMaxBlinkDuration
+	if blinking {
+		gtx.Execute(op.InvalidateCmd{At: gtx.Now.Add(timePerBlink/2 - elapsed%(timePerBlink/2))})
+	}
+	t.showCaret = !blinking || elapsed%timePerBlink < timePerBlink/2
+}
+
+// Tells the input method about the selection, the position of the caret and the text it asked for, so that it can
+// compose text and place its candidate window.
+func (t *TextInput) updateInputMethod(gtx layout.Context) {
+	selection := key.Range{Start: t.runeOffset(t.start), End: t.runeOffset(t.end)}
+	caret := t.ime.caret
+	if caretRect, found := clay.Clay_GetCaretRect(t.elementId, int32(t.end)); found {
+		caret = key.Caret{Pos: f32.Pt(caretRect.X, caretRect.Y+caretRect.Height), Ascent: caretRect.Height}
+	}
+	if selection != t.ime.selection || caret != t.ime.caret {
+		t.ime.selection = selection
+		t.ime.caret = caret
+		gtx.Execute(key.SelectionCmd{Tag: t, Range: selection, Caret: caret})
+	}
+
+	runeCount := utf8.RuneCountInString(t.text)
+	snippet := key.Snippet{Range: t.ime.snippet.Range}
+	snippet.Start = max(0, min(snippet.Start, runeCount))
+	snippet.End = max(snippet.Start, min(snippet.End, runeCount))
+	snippet.Text = t.text[t.byteOffset(snippet.Start):t.byteOffset(snippet.End)]
+	if snippet != t.ime.snippet {
+		t.ime.snippet = snippet
+		gtx.Execute(key.SnippetCmd{Tag: t, Snippet: snippet})
+	}
+}
+
+// RenderTextInput registers the input of a CLAY_RENDER_COMMAND_TYPE_TEXT_INPUT command for pointer and keyboard
+// events, and paints its selection and caret. The text itself is painted by the text commands that follow.
+func (r *Renderer) RenderTextInput(ops *op.Ops, renderCommand clay.Clay_RenderCommand) {
+	input, ok := r.textInputs.inputs[renderCommand.Id]
+	if !ok {
+		return
+	}
+	bounds := renderCommand.BoundingBox
+	area := clip.Rect(pixelRect(bounds.X, bounds.Y, bounds.X+bounds.Width, bounds.Y+bounds.Height)).Push(ops)
+	pointer.CursorText.Add(ops)
+	event.Op(ops, input)
+	key.InputHintOp{Tag: input, Hint: input.InputHint}.Add(ops)
+	input.clicker.Add(ops)
+	input.dragger.Add(ops)
+	area.Pop()
+
+	if input.focused && input.start != input.end {
+		start, end := input.orderedSelection()
+		for _, line := range clay.Clay_GetTextLines(input.elementId) {
+			lineStart := int(line.StartOffset)
+			lineEnd := lineStart + int(line.Length)
+			if start >= lineEnd || end <= lineStart {
+				continue
+			}
+			box := line.BoundingBox
+			left := box.X
+			if start > lineStart {
+				caret, _ := clay.Clay_GetCaretRect(input.elementId, int32(start))
+				left = caret.X
+			}
+			right := box.X + box.Width
+			if end < lineEnd {
+				caret, _ := clay.Clay_GetCaretRect(input.elementId, int32(end))
+				right = caret.X
+			}
+			fillRect(ops, pixelRect(left, box.Y, right, box.Y+box.Height), r.textInputs.SelectionColor)
+		}
+	}
+
+	if input.showCaret && !input.ReadOnly {
+		if caret, found := clay.Clay_GetCaretRect(input.elementId, int32(input.end)); found {
+			fillRect(ops, pixelRect(caret.X, caret.Y, caret.X+r.textInputs.CaretWidth, caret.Y+caret.Height), ClayToGioColor(renderCommand.RenderData.Text.TextColor))
+		}
+	}
+}
+
+// Returns the smallest pixel rectangle that covers the provided edges.
+func pixelRect(left, top, right, bottom float32) image.Rectangle {
+	return image.Rect(
+		int(math.Floor(float64(left))),
+		int(math.Floor(float64(top))),
+		int(math.Ceil(float64(right))),
+		int(math.Ceil(float64(bottom))),
+	)
+}
+
+func fillRect(ops *op.Ops, rect image.Rectangle, fillColor color.NRGBA) {
+	defer clip.Rect(rect).Push(ops).Pop()
+	paint.ColorOp{Color: fillColor}.Add(ops)
+	paint.PaintOp{}.Add(ops)
+}
@@ -0,0 +1,163 @@
+package claygio
+
+import (
+	"testing"
+
+	"gioui.org/io/key"
+)
+
+const testEmojiFamily = "\U0001F468‍\U0001F469‍\U0001F467" // Man, woman and girl joined by zero width joiners, 18 bytes
+
+func TestTextInputReplace(t *testing.T) {
+	tests := []struct {
+		name       string
+		text       string
+		start      int
+		end        int
+		insert     string
+		singleLine bool
+		expected   string
+		caret      int
+	}{
+		{"insert at caret", "hello", 5, 5, " world", false, "hello world", 11},
+		{"replace reversed selection", "hello world", 11, 6, "there", false, "hello there", 11},
+		{"delete selection", "hello world", 5, 11, "", false, "hello", 5},
+		{"offsets are clamped", "abc", -2, 10, "x", false, "x", 1},
+		{"newlines are kept", "", 0, 0, "a\nb", false, "a\nb", 3},
+		{"single line replaces newlines", "", 0, 0, "a\nb\r\nc\rd", true, "a b c d", 7},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			input := &TextInput{text: test.text, SingleLine: test.singleLine}
+			input.replace(test.start, test.end, test.insert)
+			if input.text != test.expected {
+				t.Errorf("expected text %q, got %q", test.expected, input.text)
+			}
+			if input.start != test.caret || input.end != test.caret {
+				t.Errorf("expected the caret at %d, got selection %d-%d", test.caret, input.start, input.end)
+			}
+		})
+	}
+}
+
+func TestTextInputGraphemeMovement(t *testing.T) {
+	// "e" with a combining acute accent is 3 bytes, followed by "x" and an emoji ZWJ sequence
+	input := &TextInput{text: "éx" + testEmojiFamily}
+	tests := []struct {
+		offset   int
+		previous int
+		next     int
+	}{
+		{0, 0, 3},
+		{2, 0, 3},
+		{3, 0, 4},
+		{4, 3, 22},
+		{10, 4, 22},
+		{22, 4, 22},
+	}
+	for _, test := range tests {
+		if got := input.previousOffset(test.offset, false); got != test.previous {
+			t.Errorf("previous grapheme from %d: expected %d, got %d", test.offset, test.previous, got)
+		}
+		if got := input.nextOffset(test.offset, false); got != test.next {
+			t.Errorf("next grapheme from %d: expected %d, got %d", test.offset, test.next, got)
+		}
+	}
+}
+
+func TestTextInputWordMovement(t *testing.T) {
+	input := &TextInput{text: "hello  big world"}
+	tests := []struct {
+		offset    int
+		previous  int
+		next      int
+		wordStart int
+		wordEnd   int
+	}{
+		{0, 0, 5, 0, 5},
+		{5, 0, 10, 0, 5},
+		{6, 0, 10, 6, 6},
+		{8, 7, 10, 7, 10},
+		{11, 7, 16, 11, 16},
+		{16, 11, 16, 11, 16},
+	}
+	for _, test := range tests {
+		if got := input.previousOffset(test.offset, true); got != test.previous {
+			t.Errorf("previous word from %d: expected %d, got %d", test.offset, test.previous, got)
+		}
+		if got := input.nextOffset(test.offset, true); got != test.next {
+			t.Errorf("next word from %d: expected %d, got %d", test.offset, test.next, got)
+		}
+		if got := input.wordStart(test.offset); got != test.wordStart {
+			t.Errorf("word start from %d: expected %d, got %d", test.offset, test.wordStart, got)
+		}
+		if got := input.wordEnd(test.offset); got != test.wordEnd {
+			t.Errorf("word end from %d: expected %d, got %d", test.offset, test.wordEnd, got)
+		}
+	}
+}
+
+func TestTextInputRuneOffsets(t *testing.T) {
+	// Runes of 1, 2, 3, 4 and 1 bytes
+	input := &TextInput{text: "aé€😀b"}
+	tests := []struct {
+		runes int
+		bytes int
+	}{
+		{0, 0},
+		{1, 1},
+		{2, 3},
+		{3, 6},
+		{4, 10},
+		{5, 11},
+	}
+	for _, test := range tests {
+		if got := input.byteOffset(test.runes); got != test.bytes {
+			t.Errorf("byte offset of rune %d: expected %d, got %d", test.runes, test.bytes, got)
+		}
+		if got := input.runeOffset(test.bytes); got != test.runes {
+			t.Errorf("rune offset of byte %d: expected %d, got %d", test.bytes, test.runes, got)
+		}
+	}
+	// Offsets past the end are clamped to it
+	if got := input.byteOffset(10); got != 11 {
+		t.Errorf("expected rune 10 to be clamped to byte 11, got %d", got)
+	}
+	if got := input.runeOffset(20); got != 5 {
+		t.Errorf("expected byte 20 to be clamped to rune 5, got %d", got)
+	}
+}
+
+func TestTextInputEdit(t *testing.T) {
+	tests := []struct {
+		name       string
+		text       string
+		singleLine bool
+		edit       key.EditEvent
+		expected   string
+		submitted  bool
+	}{
+		{"ranges are in runes", "é€", false, key.EditEvent{Range: key.Range{Start: 1, End: 2}, Text: "x"}, "éx", false},
+		{"newline is inserted", "ab", false, key.EditEvent{Range: key.Range{Start: 2, End: 2}, Text: "\n"}, "ab\n", false},
+		{"single line replaces newlines", "ab", true, key.EditEvent{Range: key.Range{Start: 1, End: 1}, Text: "x\ny"}, "ax yb", false},
+		{"single line submits on enter", "ab", true, key.EditEvent{Range: key.Range{Start: 2, End: 2}, Text: "\n"}, "ab", true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			submitted := false
+			input := &TextInput{text: test.text, SingleLine: test.singleLine, OnSubmit: func(text string) {
+				submitted = true
+				if text != test.expected {
+					t.Errorf("expected %q to be submitted, got %q", test.expected, text)
+				}
+			}}
+			input.edit(test.edit)
+			if input.text != test.expected {
+				t.Errorf("expected text %q, got %q", test.expected, input.text)
+			}
+			if submitted != test.submitted {
+				t.Errorf("expected submitted to be %v, got %v", test.submitted, submitted)
+			}
+		})
+	}
+}
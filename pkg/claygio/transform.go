@@ -0,0 +1,41 @@
+package claygio
+
+import (
+	"gioui.org/f32"
+	"gioui.org/op"
+	"gioui.org/op/paint"
+	"github.com/zodimo/clay-go/clay"
+)
+
+// The state pushed for a CLAY_RENDER_COMMAND_TYPE_TRANSFORM_START command, popped again by the matching
+// CLAY_RENDER_COMMAND_TYPE_TRANSFORM_END command.
+type transformState struct {
+	transform op.TransformStack
+	opacity   paint.OpacityStack
+}
+
+// RenderTransformStart transforms and fades everything rendered until the matching RenderTransformEnd.
+func (r *Renderer) RenderTransformStart(ops *op.Ops, renderCommand clay.Clay_RenderCommand) {
+	spec := renderCommand.RenderData.Transform
+	bounds := renderCommand.BoundingBox
+	origin := f32.Pt(bounds.X+bounds.Width*spec.Origin.X, bounds.Y+bounds.Height*spec.Origin.Y)
+	transform := f32.Affine2D{}.
+		Scale(origin, f32.Pt(spec.Scale.X, spec.Scale.Y)).
+		Rotate(origin, spec.Rotation).
+		Offset(f32.Pt(spec.Translation.X, spec.Translation.Y))
+	r.transforms = append(r.transforms, transformState{
+		transform: op.Affine(transform).Push(ops),
+		opacity:   paint.PushOpacity(ops, spec.Opacity),
+	})
+}
+
+// RenderTransformEnd pops the most recently started transform. Unbalanced ends are ignored.
+func (r *Renderer) RenderTransformEnd() {
+	if len(r.transforms) == 0 {
+		return
+	}
+	state := r.transforms[len(r.transforms)-1]
+	r.transforms = r.transforms[:len(r.transforms)-1]
+	state.opacity.Pop()
+	state.transform.Pop()
+}
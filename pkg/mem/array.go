@@ -2,7 +2,6 @@ package mem
 
 import (
 	"fmt"
-	"unsafe"
 )
 
 type MemArray[T any] struct {
@@ -22,7 +21,7 @@ func (m *MemArray[T]) initHashMap() {
 		return
 	}
 	m.hashInitialised = true
-	for i := int32(0); i < m.Capacity()-2; i++ {
+	for i := int32(0); i < m.Capacity(); i++ {
 		m.Add(m.ZeroValue)
 	}
 
@@ -114,7 +113,7 @@ func (m *MemArray[T]) RemoveSwapback(index int32) T {
 
 func (m *MemArray[T]) Reset() {
 	if m.isHashmap {
-		for i := int32(0); i < m.Capacity()-2; i++ {
+		for i := int32(0); i < m.Capacity(); i++ {
 			m.Set(i, m.ZeroValue)
 		}
 	} else {
@@ -182,10 +181,10 @@ func MemArrayWithInitialLength[T any](length int32) MemArrayOption[T] {
 		o.InitialLength = length
 	}
 }
-func defaultMemArrayOptions[T any](size int) MemArrayOptions[T] {
+func defaultMemArrayOptions[T any]() MemArrayOptions[T] {
 	zero := new(T)
 	return MemArrayOptions[T]{
-		Arena:         NewArenaWithSizeUnsafe(size),
+		Arena:         nil,
 		IsHashmap:     false,
 		ZeroValue:     *zero,
 		ZeroValuePtr:  zero,
@@ -195,9 +194,10 @@ func defaultMemArrayOptions[T any](size int) MemArrayOptions[T] {
 
 func NewMemArray[T any](capacity int32, options ...MemArrayOption[T]) MemArray[T] {
 
-	sizeOfArray := int(capacity) * int(unsafe.Sizeof(new(T)))
-
-	opts := defaultMemArrayOptions[T](sizeOfArray)
+	if capacity <= 0 {
+		panic(fmt.Sprintf("NewMemArray capacity must be positive: %d", capacity))
+	}
+	opts := defaultMemArrayOptions[T]()
 	for _, option := range options {
 		option(&opts)
 	}
@@ -42,6 +42,20 @@ func TestNewMemArray(t *testing.T) {
 		}()
 	})
 
+	t.Run("hashmap array has a bucket for every index below its capacity", func(t *testing.T) {
+		capacity := int32(10)
+		arr := NewMemArray[int32](capacity, MemArrayWithIsHashmap[int32](), MemArrayWithZeroValue[int32](-1))
+
+		if arr.Length() != capacity {
+			t.Errorf("expected Length = %d, got %d", capacity, arr.Length())
+		}
+		arr.Set(capacity-1, 3)
+		arr.Reset()
+		if value := arr.GetValue(capacity - 1); value != -1 {
+			t.Errorf("expected the last bucket to be reset to -1, got %d", value)
+		}
+	})
+
 	// t.Run("creates array with different types", func(t *testing.T) {
 	// 	intArr := NewMemArray[int](5)
 	// 	if intArr.Capacity != 5 {